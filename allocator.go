@@ -0,0 +1,130 @@
+package gollect
+
+import (
+	"sync"
+)
+
+// NodeAllocator provides the nodes of node-based collections, such as List, so that nodes can be
+// reused instead of being allocated for every insertion and dropped for the GC on every removal.
+//
+// Allocate must return a zero-valued node. Free is called with a node the collection no longer
+// uses, and must not be called twice for the same node.
+type NodeAllocator[N any] interface {
+	Allocate() *N
+	Free(node *N)
+}
+
+// FreeListAllocator is a NodeAllocator that keeps freed nodes in a free list, and hands them out
+// again before allocating new ones.
+//
+// It is not safe for concurrent use, so it should only be shared by collections used from the
+// same goroutine.
+type FreeListAllocator[N any] struct {
+	free []*N
+}
+
+// NewFreeListAllocator creates a new empty FreeListAllocator instance.
+func NewFreeListAllocator[N any]() *FreeListAllocator[N] {
+	return &FreeListAllocator[N]{}
+}
+
+// Allocate returns a node from the free list, or a new node if the free list is empty.
+func (a *FreeListAllocator[N]) Allocate() *N {
+	if len(a.free) == 0 {
+		return new(N)
+	}
+	node := a.free[len(a.free)-1]
+	a.free[len(a.free)-1] = nil
+	a.free = a.free[:len(a.free)-1]
+	return node
+}
+
+// Free clears node and adds it to the free list.
+func (a *FreeListAllocator[N]) Free(node *N) {
+	var zero N
+	*node = zero
+	a.free = append(a.free, node)
+}
+
+// FreeCount returns the number of nodes in the free list.
+func (a *FreeListAllocator[N]) FreeCount() int {
+	return len(a.free)
+}
+
+// Trim drops the free list, so its nodes can be collected by the GC.
+func (a *FreeListAllocator[N]) Trim() {
+	a.free = nil
+}
+
+// PoolAllocator is a NodeAllocator backed by a sync.Pool. It is safe for concurrent use, and the
+// GC may drop pooled nodes when they are not needed.
+type PoolAllocator[N any] struct {
+	pool sync.Pool
+}
+
+// NewPoolAllocator creates a new PoolAllocator instance.
+func NewPoolAllocator[N any]() *PoolAllocator[N] {
+	return &PoolAllocator[N]{pool: sync.Pool{New: func() interface{} { return new(N) }}}
+}
+
+// Allocate returns a node from the pool, or a new node if the pool is empty.
+func (a *PoolAllocator[N]) Allocate() *N {
+	return a.pool.Get().(*N)
+}
+
+// Free clears node and returns it to the pool.
+func (a *PoolAllocator[N]) Free(node *N) {
+	var zero N
+	*node = zero
+	a.pool.Put(node)
+}
+
+// ArenaAllocator is a NodeAllocator that hands out nodes from slabs of slab_size nodes, so most
+// allocations cost nothing. Freed nodes are not reused. Instead, all the nodes are released at
+// once by Reset.
+//
+// It is not safe for concurrent use.
+type ArenaAllocator[N any] struct {
+	slab      []N
+	slab_size int
+	allocated int
+}
+
+// NewArenaAllocator creates a new ArenaAllocator instance that allocates slab_size nodes at a
+// time.
+func NewArenaAllocator[N any](slab_size int) *ArenaAllocator[N] {
+	if slab_size <= 0 {
+		panic("ERROR: NewArenaAllocator - slab size must be positive")
+	}
+	return &ArenaAllocator[N]{slab_size: slab_size}
+}
+
+// Allocate returns the next node of the current slab, starting a new slab if it is full.
+func (a *ArenaAllocator[N]) Allocate() *N {
+	if len(a.slab) == 0 {
+		a.slab = make([]N, a.slab_size)
+	}
+	node := &a.slab[0]
+	a.slab = a.slab[1:]
+	a.allocated++
+	return node
+}
+
+// Free does nothing, as the nodes of an ArenaAllocator are only released by Reset.
+func (a *ArenaAllocator[N]) Free(node *N) {
+}
+
+// Allocated returns the number of nodes allocated since the ArenaAllocator was created or Reset.
+func (a *ArenaAllocator[N]) Allocated() int {
+	return a.allocated
+}
+
+// Reset releases every node allocated so far, so the GC can collect their slabs once nothing
+// refers to them.
+//
+// Note, the collections using the ArenaAllocator still refer to its nodes, so they should be
+// dropped, not used, after a Reset.
+func (a *ArenaAllocator[N]) Reset() {
+	a.slab = nil
+	a.allocated = 0
+}
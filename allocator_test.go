@@ -0,0 +1,140 @@
+package gollect
+
+import (
+	"testing"
+)
+
+func TestListFreeListAllocator(t *testing.T) {
+	allocator := NewFreeListAllocator[ListNode[int]]()
+	l := NewListWithAllocator[int](allocator, 1, 2, 3)
+	first := l.front
+	l.PopFront()
+	if allocator.FreeCount() != 1 || first.data != 0 {
+		t.Fatalf("PopFront should return a cleared node to the free list")
+	}
+	l.PushBack(4)
+	if allocator.FreeCount() != 0 || l.back != first {
+		t.Fatalf("PushBack should reuse the freed node")
+	}
+	l.Clear()
+	if allocator.FreeCount() != 3 {
+		t.Fatalf("Clear should free 3 nodes, got %v", allocator.FreeCount())
+	}
+	copied := NewListFromList(NewListWithAllocator[int](allocator, 5, 6))
+	if copied.String() != "{5, 6}" || allocator.FreeCount() != 1 {
+		t.Fatalf("A copied List should not use the allocator of the original")
+	}
+	copied.Clear()
+	if allocator.FreeCount() != 1 {
+		t.Fatalf("A copied List should not free its nodes to the allocator of the original")
+	}
+}
+
+func TestListArenaAllocator(t *testing.T) {
+	allocator := NewArenaAllocator[ListNode[string]](16)
+	l := NewListWithAllocator[string](allocator)
+	for i := 0; i < 40; i++ {
+		l.PushFront("x")
+	}
+	l.Erase(20)
+	if l.Size() != 39 || allocator.Allocated() != 40 {
+		t.Fatalf("Arena should have allocated 40 nodes, got %v", allocator.Allocated())
+	}
+	allocator.Reset()
+	if allocator.Allocated() != 0 {
+		t.Fatalf("Reset should release every node")
+	}
+}
+
+func TestListPoolAllocatorDestruct(t *testing.T) {
+	Msgs = []string{}
+	l := NewListWithAllocator[DBool](NewPoolAllocator[ListNode[DBool]](), true, true, true)
+	l.Erase(1)
+	l.Clear()
+	if len(Msgs) != 3 {
+		t.Fatalf("Destruct method should have been called 3 times, got %v", len(Msgs))
+	}
+}
+
+func benchmarkListAllocator(b *testing.B, allocator NodeAllocator[ListNode[int]]) {
+	b.ReportAllocs()
+	l := NewListWithAllocator[int](allocator)
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 64; j++ {
+			l.PushBack(j)
+		}
+		for j := 0; j < 64; j++ {
+			l.PopFront()
+		}
+	}
+}
+
+func BenchmarkListAllocator(b *testing.B) {
+	b.Run("Default", func(b *testing.B) { benchmarkListAllocator(b, nil) })
+	b.Run("FreeList", func(b *testing.B) { benchmarkListAllocator(b, NewFreeListAllocator[ListNode[int]]()) })
+	b.Run("Pool", func(b *testing.B) { benchmarkListAllocator(b, NewPoolAllocator[ListNode[int]]()) })
+	b.Run("Arena", func(b *testing.B) { benchmarkListAllocator(b, NewArenaAllocator[ListNode[int]](1024)) })
+}
+
+func TestSkipListFreeListAllocator(t *testing.T) {
+	allocator := NewFreeListAllocator[SkipListNode[int, DBool]]()
+	s := NewSkipListWithAllocator[int, DBool](allocator)
+	for idx := 0; idx < 10; idx++ {
+		s.Put(idx, true)
+	}
+	Msgs = nil
+	s.Erase(3)
+	if allocator.FreeCount() != 1 || len(Msgs) != 1 {
+		t.Fatalf("Erase should destruct the value and free its node")
+	}
+	s.Put(3, true)
+	if allocator.FreeCount() != 0 || s.Size() != 10 {
+		t.Fatalf("Put should reuse the freed node")
+	}
+	copied := NewSkipListFromSkipList(s)
+	copied.Clear()
+	if allocator.FreeCount() != 0 {
+		t.Fatalf("A copied SkipList should not use the allocator of the original")
+	}
+	s.Clear()
+	if allocator.FreeCount() != 10 || len(Msgs) != 21 {
+		t.Fatalf("Clear should free 10 nodes, got %v", allocator.FreeCount())
+	}
+}
+
+func TestBTreeFreeListAllocator(t *testing.T) {
+	allocator := NewFreeListAllocator[BTreeNode[int, int]]()
+	b := NewBTreeWithAllocator[int, int](2, allocator)
+	for idx := 0; idx < 100; idx++ {
+		b.Put(idx, idx)
+	}
+	for idx := 0; idx < 100; idx += 2 {
+		b.Erase(idx)
+	}
+	if allocator.FreeCount() == 0 {
+		t.Fatalf("Erase should free the merged nodes")
+	}
+	freed := allocator.FreeCount()
+	for idx := 0; idx < 100; idx += 2 {
+		b.Put(idx, idx)
+	}
+	if allocator.FreeCount() >= freed {
+		t.Fatalf("Put should reuse the freed nodes")
+	}
+
+	clone := b.Clone()
+	freed = allocator.FreeCount()
+	b.Clear()
+	for idx := 0; idx < 100; idx++ {
+		b.Put(idx, -idx)
+	}
+	for idx := 0; idx < 100; idx++ {
+		if value, _ := clone.Get(idx); value != idx {
+			t.Fatalf("Nodes shared with a clone should not be freed, got %v for %v", value, idx)
+		}
+	}
+	clone.Clear()
+	if allocator.FreeCount() > freed {
+		t.Fatalf("A clone should not use the allocator of the original")
+	}
+}
@@ -0,0 +1,471 @@
+package gollect
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+const bitsetWordSize = 64
+
+// Bitset is a compact collection of bits, using one bit per flag instead of the one byte per
+// flag of an NVector[bool].
+//
+// A dynamic Bitset grows automatically when a bit past its end is set, and treats bits past its
+// end as cleared. A fixed Bitset panics when a bit past its end is set, cleared or flipped
+// instead.
+type Bitset struct {
+	words []uint64
+	size  int
+	fixed bool
+}
+
+func bitsetWords(size int) int {
+	return (size + bitsetWordSize - 1) / bitsetWordSize
+}
+
+// NewBitset creates a new empty dynamic Bitset, by value.
+func NewBitset() Bitset {
+	return Bitset{words: []uint64{}}
+}
+
+// NewBitsetWithSize creates a new dynamic Bitset with size cleared bits, by value.
+func NewBitsetWithSize(size int) Bitset {
+	if size < 0 {
+		panic("ERROR: Bitset - negative size")
+	}
+	return Bitset{words: make([]uint64, bitsetWords(size)), size: size}
+}
+
+// NewFixedBitset creates a new fixed Bitset with size cleared bits, by value.
+func NewFixedBitset(size int) Bitset {
+	b := NewBitsetWithSize(size)
+	b.fixed = true
+	return b
+}
+
+// NewBitsetFromBitset creates a new Bitset with the same bits as another, by value.
+func NewBitsetFromBitset(other Bitset) Bitset {
+	return Bitset{words: append([]uint64{}, other.words...), size: other.size, fixed: other.fixed}
+}
+
+// NewBitsetFromNVector creates a new dynamic Bitset with the bits set where other is true, by
+// value.
+func NewBitsetFromNVector(other NVector[bool]) Bitset {
+	b := NewBitsetWithSize(other.Size())
+	for idx, val := range other.data {
+		if val {
+			b.Set(idx)
+		}
+	}
+	return b
+}
+
+// MakeBitset creates a new empty dynamic Bitset instance.
+func MakeBitset() *Bitset {
+	b := NewBitset()
+	return &b
+}
+
+// MakeBitsetWithSize creates a new dynamic Bitset instance with size cleared bits.
+func MakeBitsetWithSize(size int) *Bitset {
+	b := NewBitsetWithSize(size)
+	return &b
+}
+
+// MakeFixedBitset creates a new fixed Bitset instance with size cleared bits.
+func MakeFixedBitset(size int) *Bitset {
+	b := NewFixedBitset(size)
+	return &b
+}
+
+// MakeBitsetFromBitset creates a new Bitset instance with the same bits as another.
+func MakeBitsetFromBitset(other Bitset) *Bitset {
+	b := NewBitsetFromBitset(other)
+	return &b
+}
+
+// MakeBitsetFromNVector creates a new dynamic Bitset instance with the bits set where other is
+// true.
+func MakeBitsetFromNVector(other NVector[bool]) *Bitset {
+	b := NewBitsetFromNVector(other)
+	return &b
+}
+
+// Size returns the number of bits in the Bitset.
+func (b *Bitset) Size() int {
+	return b.size
+}
+
+// IsEmpty returns true if the Bitset has no bits.
+func (b *Bitset) IsEmpty() bool {
+	return b.size == 0
+}
+
+// IsFixed returns true if the Bitset is fixed size.
+func (b *Bitset) IsFixed() bool {
+	return b.fixed
+}
+
+// Resize resizes the Bitset, clearing any bits past the new size.
+func (b *Bitset) Resize(new_size int) {
+	if new_size < 0 {
+		panic("ERROR: Bitset.Resize - negative new size")
+	}
+	words := bitsetWords(new_size)
+	if words > cap(b.words) {
+		tmp := make([]uint64, words, words+words/2)
+		copy(tmp, b.words)
+		b.words = tmp
+	} else if words > len(b.words) {
+		// The words past the length are always cleared when the Bitset shrinks.
+		b.words = b.words[:words]
+	} else {
+		for idx := words; idx < len(b.words); idx++ {
+			b.words[idx] = 0
+		}
+		b.words = b.words[:words]
+	}
+	b.size = new_size
+	b.trim()
+}
+
+// trim clears the unused bits of the last word.
+func (b *Bitset) trim() {
+	if rem := b.size % bitsetWordSize; rem != 0 {
+		b.words[len(b.words)-1] &= (uint64(1) << rem) - 1
+	}
+}
+
+// ensure makes sure index is within the Bitset, growing it if it is dynamic.
+func (b *Bitset) ensure(index int, method string) {
+	if index < 0 {
+		panic("ERROR: Bitset." + method + " - negative index")
+	}
+	if index >= b.size {
+		if b.fixed {
+			panic("ERROR: Bitset." + method + " - index out of range")
+		}
+		b.Resize(index + 1)
+	}
+}
+
+// Set sets the bit at index.
+func (b *Bitset) Set(index int) {
+	b.ensure(index, "Set")
+	b.words[index/bitsetWordSize] |= uint64(1) << (index % bitsetWordSize)
+}
+
+// SetTo sets the bit at index to value.
+func (b *Bitset) SetTo(index int, value bool) {
+	if value {
+		b.Set(index)
+	} else {
+		b.Clear(index)
+	}
+}
+
+// Clear clears the bit at index.
+func (b *Bitset) Clear(index int) {
+	if index < 0 {
+		panic("ERROR: Bitset.Clear - negative index")
+	}
+	if index >= b.size {
+		if b.fixed {
+			panic("ERROR: Bitset.Clear - index out of range")
+		}
+		return
+	}
+	b.words[index/bitsetWordSize] &^= uint64(1) << (index % bitsetWordSize)
+}
+
+// Flip inverts the bit at index.
+func (b *Bitset) Flip(index int) {
+	b.ensure(index, "Flip")
+	b.words[index/bitsetWordSize] ^= uint64(1) << (index % bitsetWordSize)
+}
+
+// Test returns true if the bit at index is set.
+func (b *Bitset) Test(index int) bool {
+	if index < 0 || index >= b.size {
+		return false
+	}
+	return b.words[index/bitsetWordSize]&(uint64(1)<<(index%bitsetWordSize)) != 0
+}
+
+// SetAll sets every bit in the Bitset.
+func (b *Bitset) SetAll() {
+	for idx := range b.words {
+		b.words[idx] = ^uint64(0)
+	}
+	b.trim()
+}
+
+// ClearAll clears every bit in the Bitset.
+func (b *Bitset) ClearAll() {
+	for idx := range b.words {
+		b.words[idx] = 0
+	}
+}
+
+// FlipAll inverts every bit in the Bitset.
+func (b *Bitset) FlipAll() {
+	for idx := range b.words {
+		b.words[idx] = ^b.words[idx]
+	}
+	b.trim()
+}
+
+// Count returns the number of set bits.
+func (b *Bitset) Count() int {
+	count := 0
+	for _, word := range b.words {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// Any returns true if any bit is set.
+func (b *Bitset) Any() bool {
+	for _, word := range b.words {
+		if word != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// None returns true if no bit is set.
+func (b *Bitset) None() bool {
+	return !b.Any()
+}
+
+// All returns true if every bit is set.
+func (b *Bitset) All() bool {
+	return b.Count() == b.size
+}
+
+// NextSet returns the index of the first set bit at or after from.
+func (b *Bitset) NextSet(from int) (index int, found bool) {
+	if from < 0 {
+		from = 0
+	}
+	if from >= b.size {
+		return -1, false
+	}
+	word_idx := from / bitsetWordSize
+	word := b.words[word_idx] >> (from % bitsetWordSize)
+	if word != 0 {
+		return from + bits.TrailingZeros64(word), true
+	}
+	for word_idx++; word_idx < len(b.words); word_idx++ {
+		if b.words[word_idx] != 0 {
+			return word_idx*bitsetWordSize + bits.TrailingZeros64(b.words[word_idx]), true
+		}
+	}
+	return -1, false
+}
+
+// NextClear returns the index of the first cleared bit at or after from, within the size of the
+// Bitset.
+func (b *Bitset) NextClear(from int) (index int, found bool) {
+	if from < 0 {
+		from = 0
+	}
+	if from >= b.size {
+		return -1, false
+	}
+	word_idx := from / bitsetWordSize
+	word := ^b.words[word_idx] >> (from % bitsetWordSize)
+	if word != 0 {
+		index = from + bits.TrailingZeros64(word)
+	} else {
+		index = -1
+		for word_idx++; word_idx < len(b.words); word_idx++ {
+			if b.words[word_idx] != ^uint64(0) {
+				index = word_idx*bitsetWordSize + bits.TrailingZeros64(^b.words[word_idx])
+				break
+			}
+		}
+	}
+	if index < 0 || index >= b.size {
+		return -1, false
+	}
+	return index, true
+}
+
+// Visit calls a function for the index of every set bit, in increasing order.
+func (b *Bitset) Visit(visitor CollectionVisitor[int]) {
+	break_out := false
+	for index, found := b.NextSet(0); found && !break_out; index, found = b.NextSet(index + 1) {
+		idx := index
+		visitor(&idx, &break_out)
+	}
+}
+
+// Equal returns true if both Bitsets have the same size and bits.
+func (b *Bitset) Equal(other Bitset) bool {
+	if b.size != other.size {
+		return false
+	}
+	for idx := range b.words {
+		if b.words[idx] != other.words[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// NotEqual returns true if the Bitsets differ in size or bits.
+func (b *Bitset) NotEqual(other Bitset) bool {
+	return !b.Equal(other)
+}
+
+// combine sets the bits of b to op applied to the bits of b and other, growing b to the size of
+// other if needed. Bits past the end of either Bitset are treated as cleared.
+func (b *Bitset) combine(other *Bitset, op func(left uint64, right uint64) uint64, method string) {
+	if other.size > b.size {
+		if b.fixed {
+			panic("ERROR: Bitset." + method + " - other Bitset is larger than fixed Bitset")
+		}
+		b.Resize(other.size)
+	}
+	for idx := range b.words {
+		var right uint64 = 0
+		if idx < len(other.words) {
+			right = other.words[idx]
+		}
+		b.words[idx] = op(b.words[idx], right)
+	}
+	b.trim()
+}
+
+// And returns a new Bitset with the bits set in both Bitsets.
+func (b *Bitset) And(other Bitset) Bitset {
+	ret := NewBitsetFromBitset(*b)
+	ret.AndWith(other)
+	return ret
+}
+
+// Or returns a new Bitset with the bits set in either Bitset.
+func (b *Bitset) Or(other Bitset) Bitset {
+	ret := NewBitsetFromBitset(*b)
+	ret.OrWith(other)
+	return ret
+}
+
+// Xor returns a new Bitset with the bits set in exactly one of the Bitsets.
+func (b *Bitset) Xor(other Bitset) Bitset {
+	ret := NewBitsetFromBitset(*b)
+	ret.XorWith(other)
+	return ret
+}
+
+// AndNot returns a new Bitset with the bits set in this Bitset but not in other.
+func (b *Bitset) AndNot(other Bitset) Bitset {
+	ret := NewBitsetFromBitset(*b)
+	ret.AndNotWith(other)
+	return ret
+}
+
+// AndWith clears the bits that are not set in other, in place.
+func (b *Bitset) AndWith(other Bitset) {
+	b.combine(&other, func(left uint64, right uint64) uint64 { return left & right }, "AndWith")
+}
+
+// OrWith sets the bits that are set in other, in place.
+func (b *Bitset) OrWith(other Bitset) {
+	b.combine(&other, func(left uint64, right uint64) uint64 { return left | right }, "OrWith")
+}
+
+// XorWith inverts the bits that are set in other, in place.
+func (b *Bitset) XorWith(other Bitset) {
+	b.combine(&other, func(left uint64, right uint64) uint64 { return left ^ right }, "XorWith")
+}
+
+// AndNotWith clears the bits that are set in other, in place.
+func (b *Bitset) AndNotWith(other Bitset) {
+	b.combine(&other, func(left uint64, right uint64) uint64 { return left &^ right }, "AndNotWith")
+}
+
+// Swap swaps the data of two Bitsets.
+func (b *Bitset) Swap(other *Bitset) {
+	*b, *other = *other, *b
+}
+
+// Clone returns a new Bitset with the same bits, that does not share storage with this one.
+func (b *Bitset) Clone() Bitset {
+	return NewBitsetFromBitset(*b)
+}
+
+// ToNVector returns an NVector[bool] with an element for every bit of the Bitset.
+func (b *Bitset) ToNVector() NVector[bool] {
+	v := NVector[bool]{data: make([]bool, b.size)}
+	b.Visit(func(index *int, break_out *bool) {
+		v.data[*index] = true
+	})
+	return v
+}
+
+// String returns a string representation of the Bitset, listing the indices of the set bits.
+func (b *Bitset) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	first := true
+	b.Visit(func(index *int, break_out *bool) {
+		if first {
+			fmt.Fprintf(&builder, "%v", *index)
+			first = false
+		} else {
+			fmt.Fprintf(&builder, ", %v", *index)
+		}
+	})
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
+
+// MarshalJSON encodes the Bitset as a JSON string of '0' and '1' characters, starting with the
+// bit at index 0.
+//
+// MarshalJSON has a value receiver, so that Bitset values and struct fields are encoded as well.
+func (b Bitset) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, b.size)
+	for idx := range buf {
+		if b.Test(idx) {
+			buf[idx] = '1'
+		} else {
+			buf[idx] = '0'
+		}
+	}
+	return json.Marshal(string(buf))
+}
+
+// UnmarshalJSON decodes a Bitset encoded by MarshalJSON.
+//
+// A fixed Bitset keeps its size, and fails if the encoded Bitset does not fit.
+func (b *Bitset) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	if b.fixed && len(str) > b.size {
+		return fmt.Errorf("gollect: Bitset.UnmarshalJSON - %v bits do not fit in a fixed Bitset of size %v", len(str), b.size)
+	}
+	decoded := NewBitsetWithSize(len(str))
+	for idx, c := range []byte(str) {
+		switch c {
+		case '1':
+			decoded.Set(idx)
+		case '0':
+		default:
+			return fmt.Errorf("gollect: Bitset.UnmarshalJSON - invalid character %q", c)
+		}
+	}
+	if b.fixed {
+		decoded.Resize(b.size)
+		decoded.fixed = true
+	}
+	*b = decoded
+	return nil
+}
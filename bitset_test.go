@@ -0,0 +1,124 @@
+package gollect
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBitsetSetClearFlip(t *testing.T) {
+	b := NewBitset()
+	b.Set(3)
+	b.Set(64)
+	b.Set(130)
+	if b.Size() != 131 {
+		t.Fatalf("Size should be 131, got %v", b.Size())
+	}
+	if !b.Test(64) || b.Test(65) || b.Test(1000) {
+		t.Fatalf("Test returned the wrong bits, got %v", b.String())
+	}
+	b.Flip(64)
+	b.Flip(5)
+	b.Clear(3)
+	b.Clear(1000)
+	if b.String() != "{5, 130}" || b.Count() != 2 {
+		t.Fatalf("Bitset should be {5, 130}, got %v", b.String())
+	}
+}
+
+func TestFixedBitset(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: Bitset.Set - index out of range" {
+			t.Fatalf("Should have panicked because out of range, got \"%v\"", result)
+		}
+	}()
+	b := NewFixedBitset(10)
+	b.Set(9)
+	b.Set(10)
+}
+
+func TestBitsetNext(t *testing.T) {
+	b := NewBitsetWithSize(200)
+	b.Set(70)
+	b.Set(199)
+	if idx, found := b.NextSet(0); !found || idx != 70 {
+		t.Fatalf("NextSet(0) should be 70, got %v", idx)
+	}
+	if idx, found := b.NextSet(71); !found || idx != 199 {
+		t.Fatalf("NextSet(71) should be 199, got %v", idx)
+	}
+	if _, found := b.NextSet(200); found {
+		t.Fatalf("NextSet(200) should not be found")
+	}
+	b.SetAll()
+	b.Clear(150)
+	if idx, found := b.NextClear(3); !found || idx != 150 {
+		t.Fatalf("NextClear(3) should be 150, got %v", idx)
+	}
+	if _, found := b.NextClear(151); found {
+		t.Fatalf("NextClear(151) should not be found")
+	}
+	if b.Count() != 199 {
+		t.Fatalf("Count should be 199, got %v", b.Count())
+	}
+}
+
+func TestBitsetOperations(t *testing.T) {
+	a := NewBitsetFromNVector(NewNVectorFromData(true, true, false, false))
+	b := NewBitsetFromNVector(NewNVectorFromData(true, false, true, false, false, true))
+	if and := a.And(b); and.String() != "{0}" || and.Size() != 6 {
+		t.Fatalf("And should be {0} with size 6, got %v", and.String())
+	}
+	if or := a.Or(b); or.String() != "{0, 1, 2, 5}" {
+		t.Fatalf("Or should be {0, 1, 2, 5}, got %v", or.String())
+	}
+	if xor := a.Xor(b); xor.String() != "{1, 2, 5}" {
+		t.Fatalf("Xor should be {1, 2, 5}, got %v", xor.String())
+	}
+	if andNot := a.AndNot(b); andNot.String() != "{1}" {
+		t.Fatalf("AndNot should be {1}, got %v", andNot.String())
+	}
+	a.OrWith(b)
+	if a.Count() != 4 {
+		t.Fatalf("OrWith should leave 4 bits set, got %v", a.String())
+	}
+	if v := a.ToNVector(); v.String() != "{true, true, true, false, false, true}" {
+		t.Fatalf("ToNVector returned %v", v.String())
+	}
+}
+
+func TestBitsetJSON(t *testing.T) {
+	b := NewBitsetWithSize(5)
+	b.Set(1)
+	b.Set(4)
+	data, err := json.Marshal(&b)
+	if err != nil || string(data) != "\"01001\"" {
+		t.Fatalf("Marshal should return \"01001\", got %s (%v)", data, err)
+	}
+	var decoded Bitset
+	if err := json.Unmarshal(data, &decoded); err != nil || !decoded.Equal(b) {
+		t.Fatalf("Unmarshal should return %v, got %v (%v)", b.String(), decoded.String(), err)
+	}
+	if err := json.Unmarshal([]byte("\"012\""), &decoded); err == nil {
+		t.Fatalf("Unmarshal should fail on invalid characters")
+	}
+}
+
+func TestBitsetJSONValue(t *testing.T) {
+	b := NewBitsetWithSize(3)
+	b.Set(0)
+	if data, err := json.Marshal(b); err != nil || string(data) != "\"100\"" {
+		t.Fatalf("Marshal of a value should return \"100\", got %s (%v)", data, err)
+	}
+	type holder struct {
+		Bits Bitset
+	}
+	data, err := json.Marshal(holder{Bits: b})
+	if err != nil || string(data) != "{\"Bits\":\"100\"}" {
+		t.Fatalf("Marshal of a struct field should return {\"Bits\":\"100\"}, got %s (%v)", data, err)
+	}
+	var decoded holder
+	if err := json.Unmarshal(data, &decoded); err != nil || !decoded.Bits.Equal(b) {
+		t.Fatalf("Unmarshal should return %v, got %v (%v)", b.String(), decoded.Bits.String(), err)
+	}
+}
@@ -0,0 +1,451 @@
+package gollect
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	bloomFilterMagic         = "GBF1"
+	countingBloomFilterMagic = "GCB1"
+	// bloomMaxHashes is the largest number of hash functions of a Bloom filter, which is only
+	// reached with false positive rates far below 1e-18.
+	bloomMaxHashes = 64
+)
+
+// bloomParameters returns the number of bits and hash functions a Bloom filter needs to hold
+// expected_count elements with the given false positive rate.
+func bloomParameters(expected_count int, false_positive_rate float64, method string) (size int, num_hashes int) {
+	if expected_count <= 0 {
+		panic("ERROR: " + method + " - expected count must be positive")
+	}
+	if !(false_positive_rate > 0 && false_positive_rate < 1) {
+		panic("ERROR: " + method + " - false positive rate must be between 0 and 1")
+	}
+	size = int(math.Ceil(-float64(expected_count) * math.Log(false_positive_rate) / (math.Ln2 * math.Ln2)))
+	num_hashes = int(math.Round(float64(size) / float64(expected_count) * math.Ln2))
+	if num_hashes < 1 {
+		num_hashes = 1
+	} else if num_hashes > bloomMaxHashes {
+		num_hashes = bloomMaxHashes
+	}
+	return
+}
+
+// bloomVisitPositions calls visitor with the num_hashes positions of hash in a filter of size
+// positions, using double hashing. It stops early if visitor returns false.
+func bloomVisitPositions(hash uint64, num_hashes int, size int, visitor func(position int) bool) bool {
+	// splitmix64 finalizer, so that the second hash is independent enough from the first
+	second := hash + 0x9e3779b97f4a7c15
+	second = (second ^ (second >> 30)) * 0xbf58476d1ce4e5b9
+	second = (second ^ (second >> 27)) * 0x94d049bb133111eb
+	second = (second ^ (second >> 31)) | 1
+	for idx := 0; idx < num_hashes; idx++ {
+		if !visitor(int((hash + uint64(idx)*second) % uint64(size))) {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomEstimate estimates the number of elements added to a filter of size positions from the
+// number of positions that are set.
+func bloomEstimate(set int, size int, num_hashes int) float64 {
+	if set >= size {
+		return math.Inf(1)
+	}
+	return -float64(size) / float64(num_hashes) * math.Log(1-float64(set)/float64(size))
+}
+
+func resolveBloomHasher[T any](hasher Hasher[T], method string) Hasher[T] {
+	hasher = resolveStableHasher(hasher)
+	if hasher == nil {
+		panic("ERROR: " + method + " - no Hasher for element type")
+	}
+	return hasher
+}
+
+// BloomFilter is a probabilistic set that can tell that an element was definitely not added, or
+// that it may have been added.
+//
+// Elements are hashed with a Hasher. By default this is the Hashable implementation of the
+// element type, or a stable Hasher if it is comparable, so a serialized BloomFilter can be read
+// back by another run of the program.
+type BloomFilter[T any] struct {
+	bits       Bitset
+	num_hashes int
+	hasher     Hasher[T]
+}
+
+// NewBloomFilter creates a new empty BloomFilter sized to hold expected_count elements with the
+// given false positive rate, by value.
+func NewBloomFilter[T any](expected_count int, false_positive_rate float64) BloomFilter[T] {
+	return NewBloomFilterWithHasher[T](expected_count, false_positive_rate, nil)
+}
+
+// NewBloomFilterWithHasher creates a new empty BloomFilter sized to hold expected_count elements
+// with the given false positive rate, that uses hasher for its elements, by value.
+func NewBloomFilterWithHasher[T any](expected_count int, false_positive_rate float64, hasher Hasher[T]) BloomFilter[T] {
+	size, num_hashes := bloomParameters(expected_count, false_positive_rate, "BloomFilter")
+	return BloomFilter[T]{
+		bits:       NewFixedBitset(size),
+		num_hashes: num_hashes,
+		hasher:     resolveBloomHasher(hasher, "BloomFilter"),
+	}
+}
+
+// NewBloomFilterFromBloomFilter creates a new BloomFilter with the same contents as another, by
+// value.
+func NewBloomFilterFromBloomFilter[T any](other BloomFilter[T]) BloomFilter[T] {
+	return BloomFilter[T]{bits: NewBitsetFromBitset(other.bits), num_hashes: other.num_hashes, hasher: other.hasher}
+}
+
+// MakeBloomFilter creates a new empty BloomFilter instance sized to hold expected_count elements
+// with the given false positive rate.
+func MakeBloomFilter[T any](expected_count int, false_positive_rate float64) *BloomFilter[T] {
+	b := NewBloomFilter[T](expected_count, false_positive_rate)
+	return &b
+}
+
+// MakeBloomFilterWithHasher creates a new empty BloomFilter instance sized to hold
+// expected_count elements with the given false positive rate, that uses hasher for its elements.
+func MakeBloomFilterWithHasher[T any](expected_count int, false_positive_rate float64, hasher Hasher[T]) *BloomFilter[T] {
+	b := NewBloomFilterWithHasher(expected_count, false_positive_rate, hasher)
+	return &b
+}
+
+// MakeBloomFilterFromBloomFilter creates a new BloomFilter instance with the same contents as
+// another.
+func MakeBloomFilterFromBloomFilter[T any](other BloomFilter[T]) *BloomFilter[T] {
+	b := NewBloomFilterFromBloomFilter(other)
+	return &b
+}
+
+// Size returns the number of bits in the BloomFilter.
+func (b *BloomFilter[T]) Size() int {
+	return b.bits.Size()
+}
+
+// NumHashes returns the number of hash functions the BloomFilter uses per element.
+func (b *BloomFilter[T]) NumHashes() int {
+	return b.num_hashes
+}
+
+// Add adds value to the BloomFilter.
+func (b *BloomFilter[T]) Add(value T) {
+	bloomVisitPositions(b.hasher.Hash(&value), b.num_hashes, b.bits.Size(), func(position int) bool {
+		b.bits.Set(position)
+		return true
+	})
+}
+
+// MayContain returns false if value was definitely not added to the BloomFilter, and true if it
+// may have been.
+func (b *BloomFilter[T]) MayContain(value T) bool {
+	return bloomVisitPositions(b.hasher.Hash(&value), b.num_hashes, b.bits.Size(), b.bits.Test)
+}
+
+// EstimatedCount estimates the number of distinct elements added to the BloomFilter. Returns
+// +Inf if every bit is set.
+func (b *BloomFilter[T]) EstimatedCount() float64 {
+	return bloomEstimate(b.bits.Count(), b.bits.Size(), b.num_hashes)
+}
+
+// Union returns a new BloomFilter that may contain every element of either BloomFilter.
+//
+// Panics if the BloomFilters have a different size or number of hash functions.
+func (b *BloomFilter[T]) Union(other BloomFilter[T]) BloomFilter[T] {
+	if b.bits.Size() != other.bits.Size() || b.num_hashes != other.num_hashes {
+		panic("ERROR: BloomFilter.Union - filters have different parameters")
+	}
+	return BloomFilter[T]{bits: b.bits.Or(other.bits), num_hashes: b.num_hashes, hasher: b.hasher}
+}
+
+// IsEmpty returns true if nothing was added to the BloomFilter.
+func (b *BloomFilter[T]) IsEmpty() bool {
+	return b.bits.None()
+}
+
+// Clear removes all the elements from the BloomFilter.
+func (b *BloomFilter[T]) Clear() {
+	b.bits.ClearAll()
+}
+
+// Swap swaps the data of two BloomFilters.
+func (b *BloomFilter[T]) Swap(other *BloomFilter[T]) {
+	*b, *other = *other, *b
+}
+
+// Clone returns a new BloomFilter with the same bits, that does not share storage with this one.
+func (b *BloomFilter[T]) Clone() BloomFilter[T] {
+	return NewBloomFilterFromBloomFilter(*b)
+}
+
+// String returns a string representation of the BloomFilter.
+func (b *BloomFilter[T]) String() string {
+	return fmt.Sprintf("BloomFilter{size: %v, hashes: %v, set: %v}", b.bits.Size(), b.num_hashes, b.bits.Count())
+}
+
+// MarshalBinary encodes the size, number of hash functions and bits of the BloomFilter.
+func (b *BloomFilter[T]) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 16+8*len(b.bits.words))
+	buf = append(buf, bloomFilterMagic...)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(b.num_hashes))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(b.bits.Size()))
+	for _, word := range b.bits.words {
+		buf = binary.LittleEndian.AppendUint64(buf, word)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a BloomFilter encoded by MarshalBinary.
+//
+// The BloomFilter keeps its Hasher, or uses the default one if it has none, which must be the
+// same as the one used by the encoded BloomFilter.
+func (b *BloomFilter[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 16 || string(data[:4]) != bloomFilterMagic {
+		return errors.New("gollect: BloomFilter.UnmarshalBinary - not an encoded BloomFilter")
+	}
+	num_hashes := int(binary.LittleEndian.Uint32(data[4:]))
+	size := binary.LittleEndian.Uint64(data[8:])
+	if num_hashes < 1 || num_hashes > bloomMaxHashes || size < 1 {
+		return errors.New("gollect: BloomFilter.UnmarshalBinary - invalid parameters")
+	}
+	// Check the length before allocating, so that a corrupt size cannot cause a huge allocation.
+	// The size is compared with the data length first, so that the rounding cannot overflow.
+	if size > uint64(len(data)-16)*8 || (size+bitsetWordSize-1)/bitsetWordSize != uint64(len(data)-16)/8 || (len(data)-16)%8 != 0 {
+		return errors.New("gollect: BloomFilter.UnmarshalBinary - wrong data length")
+	}
+	decoded := NewFixedBitset(int(size))
+	for idx := range decoded.words {
+		decoded.words[idx] = binary.LittleEndian.Uint64(data[16+8*idx:])
+	}
+	if rem := decoded.size % bitsetWordSize; rem != 0 && decoded.words[len(decoded.words)-1]>>rem != 0 {
+		return errors.New("gollect: BloomFilter.UnmarshalBinary - bits set past the end")
+	}
+	hasher := b.hasher
+	if hasher == nil {
+		hasher = resolveStableHasher[T](nil)
+		if hasher == nil {
+			return errors.New("gollect: BloomFilter.UnmarshalBinary - no Hasher for element type")
+		}
+	}
+	*b = BloomFilter[T]{bits: decoded, num_hashes: num_hashes, hasher: hasher}
+	return nil
+}
+
+// CountingBloomFilter is a BloomFilter that keeps a small counter instead of a bit per position,
+// so that elements can also be removed.
+//
+// Counters saturate at 255. A saturated counter is never decremented, so removing elements never
+// causes false negatives, but a filter with saturated counters may keep reporting removed
+// elements.
+type CountingBloomFilter[T any] struct {
+	counters   []uint8
+	num_hashes int
+	hasher     Hasher[T]
+}
+
+// NewCountingBloomFilter creates a new empty CountingBloomFilter sized to hold expected_count
+// elements with the given false positive rate, by value.
+func NewCountingBloomFilter[T any](expected_count int, false_positive_rate float64) CountingBloomFilter[T] {
+	return NewCountingBloomFilterWithHasher[T](expected_count, false_positive_rate, nil)
+}
+
+// NewCountingBloomFilterWithHasher creates a new empty CountingBloomFilter sized to hold
+// expected_count elements with the given false positive rate, that uses hasher for its elements,
+// by value.
+func NewCountingBloomFilterWithHasher[T any](expected_count int, false_positive_rate float64, hasher Hasher[T]) CountingBloomFilter[T] {
+	size, num_hashes := bloomParameters(expected_count, false_positive_rate, "CountingBloomFilter")
+	return CountingBloomFilter[T]{
+		counters:   make([]uint8, size),
+		num_hashes: num_hashes,
+		hasher:     resolveBloomHasher(hasher, "CountingBloomFilter"),
+	}
+}
+
+// NewCountingBloomFilterFromCountingBloomFilter creates a new CountingBloomFilter with the same
+// contents as another, by value.
+func NewCountingBloomFilterFromCountingBloomFilter[T any](other CountingBloomFilter[T]) CountingBloomFilter[T] {
+	return CountingBloomFilter[T]{counters: append([]uint8{}, other.counters...), num_hashes: other.num_hashes, hasher: other.hasher}
+}
+
+// MakeCountingBloomFilter creates a new empty CountingBloomFilter instance sized to hold
+// expected_count elements with the given false positive rate.
+func MakeCountingBloomFilter[T any](expected_count int, false_positive_rate float64) *CountingBloomFilter[T] {
+	b := NewCountingBloomFilter[T](expected_count, false_positive_rate)
+	return &b
+}
+
+// MakeCountingBloomFilterWithHasher creates a new empty CountingBloomFilter instance sized to
+// hold expected_count elements with the given false positive rate, that uses hasher for its
+// elements.
+func MakeCountingBloomFilterWithHasher[T any](expected_count int, false_positive_rate float64, hasher Hasher[T]) *CountingBloomFilter[T] {
+	b := NewCountingBloomFilterWithHasher(expected_count, false_positive_rate, hasher)
+	return &b
+}
+
+// MakeCountingBloomFilterFromCountingBloomFilter creates a new CountingBloomFilter instance with
+// the same contents as another.
+func MakeCountingBloomFilterFromCountingBloomFilter[T any](other CountingBloomFilter[T]) *CountingBloomFilter[T] {
+	b := NewCountingBloomFilterFromCountingBloomFilter(other)
+	return &b
+}
+
+// Size returns the number of counters in the CountingBloomFilter.
+func (b *CountingBloomFilter[T]) Size() int {
+	return len(b.counters)
+}
+
+// NumHashes returns the number of hash functions the CountingBloomFilter uses per element.
+func (b *CountingBloomFilter[T]) NumHashes() int {
+	return b.num_hashes
+}
+
+// Add adds value to the CountingBloomFilter.
+func (b *CountingBloomFilter[T]) Add(value T) {
+	bloomVisitPositions(b.hasher.Hash(&value), b.num_hashes, len(b.counters), func(position int) bool {
+		if b.counters[position] != math.MaxUint8 {
+			b.counters[position]++
+		}
+		return true
+	})
+}
+
+// Remove removes value from the CountingBloomFilter, and returns true if it may have been added.
+//
+// Only values that were added should be removed, otherwise the CountingBloomFilter may report
+// false negatives.
+func (b *CountingBloomFilter[T]) Remove(value T) bool {
+	hash := b.hasher.Hash(&value)
+	if !bloomVisitPositions(hash, b.num_hashes, len(b.counters), b.test) {
+		return false
+	}
+	bloomVisitPositions(hash, b.num_hashes, len(b.counters), func(position int) bool {
+		if b.counters[position] != math.MaxUint8 {
+			b.counters[position]--
+		}
+		return true
+	})
+	return true
+}
+
+func (b *CountingBloomFilter[T]) test(position int) bool {
+	return b.counters[position] != 0
+}
+
+// MayContain returns false if value is definitely not in the CountingBloomFilter, and true if it
+// may be.
+func (b *CountingBloomFilter[T]) MayContain(value T) bool {
+	return bloomVisitPositions(b.hasher.Hash(&value), b.num_hashes, len(b.counters), b.test)
+}
+
+// EstimatedCount estimates the number of distinct elements in the CountingBloomFilter. Returns
+// +Inf if every counter is non-zero.
+func (b *CountingBloomFilter[T]) EstimatedCount() float64 {
+	set := 0
+	for _, counter := range b.counters {
+		if counter != 0 {
+			set++
+		}
+	}
+	return bloomEstimate(set, len(b.counters), b.num_hashes)
+}
+
+// Union returns a new CountingBloomFilter that may contain every element of either
+// CountingBloomFilter, with the counters of both added together.
+//
+// Panics if the CountingBloomFilters have a different size or number of hash functions.
+func (b *CountingBloomFilter[T]) Union(other CountingBloomFilter[T]) CountingBloomFilter[T] {
+	if len(b.counters) != len(other.counters) || b.num_hashes != other.num_hashes {
+		panic("ERROR: CountingBloomFilter.Union - filters have different parameters")
+	}
+	result := CountingBloomFilter[T]{counters: make([]uint8, len(b.counters)), num_hashes: b.num_hashes, hasher: b.hasher}
+	for idx, counter := range b.counters {
+		sum := int(counter) + int(other.counters[idx])
+		if sum > math.MaxUint8 {
+			sum = math.MaxUint8
+		}
+		result.counters[idx] = uint8(sum)
+	}
+	return result
+}
+
+// IsEmpty returns true if the CountingBloomFilter is empty.
+func (b *CountingBloomFilter[T]) IsEmpty() bool {
+	for _, counter := range b.counters {
+		if counter != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clear removes all the elements from the CountingBloomFilter.
+func (b *CountingBloomFilter[T]) Clear() {
+	for idx := range b.counters {
+		b.counters[idx] = 0
+	}
+}
+
+// Swap swaps the data of two CountingBloomFilters.
+func (b *CountingBloomFilter[T]) Swap(other *CountingBloomFilter[T]) {
+	*b, *other = *other, *b
+}
+
+// Clone returns a new CountingBloomFilter with the same counters, that does not share storage
+// with this one.
+func (b *CountingBloomFilter[T]) Clone() CountingBloomFilter[T] {
+	return NewCountingBloomFilterFromCountingBloomFilter(*b)
+}
+
+// String returns a string representation of the CountingBloomFilter.
+func (b *CountingBloomFilter[T]) String() string {
+	set := 0
+	for _, counter := range b.counters {
+		if counter != 0 {
+			set++
+		}
+	}
+	return fmt.Sprintf("CountingBloomFilter{size: %v, hashes: %v, set: %v}", len(b.counters), b.num_hashes, set)
+}
+
+// MarshalBinary encodes the size, number of hash functions and counters of the
+// CountingBloomFilter.
+func (b *CountingBloomFilter[T]) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 16+len(b.counters))
+	buf = append(buf, countingBloomFilterMagic...)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(b.num_hashes))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(len(b.counters)))
+	buf = append(buf, b.counters...)
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a CountingBloomFilter encoded by MarshalBinary.
+//
+// The CountingBloomFilter keeps its Hasher, or uses the default one if it has none, which must be
+// the same as the one used by the encoded CountingBloomFilter.
+func (b *CountingBloomFilter[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 16 || string(data[:4]) != countingBloomFilterMagic {
+		return errors.New("gollect: CountingBloomFilter.UnmarshalBinary - not an encoded CountingBloomFilter")
+	}
+	num_hashes := int(binary.LittleEndian.Uint32(data[4:]))
+	size := binary.LittleEndian.Uint64(data[8:])
+	if num_hashes < 1 || num_hashes > bloomMaxHashes || size < 1 {
+		return errors.New("gollect: CountingBloomFilter.UnmarshalBinary - invalid parameters")
+	}
+	if uint64(len(data)-16) != size {
+		return errors.New("gollect: CountingBloomFilter.UnmarshalBinary - wrong data length")
+	}
+	hasher := b.hasher
+	if hasher == nil {
+		hasher = resolveStableHasher[T](nil)
+		if hasher == nil {
+			return errors.New("gollect: CountingBloomFilter.UnmarshalBinary - no Hasher for element type")
+		}
+	}
+	*b = CountingBloomFilter[T]{counters: append([]uint8{}, data[16:]...), num_hashes: num_hashes, hasher: hasher}
+	return nil
+}
@@ -0,0 +1,177 @@
+package gollect
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestBloomFilterAddMayContain(t *testing.T) {
+	b := NewBloomFilter[int](1000, 0.01)
+	if b.Size() != 9586 || b.NumHashes() != 7 {
+		t.Fatalf("BloomFilter should have 9586 bits and 7 hashes, got %v", b.String())
+	}
+	for x := 0; x < 1000; x++ {
+		b.Add(x)
+	}
+	for x := 0; x < 1000; x++ {
+		if !b.MayContain(x) {
+			t.Fatalf("MayContain(%v) should be true", x)
+		}
+	}
+	false_positives := 0
+	for x := 1000; x < 11000; x++ {
+		if b.MayContain(x) {
+			false_positives++
+		}
+	}
+	if false_positives > 200 {
+		t.Fatalf("Too many false positives, got %v out of 10000", false_positives)
+	}
+	if estimate := b.EstimatedCount(); math.Abs(estimate-1000) > 50 {
+		t.Fatalf("EstimatedCount should be close to 1000, got %v", estimate)
+	}
+}
+
+func TestBloomFilterHashable(t *testing.T) {
+	b := NewBloomFilter[caseless](10, 0.01)
+	b.Add("Hello")
+	if !b.MayContain("HELLO") {
+		t.Fatalf("MayContain(HELLO) should use the Hashable implementation")
+	}
+}
+
+func TestBloomFilterNoHasher(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: BloomFilter - no Hasher for element type" {
+			t.Fatalf("Should have panicked because there is no Hasher, got \"%v\"", result)
+		}
+	}()
+	NewBloomFilter[[]int](10, 0.01)
+}
+
+func TestBloomFilterUnion(t *testing.T) {
+	a := NewBloomFilter[string](100, 0.01)
+	b := NewBloomFilter[string](100, 0.01)
+	a.Add("left")
+	b.Add("right")
+	u := a.Union(b)
+	if !u.MayContain("left") || !u.MayContain("right") || a.MayContain("right") {
+		t.Fatalf("Union should contain both elements without modifying its operands")
+	}
+
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: BloomFilter.Union - filters have different parameters" {
+			t.Fatalf("Should have panicked because the parameters differ, got \"%v\"", result)
+		}
+	}()
+	c := NewBloomFilter[string](1000, 0.01)
+	a.Union(c)
+}
+
+func TestBloomFilterSerialization(t *testing.T) {
+	b := NewBloomFilter[string](100, 0.05)
+	b.Add("alpha")
+	b.Add("beta")
+	data, _ := b.MarshalBinary()
+	var decoded BloomFilter[string]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if !decoded.MayContain("alpha") || !decoded.MayContain("beta") || decoded.Size() != b.Size() {
+		t.Fatalf("Round trip changed the contents, got %v", decoded.String())
+	}
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Fatalf("UnmarshalBinary should have failed on truncated data")
+	}
+}
+
+func TestBloomFilterUnmarshalCorrupt(t *testing.T) {
+	b := NewBloomFilter[string](100, 0.05)
+	data, _ := b.MarshalBinary()
+	var decoded BloomFilter[string]
+
+	corrupt := append([]byte{}, data...)
+	binary.LittleEndian.PutUint32(corrupt[4:], 1<<30)
+	if err := decoded.UnmarshalBinary(corrupt); err == nil {
+		t.Fatalf("UnmarshalBinary should have failed on too many hash functions")
+	}
+	corrupt = append([]byte{}, data...)
+	binary.LittleEndian.PutUint64(corrupt[8:], 1<<62)
+	if err := decoded.UnmarshalBinary(corrupt); err == nil {
+		t.Fatalf("UnmarshalBinary should have failed on a size that does not match the data")
+	}
+	corrupt = append([]byte{}, data...)
+	binary.LittleEndian.PutUint64(corrupt[8:], uint64(b.Size()+64))
+	if err := decoded.UnmarshalBinary(corrupt); err == nil {
+		t.Fatalf("UnmarshalBinary should have failed on a size that does not match the data")
+	}
+	corrupt = append([]byte{}, data[:16]...)
+	binary.LittleEndian.PutUint32(corrupt[4:], 3)
+	binary.LittleEndian.PutUint64(corrupt[8:], 1<<64-11)
+	if err := decoded.UnmarshalBinary(corrupt); err == nil {
+		t.Fatalf("UnmarshalBinary should have failed on a size that overflows when rounded up")
+	}
+
+	c := NewCountingBloomFilter[string](100, 0.05)
+	data, _ = c.MarshalBinary()
+	binary.LittleEndian.PutUint32(data[4:], bloomMaxHashes+1)
+	var decoded_counting CountingBloomFilter[string]
+	if err := decoded_counting.UnmarshalBinary(data); err == nil {
+		t.Fatalf("UnmarshalBinary should have failed on too many hash functions")
+	}
+}
+
+func TestCountingBloomFilter(t *testing.T) {
+	b := NewCountingBloomFilter[string](100, 0.01)
+	b.Add("alpha")
+	b.Add("beta")
+	b.Add("beta")
+	if !b.Remove("beta") || !b.MayContain("beta") {
+		t.Fatalf("beta was added twice, so should still be present after one Remove")
+	}
+	b.Remove("beta")
+	if b.MayContain("beta") || !b.MayContain("alpha") {
+		t.Fatalf("beta should have been removed")
+	}
+	if b.Remove("gamma") {
+		t.Fatalf("Remove(gamma) should be false")
+	}
+
+	other := NewCountingBloomFilter[string](100, 0.01)
+	other.Add("gamma")
+	u := b.Union(other)
+	if !u.MayContain("alpha") || !u.MayContain("gamma") {
+		t.Fatalf("Union should contain alpha and gamma")
+	}
+	if estimate := u.EstimatedCount(); math.Round(estimate) != 2 {
+		t.Fatalf("EstimatedCount should be 2, got %v", estimate)
+	}
+
+	data, _ := u.MarshalBinary()
+	var decoded CountingBloomFilter[string]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	decoded.Remove("gamma")
+	if decoded.MayContain("gamma") || !decoded.MayContain("alpha") {
+		t.Fatalf("Decoded filter has the wrong contents, got %v", decoded.String())
+	}
+}
+
+func TestStableHasher(t *testing.T) {
+	h := NewStableHasher[struct {
+		A int
+		B string
+	}]()
+	value := struct {
+		A int
+		B string
+	}{1, "x"}
+	// FNV-1a of the little-endian int followed by the string length and bytes
+	if h.Hash(&value) != 0x928d5664f9ba49a7 {
+		t.Fatalf("StableHasher should not depend on a seed, got %#x", h.Hash(&value))
+	}
+}
@@ -0,0 +1,804 @@
+package gollect
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+type bTreeItem[K any, V any] struct {
+	key   K
+	value V
+	// cow identifies the BTree that stored the value. After a Clone, the value is shared and
+	// neither BTree destructs it.
+	cow *bTreeCow
+}
+
+// bTreeCow identifies which BTree owns a node. Nodes owned by another BTree are shared with it
+// and must be copied before they are modified.
+type bTreeCow struct {
+	_ byte
+}
+
+// BTreeNode is a node of a BTree, holding up to 2*degree-1 key/value pairs. It is exported so that
+// a NodeAllocator for BTree nodes can be named, as in NewFreeListAllocator[BTreeNode[K, V]]().
+type BTreeNode[K any, V any] struct {
+	items    []bTreeItem[K, V]
+	children []*BTreeNode[K, V]
+	cow      *bTreeCow
+}
+
+func (n *BTreeNode[K, V]) isLeaf() bool {
+	return len(n.children) == 0
+}
+
+type bTreeRemoveType int
+
+const (
+	bTreeRemoveItem bTreeRemoveType = iota
+	bTreeRemoveMin
+	bTreeRemoveMax
+)
+
+// BTree is an ordered collection of key/value pairs, based on an in-memory B-tree.
+//
+// The degree is the minimum number of children of every node except the root, so every node
+// holds between degree-1 and 2*degree-1 keys. Put, Get and Erase take O(log n) time.
+//
+// A BTree can be cloned in O(1) time. The clone shares its nodes with the original, and each of
+// them copies a node the first time they modify it.
+//
+// If the values implement the Destructible interface, they will have the Destruct method
+// called on them when they are removed from the BTree, unless they are shared with a clone.
+type BTree[K any, V any] struct {
+	root      *BTreeNode[K, V]
+	degree    int
+	size      int
+	compare   Comparator[K]
+	cow       *bTreeCow
+	allocator NodeAllocator[BTreeNode[K, V]]
+}
+
+func newBTree[K any, V any](degree int, compare Comparator[K]) BTree[K, V] {
+	if degree < 2 {
+		panic("ERROR: BTree - degree must be at least 2")
+	}
+	return BTree[K, V]{degree: degree, compare: compare, cow: &bTreeCow{}}
+}
+
+// NewBTree creates a new empty BTree ordered by the native `<` operator, by value.
+func NewBTree[K constraints.Ordered, V any](degree int) BTree[K, V] {
+	return newBTree[K, V](degree, NativeComparator[K]())
+}
+
+// NewBTreeWithComparator creates a new empty BTree ordered by compare, by value.
+func NewBTreeWithComparator[K any, V any](degree int, compare Comparator[K]) BTree[K, V] {
+	return newBTree[K, V](degree, compare)
+}
+
+// NewBTreeWithAllocator creates a new empty BTree ordered by the native `<` operator, that gets its
+// nodes from allocator, by value.
+//
+// A nil allocator allocates every node with new, like a BTree created any other way. Copies of
+// the BTree, including the ones made by Clone, do not use the allocator, as it may not be safe to
+// share, see FreeListAllocator. Nodes shared with a clone are never freed.
+func NewBTreeWithAllocator[K constraints.Ordered, V any](degree int, allocator NodeAllocator[BTreeNode[K, V]]) BTree[K, V] {
+	t := newBTree[K, V](degree, NativeComparator[K]())
+	t.allocator = allocator
+	return t
+}
+
+// NewBTreeWithComparatorAndAllocator creates a new empty BTree ordered by compare, that gets its
+// nodes from allocator, by value.
+//
+// See NewBTreeWithAllocator.
+func NewBTreeWithComparatorAndAllocator[K any, V any](degree int, compare Comparator[K], allocator NodeAllocator[BTreeNode[K, V]]) BTree[K, V] {
+	t := newBTree[K, V](degree, compare)
+	t.allocator = allocator
+	return t
+}
+
+// NewBTreeFromSortedVector creates a new BTree from keys, which must be sorted by the native `<`
+// operator without duplicates, by value.
+//
+// The i-th key is associated with the i-th element of values. If values is empty, every key is
+// associated with the zero value instead. The BTree is built in O(n) time.
+func NewBTreeFromSortedVector[K constraints.Ordered, V any](degree int, keys Vector[K], values Vector[V]) BTree[K, V] {
+	return NewBTreeFromSortedVectorWithComparator(degree, NativeComparator[K](), keys, values)
+}
+
+// NewBTreeFromSortableVector creates a new BTree from keys, which must already be sorted without
+// duplicates, by value.
+//
+// See NewBTreeFromSortedVector.
+func NewBTreeFromSortableVector[K constraints.Ordered, V any](degree int, keys SortableVector[K], values Vector[V]) BTree[K, V] {
+	return NewBTreeFromSortedVector(degree, keys.Vector, values)
+}
+
+// NewBTreeFromSortedVectorWithComparator creates a new BTree ordered by compare from keys, which
+// must be sorted by compare without duplicates, by value.
+//
+// See NewBTreeFromSortedVector.
+func NewBTreeFromSortedVectorWithComparator[K any, V any](degree int, compare Comparator[K], keys Vector[K], values Vector[V]) BTree[K, V] {
+	t := newBTree[K, V](degree, compare)
+	t.bulkLoad(keys, values)
+	return t
+}
+
+// MakeBTree creates a new empty BTree instance ordered by the native `<` operator.
+func MakeBTree[K constraints.Ordered, V any](degree int) *BTree[K, V] {
+	t := NewBTree[K, V](degree)
+	return &t
+}
+
+// MakeBTreeWithComparator creates a new empty BTree instance ordered by compare.
+func MakeBTreeWithComparator[K any, V any](degree int, compare Comparator[K]) *BTree[K, V] {
+	t := NewBTreeWithComparator[K, V](degree, compare)
+	return &t
+}
+
+// MakeBTreeWithAllocator creates a new empty BTree instance ordered by the native `<` operator,
+// that gets its nodes from allocator.
+func MakeBTreeWithAllocator[K constraints.Ordered, V any](degree int, allocator NodeAllocator[BTreeNode[K, V]]) *BTree[K, V] {
+	t := NewBTreeWithAllocator[K, V](degree, allocator)
+	return &t
+}
+
+// MakeBTreeWithComparatorAndAllocator creates a new empty BTree instance ordered by compare, that
+// gets its nodes from allocator.
+func MakeBTreeWithComparatorAndAllocator[K any, V any](degree int, compare Comparator[K], allocator NodeAllocator[BTreeNode[K, V]]) *BTree[K, V] {
+	t := NewBTreeWithComparatorAndAllocator[K, V](degree, compare, allocator)
+	return &t
+}
+
+// MakeBTreeFromSortedVector creates a new BTree instance from keys, which must be sorted by the
+// native `<` operator without duplicates.
+//
+// See NewBTreeFromSortedVector.
+func MakeBTreeFromSortedVector[K constraints.Ordered, V any](degree int, keys Vector[K], values Vector[V]) *BTree[K, V] {
+	t := NewBTreeFromSortedVector(degree, keys, values)
+	return &t
+}
+
+// MakeBTreeFromSortableVector creates a new BTree instance from keys, which must already be
+// sorted without duplicates.
+//
+// See NewBTreeFromSortedVector.
+func MakeBTreeFromSortableVector[K constraints.Ordered, V any](degree int, keys SortableVector[K], values Vector[V]) *BTree[K, V] {
+	t := NewBTreeFromSortableVector(degree, keys, values)
+	return &t
+}
+
+// MakeBTreeFromSortedVectorWithComparator creates a new BTree instance ordered by compare from
+// keys, which must be sorted by compare without duplicates.
+//
+// See NewBTreeFromSortedVector.
+func MakeBTreeFromSortedVectorWithComparator[K any, V any](degree int, compare Comparator[K], keys Vector[K], values Vector[V]) *BTree[K, V] {
+	t := NewBTreeFromSortedVectorWithComparator(degree, compare, keys, values)
+	return &t
+}
+
+func (t *BTree[K, V]) maxItems() int {
+	return 2*t.degree - 1
+}
+
+func (t *BTree[K, V]) minItems() int {
+	return t.degree - 1
+}
+
+func (t *BTree[K, V]) newNode() *BTreeNode[K, V] {
+	if t.allocator == nil {
+		return &BTreeNode[K, V]{cow: t.cow}
+	}
+	node := t.allocator.Allocate()
+	node.cow = t.cow
+	return node
+}
+
+// freeNode returns node to the allocator, if the BTree owns it.
+func (t *BTree[K, V]) freeNode(node *BTreeNode[K, V]) {
+	if t.allocator != nil && node.cow == t.cow {
+		t.allocator.Free(node)
+	}
+}
+
+// bulkLoad replaces the contents of the BTree with the sorted keys and values.
+func (t *BTree[K, V]) bulkLoad(keys Vector[K], values Vector[V]) {
+	if !values.IsEmpty() && values.Size() != keys.Size() {
+		panic("ERROR: BTree - keys and values have different sizes")
+	}
+	items := make([]bTreeItem[K, V], keys.Size())
+	for idx := range items {
+		items[idx].key = keys.At(idx)
+		items[idx].cow = t.cow
+		if !values.IsEmpty() {
+			items[idx].value = values.At(idx)
+		}
+		if idx > 0 && t.compare(&items[idx-1].key, &items[idx].key) >= 0 {
+			panic("ERROR: BTree - bulk load keys are not sorted")
+		}
+	}
+	t.size = len(items)
+	if len(items) == 0 {
+		t.root = nil
+		return
+	}
+	// capacities[h] is the number of keys in a full subtree of height h.
+	capacities := []int{t.maxItems()}
+	for capacities[len(capacities)-1] < len(items) {
+		last := capacities[len(capacities)-1]
+		if last > (math.MaxInt-1)/(2*t.degree) {
+			capacities = append(capacities, math.MaxInt)
+		} else {
+			capacities = append(capacities, (last+1)*2*t.degree-1)
+		}
+	}
+	t.root = t.build(items, len(capacities)-1, capacities)
+}
+
+// build creates a subtree of the given height holding items.
+//
+// The number of children of every node is chosen so that the items are spread as evenly as
+// possible, which keeps every node within the limits of the degree.
+func (t *BTree[K, V]) build(items []bTreeItem[K, V], height int, capacities []int) *BTreeNode[K, V] {
+	node := t.newNode()
+	if height == 0 {
+		node.items = append(make([]bTreeItem[K, V], 0, t.maxItems()), items...)
+		return node
+	}
+	child_capacity := capacities[height-1]
+	// Just enough children for the items to fit, but at least two.
+	children := (len(items) + 1) / (child_capacity + 1)
+	if (len(items)+1)%(child_capacity+1) != 0 {
+		children++
+	}
+	if children < 2 {
+		children = 2
+	}
+	child_total := len(items) - (children - 1)
+	child_base := child_total / children
+	child_rem := child_total % children
+
+	node.items = make([]bTreeItem[K, V], 0, t.maxItems())
+	node.children = make([]*BTreeNode[K, V], 0, t.maxItems()+1)
+	start := 0
+	for i := 0; i < children; i++ {
+		end := start + child_base
+		if i < child_rem {
+			end++
+		}
+		node.children = append(node.children, t.build(items[start:end], height-1, capacities))
+		if i < children-1 {
+			node.items = append(node.items, items[end])
+		}
+		start = end + 1
+	}
+	return node
+}
+
+// IsEmpty returns true if the BTree is empty.
+func (t *BTree[K, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Size returns the number of key/value pairs in the BTree.
+func (t *BTree[K, V]) Size() int {
+	return t.size
+}
+
+// Degree returns the degree of the BTree.
+func (t *BTree[K, V]) Degree() int {
+	return t.degree
+}
+
+// Clone creates a copy of the BTree in O(1) time, by value.
+//
+// The nodes are shared between the BTree and the copy until either of them modifies them.
+func (t *BTree[K, V]) Clone() BTree[K, V] {
+	t.cow = &bTreeCow{}
+	clone := *t
+	clone.cow = &bTreeCow{}
+	clone.allocator = nil
+	return clone
+}
+
+func (t *BTree[K, V]) find(node *BTreeNode[K, V], key *K) (index int, found bool) {
+	index = sort.Search(len(node.items), func(i int) bool { return t.compare(&node.items[i].key, key) >= 0 })
+	found = index < len(node.items) && t.compare(&node.items[index].key, key) == 0
+	return
+}
+
+// mutable returns node if it is owned by the BTree, or a copy of it owned by the BTree.
+func (t *BTree[K, V]) mutable(node *BTreeNode[K, V]) *BTreeNode[K, V] {
+	if node.cow == t.cow {
+		return node
+	}
+	clone := t.newNode()
+	clone.items = append(make([]bTreeItem[K, V], 0, t.maxItems()), node.items...)
+	if !node.isLeaf() {
+		clone.children = append(make([]*BTreeNode[K, V], 0, t.maxItems()+1), node.children...)
+	}
+	return clone
+}
+
+func (t *BTree[K, V]) mutableChild(node *BTreeNode[K, V], index int) *BTreeNode[K, V] {
+	child := t.mutable(node.children[index])
+	node.children[index] = child
+	return child
+}
+
+// splitChild splits the full child at index in two, moving its middle item up into node.
+func (t *BTree[K, V]) splitChild(node *BTreeNode[K, V], index int) {
+	child := t.mutableChild(node, index)
+	mid := t.degree - 1
+	item := child.items[mid]
+	right := t.newNode()
+	right.items = append(make([]bTreeItem[K, V], 0, t.maxItems()), child.items[mid+1:]...)
+	if !child.isLeaf() {
+		right.children = append(make([]*BTreeNode[K, V], 0, t.maxItems()+1), child.children[mid+1:]...)
+		for i := mid + 1; i < len(child.children); i++ {
+			child.children[i] = nil
+		}
+		child.children = child.children[:mid+1]
+	}
+	for i := mid; i < len(child.items); i++ {
+		child.items[i] = bTreeItem[K, V]{}
+	}
+	child.items = child.items[:mid]
+
+	node.items = append(node.items, bTreeItem[K, V]{})
+	copy(node.items[index+1:], node.items[index:])
+	node.items[index] = item
+	node.children = append(node.children, nil)
+	copy(node.children[index+2:], node.children[index+1:])
+	node.children[index+1] = right
+}
+
+// Put sets the value associated with key, replacing any previous value.
+//
+// If a previous value implements the Destructible interface, it will have the Destruct method
+// called on it, unless it is shared with a clone.
+func (t *BTree[K, V]) Put(key K, value V) {
+	if t.root == nil {
+		t.root = t.newNode()
+		t.root.items = make([]bTreeItem[K, V], 0, t.maxItems())
+	}
+	t.root = t.mutable(t.root)
+	if len(t.root.items) >= t.maxItems() {
+		old := t.root
+		t.root = t.newNode()
+		t.root.items = make([]bTreeItem[K, V], 0, t.maxItems())
+		t.root.children = append(make([]*BTreeNode[K, V], 0, t.maxItems()+1), old)
+		t.splitChild(t.root, 0)
+	}
+	if t.insert(t.root, key, value) {
+		t.size++
+	}
+}
+
+// insert puts key and value into the subtree of node, which must be mutable and not full, and
+// returns true if the key was not already present.
+func (t *BTree[K, V]) insert(node *BTreeNode[K, V], key K, value V) bool {
+	index, found := t.find(node, &key)
+	if found {
+		t.destructItem(&node.items[index])
+		node.items[index].value = value
+		node.items[index].cow = t.cow
+		return false
+	}
+	if node.isLeaf() {
+		node.items = append(node.items, bTreeItem[K, V]{})
+		copy(node.items[index+1:], node.items[index:])
+		node.items[index] = bTreeItem[K, V]{key: key, value: value, cow: t.cow}
+		return true
+	}
+	if len(node.children[index].items) >= t.maxItems() {
+		t.splitChild(node, index)
+		if c := t.compare(&key, &node.items[index].key); c == 0 {
+			return t.insert(node, key, value)
+		} else if c > 0 {
+			index++
+		}
+	}
+	return t.insert(t.mutableChild(node, index), key, value)
+}
+
+// PutRef sets the value associated with key, replacing any previous value.
+func (t *BTree[K, V]) PutRef(key *K, value *V) {
+	t.Put(*key, *value)
+}
+
+// Get returns the value associated with key, and whether it was found.
+func (t *BTree[K, V]) Get(key K) (value V, found bool) {
+	node := t.root
+	for node != nil {
+		index, found := t.find(node, &key)
+		if found {
+			return node.items[index].value, true
+		}
+		if node.isLeaf() {
+			break
+		}
+		node = node.children[index]
+	}
+	return
+}
+
+// GetRef returns a pointer to the value associated with key, or nil if it was not found.
+//
+// Note, this copies any nodes on the path to key that are shared with a clone, so that
+// modifying the value does not affect the clone.
+func (t *BTree[K, V]) GetRef(key K) *V {
+	if !t.ContainsKey(key) {
+		return nil
+	}
+	t.root = t.mutable(t.root)
+	node := t.root
+	for {
+		index, found := t.find(node, &key)
+		if found {
+			return &node.items[index].value
+		}
+		node = t.mutableChild(node, index)
+	}
+}
+
+// ContainsKey returns true if the BTree contains key.
+func (t *BTree[K, V]) ContainsKey(key K) bool {
+	_, found := t.Get(key)
+	return found
+}
+
+// Erase removes key and its value from the BTree, and returns true if it was present.
+//
+// If the value implements the Destructible interface, it will have the Destruct method called
+// on it, unless it is shared with a clone.
+func (t *BTree[K, V]) Erase(key K) bool {
+	var item bTreeItem[K, V]
+	return t.erase(&key, &item)
+}
+
+// erase removes key and its value from the BTree, and returns true if it was present. The removed
+// item is stored in item, so that EraseRange can remove many keys with one.
+func (t *BTree[K, V]) erase(key *K, item *bTreeItem[K, V]) bool {
+	if t.root == nil {
+		return false
+	}
+	t.root = t.mutable(t.root)
+	var found bool
+	*item, found = t.remove(t.root, key, bTreeRemoveItem)
+	if len(t.root.items) == 0 {
+		old := t.root
+		if t.root.isLeaf() {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+		t.freeNode(old)
+	}
+	if !found {
+		return false
+	}
+	t.size--
+	t.destructItem(item)
+	return true
+}
+
+// remove removes an item from the subtree of node, which must be mutable and, unless it is the
+// root, hold more than the minimum number of items.
+func (t *BTree[K, V]) remove(node *BTreeNode[K, V], key *K, typ bTreeRemoveType) (item bTreeItem[K, V], found bool) {
+	index := 0
+	switch typ {
+	case bTreeRemoveMax:
+		if node.isLeaf() {
+			return t.removeItemAt(node, len(node.items)-1), true
+		}
+		index = len(node.items)
+	case bTreeRemoveMin:
+		if node.isLeaf() {
+			return t.removeItemAt(node, 0), true
+		}
+	case bTreeRemoveItem:
+		index, found = t.find(node, key)
+		if node.isLeaf() {
+			if found {
+				return t.removeItemAt(node, index), true
+			}
+			return
+		}
+	}
+	if len(node.children[index].items) <= t.minItems() {
+		t.growChild(node, index)
+		return t.remove(node, key, typ)
+	}
+	child := t.mutableChild(node, index)
+	if found {
+		// Replace the item with its predecessor, which is the largest item in the left subtree.
+		item = node.items[index]
+		node.items[index], _ = t.remove(child, nil, bTreeRemoveMax)
+		return item, true
+	}
+	return t.remove(child, key, typ)
+}
+
+func (t *BTree[K, V]) removeItemAt(node *BTreeNode[K, V], index int) bTreeItem[K, V] {
+	item := node.items[index]
+	copy(node.items[index:], node.items[index+1:])
+	node.items[len(node.items)-1] = bTreeItem[K, V]{}
+	node.items = node.items[:len(node.items)-1]
+	return item
+}
+
+func (t *BTree[K, V]) removeChildAt(node *BTreeNode[K, V], index int) *BTreeNode[K, V] {
+	child := node.children[index]
+	copy(node.children[index:], node.children[index+1:])
+	node.children[len(node.children)-1] = nil
+	node.children = node.children[:len(node.children)-1]
+	return child
+}
+
+// growChild makes sure the child at index holds more than the minimum number of items, by
+// borrowing an item from a sibling or by merging it with a sibling.
+func (t *BTree[K, V]) growChild(node *BTreeNode[K, V], index int) {
+	if index > 0 && len(node.children[index-1].items) > t.minItems() {
+		child := t.mutableChild(node, index)
+		left := t.mutableChild(node, index-1)
+		child.items = append(child.items, bTreeItem[K, V]{})
+		copy(child.items[1:], child.items)
+		child.items[0] = node.items[index-1]
+		node.items[index-1] = t.removeItemAt(left, len(left.items)-1)
+		if !left.isLeaf() {
+			child.children = append(child.children, nil)
+			copy(child.children[1:], child.children)
+			child.children[0] = t.removeChildAt(left, len(left.children)-1)
+		}
+	} else if index < len(node.items) && len(node.children[index+1].items) > t.minItems() {
+		child := t.mutableChild(node, index)
+		right := t.mutableChild(node, index+1)
+		child.items = append(child.items, node.items[index])
+		node.items[index] = t.removeItemAt(right, 0)
+		if !right.isLeaf() {
+			child.children = append(child.children, t.removeChildAt(right, 0))
+		}
+	} else {
+		if index >= len(node.items) {
+			index--
+		}
+		child := t.mutableChild(node, index)
+		merge_item := t.removeItemAt(node, index)
+		merge_child := t.removeChildAt(node, index+1)
+		child.items = append(child.items, merge_item)
+		child.items = append(child.items, merge_child.items...)
+		child.children = append(child.children, merge_child.children...)
+		t.freeNode(merge_child)
+	}
+}
+
+// EraseRange removes every key in the range [from, to) and its value from the BTree, and
+// returns the number of keys removed.
+//
+// Removing k keys takes O(k log n) time. Each key is removed in place, so no memory is allocated
+// for each key unless the nodes are shared with a clone.
+//
+// If the values implement the Destructible interface, they will have the Destruct method
+// called on them, unless they are shared with a clone.
+func (t *BTree[K, V]) EraseRange(from K, to K) int {
+	var item bTreeItem[K, V]
+	removed := 0
+	for {
+		key := t.lowerBound(&from)
+		if key == nil || t.compare(key, &to) >= 0 {
+			return removed
+		}
+		from = *key
+		t.erase(&from, &item)
+		removed++
+	}
+}
+
+// lowerBound returns a pointer to the lowest key that is not lesser than key, or nil if there is
+// none.
+func (t *BTree[K, V]) lowerBound(key *K) (result *K) {
+	node := t.root
+	for node != nil {
+		index, found := t.find(node, key)
+		if found {
+			return &node.items[index].key
+		}
+		if index < len(node.items) {
+			result = &node.items[index].key
+		}
+		if node.isLeaf() {
+			break
+		}
+		node = node.children[index]
+	}
+	return
+}
+
+// Clear removes all the key/value pairs from the BTree.
+//
+// If the values implement the Destructible interface, they will have the Destruct method
+// called on them, unless they are shared with a clone.
+func (t *BTree[K, V]) Clear() {
+	if t.root != nil {
+		t.destructNode(t.root)
+	}
+	t.root = nil
+	t.size = 0
+}
+
+// destructItem calls the Destruct method of the item's value, if the BTree owns it.
+func (t *BTree[K, V]) destructItem(item *bTreeItem[K, V]) {
+	if item.cow != t.cow {
+		return
+	}
+	if e, isDestructible := interface{}(&item.value).(Destructible); isDestructible {
+		e.Destruct()
+	}
+}
+
+// destructNode destructs the values owned by the BTree in the subtree of node, and frees the
+// nodes it owns. A node that is shared with a clone only holds shared values and shared
+// children, so it is skipped entirely.
+func (t *BTree[K, V]) destructNode(node *BTreeNode[K, V]) {
+	if node.cow != t.cow {
+		return
+	}
+	for i := range node.items {
+		t.destructItem(&node.items[i])
+	}
+	for _, child := range node.children {
+		t.destructNode(child)
+	}
+	t.freeNode(node)
+}
+
+// Swap swaps the data of two BTrees.
+func (t *BTree[K, V]) Swap(other *BTree[K, V]) {
+	*t, *other = *other, *t
+}
+
+// DeepClone returns a new BTree with deep copies of the values, that does not share any nodes
+// with this one, see Vector.DeepClone.
+//
+// Note, the keys are still copied by assignment, so that they keep their order.
+func (t *BTree[K, V]) DeepClone() BTree[K, V] {
+	result := *t
+	result.cow = &bTreeCow{}
+	result.allocator = nil
+	if t.root != nil {
+		result.root = result.deepCopyNode(t.root)
+	}
+	return result
+}
+
+func (t *BTree[K, V]) deepCopyNode(node *BTreeNode[K, V]) *BTreeNode[K, V] {
+	copied := &BTreeNode[K, V]{cow: t.cow, items: make([]bTreeItem[K, V], len(node.items), t.maxItems())}
+	for idx, item := range node.items {
+		copied.items[idx] = bTreeItem[K, V]{key: item.key, value: DeepCopy(item.value), cow: t.cow}
+	}
+	if !node.isLeaf() {
+		copied.children = make([]*BTreeNode[K, V], len(node.children), t.maxItems()+1)
+		for idx, child := range node.children {
+			copied.children[idx] = t.deepCopyNode(child)
+		}
+	}
+	return copied
+}
+
+// Front gets the key/value pair with the lowest key.
+func (t *BTree[K, V]) Front() (key K, value V) {
+	if t.IsEmpty() {
+		panic("ERROR: BTree.Front - empty tree")
+	}
+	node := t.root
+	for !node.isLeaf() {
+		node = node.children[0]
+	}
+	return node.items[0].key, node.items[0].value
+}
+
+// Back gets the key/value pair with the highest key.
+func (t *BTree[K, V]) Back() (key K, value V) {
+	if t.IsEmpty() {
+		panic("ERROR: BTree.Back - empty tree")
+	}
+	node := t.root
+	for !node.isLeaf() {
+		node = node.children[len(node.children)-1]
+	}
+	last := len(node.items) - 1
+	return node.items[last].key, node.items[last].value
+}
+
+// Visit calls a function for every key/value pair in the BTree, in key order.
+//
+// Note, the values must not be modified through the visitor, because they may be shared with a
+// clone. Use GetRef to modify a value.
+func (t *BTree[K, V]) Visit(visitor MapVisitor[K, V]) {
+	if t.root == nil {
+		return
+	}
+	break_out := false
+	t.visitNode(t.root, nil, nil, visitor, &break_out)
+}
+
+// VisitRange calls a function for every key/value pair in the BTree with a key in the range
+// [from, to), in key order.
+//
+// Note, the values must not be modified through the visitor, because they may be shared with a
+// clone. Use GetRef to modify a value.
+func (t *BTree[K, V]) VisitRange(from K, to K, visitor MapVisitor[K, V]) {
+	if t.root == nil {
+		return
+	}
+	break_out := false
+	t.visitNode(t.root, &from, &to, visitor, &break_out)
+}
+
+// visitNode visits the subtree of node in key order, limited to the range [from, to) if they are
+// not nil. It returns false once a key at or past to has been reached.
+func (t *BTree[K, V]) visitNode(node *BTreeNode[K, V], from *K, to *K, visitor MapVisitor[K, V], break_out *bool) bool {
+	start := 0
+	if from != nil {
+		start, _ = t.find(node, from)
+	}
+	for idx := start; idx <= len(node.items); idx++ {
+		if !node.isLeaf() {
+			if !t.visitNode(node.children[idx], from, to, visitor, break_out) || *break_out {
+				return false
+			}
+		}
+		if idx == len(node.items) {
+			break
+		}
+		item := &node.items[idx]
+		if to != nil && t.compare(&item.key, to) >= 0 {
+			return false
+		}
+		visitor(&item.key, &item.value, break_out)
+		if *break_out {
+			return false
+		}
+	}
+	return true
+}
+
+// Keys returns a Vector of all the keys in the BTree, in key order.
+func (t *BTree[K, V]) Keys() Vector[K] {
+	keys := NewVector[K]()
+	t.Visit(func(key *K, value *V, break_out *bool) {
+		keys.PushBack(*key)
+	})
+	return keys
+}
+
+// Values returns a Vector of all the values in the BTree, in key order.
+func (t *BTree[K, V]) Values() Vector[V] {
+	values := NewVector[V]()
+	t.Visit(func(key *K, value *V, break_out *bool) {
+		values.PushBack(*value)
+	})
+	return values
+}
+
+// String returns a string representation of the BTree and it's contents.
+func (t *BTree[K, V]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	count := 0
+	t.Visit(func(key *K, value *V, break_out *bool) {
+		if count == t.size-1 {
+			fmt.Fprintf(&builder, "%v: %v", *key, *value)
+		} else {
+			fmt.Fprintf(&builder, "%v: %v, ", *key, *value)
+		}
+		count++
+	})
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
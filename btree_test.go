@@ -0,0 +1,216 @@
+package gollect
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkBTree verifies the B-tree invariants of t, and returns its keys in order.
+func checkBTree[K any, V any](tb testing.TB, t *BTree[K, V]) []K {
+	keys := []K{}
+	var depth = -1
+	var walk func(node *BTreeNode[K, V], level int, is_root bool)
+	walk = func(node *BTreeNode[K, V], level int, is_root bool) {
+		if !is_root && len(node.items) < t.minItems() {
+			tb.Fatalf("Node has %v items, fewer than %v", len(node.items), t.minItems())
+		}
+		if len(node.items) > t.maxItems() {
+			tb.Fatalf("Node has %v items, more than %v", len(node.items), t.maxItems())
+		}
+		if node.isLeaf() {
+			if depth < 0 {
+				depth = level
+			} else if depth != level {
+				tb.Fatalf("Leaves at depths %v and %v", depth, level)
+			}
+		} else if len(node.children) != len(node.items)+1 {
+			tb.Fatalf("Node has %v items but %v children", len(node.items), len(node.children))
+		}
+		for idx := 0; idx <= len(node.items); idx++ {
+			if !node.isLeaf() {
+				walk(node.children[idx], level+1, false)
+			}
+			if idx < len(node.items) {
+				keys = append(keys, node.items[idx].key)
+			}
+		}
+	}
+	if t.root != nil {
+		walk(t.root, 0, true)
+	}
+	for i := 1; i < len(keys); i++ {
+		if t.compare(&keys[i-1], &keys[i]) >= 0 {
+			tb.Fatalf("Keys are not sorted at %v", i)
+		}
+	}
+	if len(keys) != t.Size() {
+		tb.Fatalf("Size should be %v, got %v", len(keys), t.Size())
+	}
+	return keys
+}
+
+func TestBTreePutGetErase(t *testing.T) {
+	for _, degree := range []int{2, 3, 8} {
+		tree := NewBTree[int, int](degree)
+		reference := map[int]int{}
+		rng := rand.New(rand.NewSource(int64(degree)))
+		for i := 0; i < 3000; i++ {
+			key := rng.Intn(700)
+			if rng.Intn(3) == 0 {
+				_, present := reference[key]
+				if tree.Erase(key) != present {
+					t.Fatalf("Erase(%v) should return %v", key, present)
+				}
+				delete(reference, key)
+			} else {
+				tree.Put(key, i)
+				reference[key] = i
+			}
+		}
+		checkBTree(t, &tree)
+		for key, value := range reference {
+			if v, found := tree.Get(key); !found || v != value {
+				t.Fatalf("Get(%v) should be %v, got %v (%v)", key, value, v, found)
+			}
+		}
+	}
+}
+
+func TestBTreeBulkLoad(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 3, 4, 5, 17, 100, 1000, 4321} {
+		for _, degree := range []int{2, 3, 16} {
+			keys := NewSortableVector[int]()
+			for i := 0; i < size; i++ {
+				keys.PushBack(i * 2)
+			}
+			tree := NewBTreeFromSortableVector(degree, keys, NewVector[string]())
+			got := checkBTree(t, &tree)
+			if len(got) != size {
+				t.Fatalf("Bulk loaded tree should have %v keys, got %v", size, len(got))
+			}
+			tree.Put(1, "one")
+			tree.Erase(0)
+			checkBTree(t, &tree)
+		}
+	}
+}
+
+func TestBTreeBulkLoadUnsorted(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: BTree - bulk load keys are not sorted" {
+			t.Fatalf("Should have panicked because unsorted, got \"%v\"", result)
+		}
+	}()
+	NewBTreeFromSortedVector(2, NewVectorFromData(1, 3, 2), NewVector[int]())
+}
+
+func TestBTreeClone(t *testing.T) {
+	tree := NewBTree[int, int](3)
+	for i := 0; i < 200; i++ {
+		tree.Put(i, i)
+	}
+	clone := tree.Clone()
+	for i := 0; i < 100; i++ {
+		clone.Erase(i)
+		tree.Put(i, -i)
+	}
+	*clone.GetRef(150) = 1500
+	clone.Put(1000, 1000)
+
+	checkBTree(t, &tree)
+	checkBTree(t, &clone)
+	if tree.Size() != 200 || clone.Size() != 101 {
+		t.Fatalf("Sizes should be 200 and 101, got %v and %v", tree.Size(), clone.Size())
+	}
+	if v, _ := tree.Get(150); v != 150 {
+		t.Fatalf("Original should still have 150 at 150, got %v", v)
+	}
+	if v, _ := tree.Get(50); v != -50 {
+		t.Fatalf("Original should have -50 at 50, got %v", v)
+	}
+	if tree.ContainsKey(1000) || clone.ContainsKey(50) {
+		t.Fatalf("Modifications should not leak between clones")
+	}
+}
+
+func TestBTreeRange(t *testing.T) {
+	tree := NewBTree[int, int](2)
+	for i := 0; i < 100; i++ {
+		tree.Put(i, i)
+	}
+	visited := []int{}
+	tree.VisitRange(10, 20, func(key *int, value *int, break_out *bool) {
+		visited = append(visited, *key)
+	})
+	if len(visited) != 10 || visited[0] != 10 || visited[9] != 19 {
+		t.Fatalf("VisitRange should visit 10 to 19, got %v", visited)
+	}
+	if n := tree.EraseRange(25, 75); n != 50 {
+		t.Fatalf("EraseRange should remove 50 keys, got %v", n)
+	}
+	keys := checkBTree(t, &tree)
+	if !sort.IntsAreSorted(keys) || len(keys) != 50 || keys[25] != 75 {
+		t.Fatalf("Keys 25 to 74 should be erased, got %v", keys)
+	}
+	if k, _ := tree.Back(); k != 99 {
+		t.Fatalf("Back should be 99, got %v", k)
+	}
+}
+
+func TestBTreeEraseRange(t *testing.T) {
+	tree := NewBTree[int, int](3)
+	for i := 0; i < 1000; i++ {
+		tree.Put(i, i)
+	}
+	clone := tree.Clone()
+	if n := tree.EraseRange(990, 2000); n != 10 || tree.Size() != 990 {
+		t.Fatalf("EraseRange should remove the last 10 keys, got %v", n)
+	}
+	if n := tree.EraseRange(500, 500); n != 0 {
+		t.Fatalf("EraseRange of an empty range should remove nothing, got %v", n)
+	}
+	if clone.Size() != 1000 || len(checkBTree(t, &clone)) != 1000 {
+		t.Fatalf("EraseRange should not change a clone")
+	}
+	clone.Clear()
+
+	from := 0
+	allocs := testing.AllocsPerRun(10, func() {
+		tree.EraseRange(from, from+50)
+		from += 50
+	})
+	if allocs >= 10 {
+		t.Fatalf("EraseRange should not allocate for every key, got %v allocations", allocs)
+	}
+	keys := checkBTree(t, &tree)
+	if len(keys) != 440 || keys[0] != 550 || keys[439] != 989 {
+		t.Fatalf("Keys 0 to 549 and 990 to 999 should be erased, got %v keys", len(keys))
+	}
+}
+
+func TestBTreeCloneDestruct(t *testing.T) {
+	Msgs = []string{}
+	tree := NewBTree[int, DBool](2)
+	for i := 0; i < 20; i++ {
+		tree.Put(i, true)
+	}
+	clone := tree.Clone()
+	clone.Put(0, true)
+	clone.Erase(1)
+	clone.Clear()
+	if len(Msgs) != 1 {
+		t.Fatalf("Destruct method should only have been called on the clone's own value, got %v calls", len(Msgs))
+	}
+	if tree.Size() != 20 {
+		t.Fatalf("Original should still have 20 keys, got %v", tree.Size())
+	}
+	tree.Put(100, true)
+	tree.Put(101, true)
+	tree.Erase(101)
+	tree.Clear()
+	if len(Msgs) != 3 {
+		t.Fatalf("Destruct method should have been called 3 times, got %v", len(Msgs))
+	}
+}
@@ -0,0 +1,150 @@
+package gollect
+
+import (
+	"reflect"
+)
+
+// DeepCopy returns a deep copy of value.
+//
+// If *T implements DeepCloner[T], its DeepClone method is used, and otherwise if it implements
+// Cloner[T], its Clone method is used. Otherwise pointers, slices, maps, arrays, interfaces and
+// exported struct fields are copied recursively, preserving shared and cyclic references.
+// Unexported struct fields, functions and channels are copied by assignment.
+func DeepCopy[T any](value T) T {
+	if c, isDeepCloner := interface{}(&value).(DeepCloner[T]); isDeepCloner {
+		return c.DeepClone()
+	}
+	if c, isCloner := interface{}(&value).(Cloner[T]); isCloner {
+		return c.Clone()
+	}
+	src := reflect.ValueOf(&value).Elem()
+	dst := reflect.New(src.Type()).Elem()
+	deepCopyValue(dst, src, map[deepCopyKey]reflect.Value{})
+	return *dst.Addr().Interface().(*T)
+}
+
+// deepCopyKey identifies a pointer, slice or map that has already been copied.
+type deepCopyKey struct {
+	typ  reflect.Type
+	ptr  uintptr
+	size int
+}
+
+// cloneMethod returns the name of the method DeepCopy uses to copy values of type t, which is
+// DeepClone if *t implements DeepCloner[t], Clone if it implements Cloner[t], or "" otherwise.
+func cloneMethod(t reflect.Type) string {
+	if t.Kind() == reflect.Interface {
+		return ""
+	}
+	for _, name := range []string{"DeepClone", "Clone"} {
+		method, found := reflect.PointerTo(t).MethodByName(name)
+		if found && method.Type.NumIn() == 1 && method.Type.NumOut() == 1 && method.Type.Out(0) == t {
+			return name
+		}
+	}
+	return ""
+}
+
+// needsDeepCopy returns false if values of type t can be copied by assignment.
+func needsDeepCopy(t reflect.Type) bool {
+	if cloneMethod(t) != "" {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	case reflect.Array:
+		return needsDeepCopy(t.Elem())
+	case reflect.Struct:
+		for idx := 0; idx < t.NumField(); idx++ {
+			if t.Field(idx).IsExported() && needsDeepCopy(t.Field(idx).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func deepCopyValue(dst reflect.Value, src reflect.Value, copied map[deepCopyKey]reflect.Value) {
+	if name := cloneMethod(src.Type()); name != "" {
+		ptr := reflect.New(src.Type())
+		ptr.Elem().Set(src)
+		dst.Set(ptr.MethodByName(name).Call(nil)[0])
+		return
+	}
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		key := deepCopyKey{typ: src.Type(), ptr: src.Pointer()}
+		if existing, found := copied[key]; found {
+			dst.Set(existing)
+			return
+		}
+		ptr := reflect.New(src.Type().Elem())
+		copied[key] = ptr
+		deepCopyValue(ptr.Elem(), src.Elem(), copied)
+		dst.Set(ptr)
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		key := deepCopyKey{typ: src.Type(), ptr: src.Pointer(), size: src.Len()}
+		if existing, found := copied[key]; found {
+			dst.Set(existing)
+			return
+		}
+		slice := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		copied[key] = slice
+		if needsDeepCopy(src.Type().Elem()) {
+			for idx := 0; idx < src.Len(); idx++ {
+				deepCopyValue(slice.Index(idx), src.Index(idx), copied)
+			}
+		} else {
+			reflect.Copy(slice, src)
+		}
+		dst.Set(slice)
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		key := deepCopyKey{typ: src.Type(), ptr: src.Pointer()}
+		if existing, found := copied[key]; found {
+			dst.Set(existing)
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		copied[key] = m
+		iter := src.MapRange()
+		for iter.Next() {
+			k := reflect.New(src.Type().Key()).Elem()
+			deepCopyValue(k, iter.Key(), copied)
+			v := reflect.New(src.Type().Elem()).Elem()
+			deepCopyValue(v, iter.Value(), copied)
+			m.SetMapIndex(k, v)
+		}
+		dst.Set(m)
+	case reflect.Array:
+		for idx := 0; idx < src.Len(); idx++ {
+			deepCopyValue(dst.Index(idx), src.Index(idx), copied)
+		}
+	case reflect.Struct:
+		// Copy everything first, so unexported fields keep their values
+		dst.Set(src)
+		for idx := 0; idx < src.NumField(); idx++ {
+			if dst.Field(idx).CanSet() {
+				deepCopyValue(dst.Field(idx), src.Field(idx), copied)
+			}
+		}
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := reflect.New(src.Elem().Type()).Elem()
+		deepCopyValue(elem, src.Elem(), copied)
+		dst.Set(elem)
+	default:
+		dst.Set(src)
+	}
+}
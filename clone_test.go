@@ -0,0 +1,153 @@
+package gollect
+
+import (
+	"testing"
+)
+
+type cloneNode struct {
+	Name     string
+	Children []*cloneNode
+	Parent   *cloneNode
+	Tags     map[string]int
+	secret   *int
+}
+
+type cloneCounter struct {
+	count *int
+}
+
+func (c *cloneCounter) Clone() cloneCounter {
+	value := *c.count + 100
+	return cloneCounter{count: &value}
+}
+
+func TestDeepCopy(t *testing.T) {
+	secret := 7
+	root := &cloneNode{Name: "root", Tags: map[string]int{"a": 1}, secret: &secret}
+	child := &cloneNode{Name: "child", Parent: root}
+	root.Children = []*cloneNode{child, child}
+
+	copied := DeepCopy(root)
+	if copied == root || copied.Children[0] == child || copied.Children[0].Parent != copied {
+		t.Fatalf("DeepCopy should copy pointers and preserve cycles")
+	}
+	if copied.Children[0] != copied.Children[1] {
+		t.Fatalf("DeepCopy should preserve shared references")
+	}
+	copied.Tags["a"] = 2
+	copied.Children[0].Name = "changed"
+	if root.Tags["a"] != 1 || child.Name != "child" {
+		t.Fatalf("Modifying the copy should not modify the original")
+	}
+	if copied.secret != root.secret {
+		t.Fatalf("Unexported fields should be copied by assignment")
+	}
+}
+
+func TestDeepCopyCloner(t *testing.T) {
+	count := 1
+	c := DeepCopy(cloneCounter{count: &count})
+	if *c.count != 101 {
+		t.Fatalf("DeepCopy should use the Cloner implementation, got %v", *c.count)
+	}
+	nested := DeepCopy([]cloneCounter{{count: &count}})
+	if *nested[0].count != 101 {
+		t.Fatalf("DeepCopy should use the Cloner implementation of nested values, got %v", *nested[0].count)
+	}
+	var empty interface{}
+	if DeepCopy(empty) != nil {
+		t.Fatalf("DeepCopy of a nil interface should be nil")
+	}
+}
+
+func TestVectorDeepClone(t *testing.T) {
+	v := NewVectorFromData([]int{1, 2}, []int{3})
+	shallow := v.Clone()
+	deep := v.DeepClone()
+	(*v.AtRef(0))[0] = 10
+	if shallow.At(0)[0] != 10 || deep.At(0)[0] != 1 {
+		t.Fatalf("Clone should share the inner slices and DeepClone should not")
+	}
+	q := NewQueueFromData(v.Data()...)
+	clone := q.DeepClone()
+	(*q.FrontRef())[1] = 20
+	if clone.Front()[1] != 2 {
+		t.Fatalf("Queue.DeepClone should not share the inner slices")
+	}
+}
+
+func TestNestedCollectionDeepClone(t *testing.T) {
+	one, two := 1, 2
+	inner := NewVectorFromData(&one, &two)
+	v := NewVectorFromData(inner)
+	deep := v.DeepClone()
+	*deep.AtRef(0).At(0) = 10
+	if one != 1 {
+		t.Fatalf("DeepClone should copy the pointers of nested Vectors, got %v", one)
+	}
+	s := NewStackFromData(NewDequeFromData(&one))
+	deep_stack := DeepCopy(s)
+	top := deep_stack.Top()
+	*top.Front() = 10
+	if one != 1 {
+		t.Fatalf("DeepCopy should copy the pointers of nested collections, got %v", one)
+	}
+}
+
+func TestCollectionDeepCloneDoesNotShare(t *testing.T) {
+	v := NewVectorFromData(NewListFromData(1, 2))
+	deep := v.DeepClone()
+	deep.AtRef(0).PushBack(3)
+	if result := v.AtRef(0).String(); result != "{1, 2}" {
+		t.Fatalf("DeepClone should copy the nodes of a nested List, got %v", result)
+	}
+
+	one := 1
+	m := NewHashMap[string, *int]()
+	m.Put("one", &one)
+	deep_map := DeepCopy(NewVectorFromData(m))
+	value, _ := deep_map.AtRef(0).Get("one")
+	*value = 10
+	if one != 1 {
+		t.Fatalf("DeepCopy should copy the values of a nested HashMap, got %v", one)
+	}
+
+	s := NewSkipList[int, *int]()
+	s.Put(1, &one)
+	deep_skip := DeepCopy(NewVectorFromData(s))
+	deep_skip.AtRef(0).Put(2, &one)
+	*deep_skip.AtRef(0).GetRef(1) = nil
+	if s.Size() != 1 || *s.GetRef(1) != &one {
+		t.Fatalf("DeepCopy should copy the nodes of a nested SkipList")
+	}
+
+	r := NewRadixTree[*int]()
+	r.Insert("one", &one)
+	deep_radix := DeepCopy(NewVectorFromData(r))
+	value, _ = deep_radix.AtRef(0).Get("one")
+	*value = 10
+	deep_radix.AtRef(0).Insert("two", &one)
+	if one != 1 || r.Size() != 1 {
+		t.Fatalf("DeepCopy should copy the nodes and values of a nested RadixTree, got %v", one)
+	}
+
+	b := NewBTree[int, *int](2)
+	for idx := 0; idx < 10; idx++ {
+		b.Put(idx, &one)
+	}
+	deep_btree := b.DeepClone()
+	value, _ = deep_btree.Get(5)
+	*value = 10
+	deep_btree.Erase(3)
+	if one != 1 || b.Size() != 10 || deep_btree.Size() != 9 {
+		t.Fatalf("DeepClone should copy the nodes and values of a BTree, got %v", one)
+	}
+
+	p := NewPersistentMap[string, *int]().Put("one", &one)
+	deep_persistent := p.DeepClone()
+	value, _ = deep_persistent.Get("one")
+	*value = 10
+	if one != 1 {
+		t.Fatalf("DeepClone should copy the values of a PersistentMap, got %v", one)
+	}
+}
@@ -1,10 +1,25 @@
 package gollect
 
+import (
+	"math"
+	"reflect"
+
+	"golang.org/x/exp/constraints"
+)
+
 type EqualityComparable[T any] interface {
 	Equal(other T) bool
 	NotEqual(other T) bool
 }
 
+// Ordered identifies a type that supports a three-way comparison.
+//
+// Compare returns a negative number if the receiver is lesser than other, zero if they are
+// equal, and a positive number if the receiver is greater than other.
+type Ordered[T any] interface {
+	Compare(other T) int
+}
+
 type Comparable[T any] interface {
 	EqualityComparable[T]
 	LesserThan(other T) bool
@@ -13,6 +28,14 @@ type Comparable[T any] interface {
 	GreaterThanOrEqual(other T) bool
 }
 
+// Hashable identifies a type that can be used as a key in hash-based collections.
+//
+// Values that are Equal must return the same Hash.
+type Hashable[T any] interface {
+	EqualityComparable[T]
+	Hash() uint64
+}
+
 type Int int
 type Int8 int8
 type Int16 int16
@@ -38,6 +61,7 @@ func (i *Int) LesserThan(other Int) bool         { return *i < other }
 func (i *Int) GreaterThan(other Int) bool        { return *i > other }
 func (i *Int) LesserThanOrEqual(other Int) bool  { return *i <= other }
 func (i *Int) GreaterThanOrEqual(other Int) bool { return *i >= other }
+func (i *Int) Compare(other Int) int             { return compareNative(*i, other) }
 
 func (i *Int8) Equal(other Int8) bool              { return *i == other }
 func (i *Int8) NotEqual(other Int8) bool           { return *i != other }
@@ -45,6 +69,7 @@ func (i *Int8) LesserThan(other Int8) bool         { return *i < other }
 func (i *Int8) GreaterThan(other Int8) bool        { return *i > other }
 func (i *Int8) LesserThanOrEqual(other Int8) bool  { return *i <= other }
 func (i *Int8) GreaterThanOrEqual(other Int8) bool { return *i >= other }
+func (i *Int8) Compare(other Int8) int             { return compareNative(*i, other) }
 
 func (i *Int16) Equal(other Int16) bool              { return *i == other }
 func (i *Int16) NotEqual(other Int16) bool           { return *i != other }
@@ -52,6 +77,7 @@ func (i *Int16) LesserThan(other Int16) bool         { return *i < other }
 func (i *Int16) GreaterThan(other Int16) bool        { return *i > other }
 func (i *Int16) LesserThanOrEqual(other Int16) bool  { return *i <= other }
 func (i *Int16) GreaterThanOrEqual(other Int16) bool { return *i >= other }
+func (i *Int16) Compare(other Int16) int             { return compareNative(*i, other) }
 
 func (i *Int32) Equal(other Int32) bool              { return *i == other }
 func (i *Int32) NotEqual(other Int32) bool           { return *i != other }
@@ -59,6 +85,7 @@ func (i *Int32) LesserThan(other Int32) bool         { return *i < other }
 func (i *Int32) GreaterThan(other Int32) bool        { return *i > other }
 func (i *Int32) LesserThanOrEqual(other Int32) bool  { return *i <= other }
 func (i *Int32) GreaterThanOrEqual(other Int32) bool { return *i >= other }
+func (i *Int32) Compare(other Int32) int             { return compareNative(*i, other) }
 
 func (i *Int64) Equal(other Int64) bool              { return *i == other }
 func (i *Int64) NotEqual(other Int64) bool           { return *i != other }
@@ -66,6 +93,7 @@ func (i *Int64) LesserThan(other Int64) bool         { return *i < other }
 func (i *Int64) GreaterThan(other Int64) bool        { return *i > other }
 func (i *Int64) LesserThanOrEqual(other Int64) bool  { return *i <= other }
 func (i *Int64) GreaterThanOrEqual(other Int64) bool { return *i >= other }
+func (i *Int64) Compare(other Int64) int             { return compareNative(*i, other) }
 
 func (i *Uint) Equal(other Uint) bool              { return *i == other }
 func (i *Uint) NotEqual(other Uint) bool           { return *i != other }
@@ -73,6 +101,7 @@ func (i *Uint) LesserThan(other Uint) bool         { return *i < other }
 func (i *Uint) GreaterThan(other Uint) bool        { return *i > other }
 func (i *Uint) LesserThanOrEqual(other Uint) bool  { return *i <= other }
 func (i *Uint) GreaterThanOrEqual(other Uint) bool { return *i >= other }
+func (i *Uint) Compare(other Uint) int             { return compareNative(*i, other) }
 
 func (i *Uint8) Equal(other Uint8) bool              { return *i == other }
 func (i *Uint8) NotEqual(other Uint8) bool           { return *i != other }
@@ -80,6 +109,7 @@ func (i *Uint8) LesserThan(other Uint8) bool         { return *i < other }
 func (i *Uint8) GreaterThan(other Uint8) bool        { return *i > other }
 func (i *Uint8) LesserThanOrEqual(other Uint8) bool  { return *i <= other }
 func (i *Uint8) GreaterThanOrEqual(other Uint8) bool { return *i >= other }
+func (i *Uint8) Compare(other Uint8) int             { return compareNative(*i, other) }
 
 func (i *Uint16) Equal(other Uint16) bool              { return *i == other }
 func (i *Uint16) NotEqual(other Uint16) bool           { return *i != other }
@@ -87,6 +117,7 @@ func (i *Uint16) LesserThan(other Uint16) bool         { return *i < other }
 func (i *Uint16) GreaterThan(other Uint16) bool        { return *i > other }
 func (i *Uint16) LesserThanOrEqual(other Uint16) bool  { return *i <= other }
 func (i *Uint16) GreaterThanOrEqual(other Uint16) bool { return *i >= other }
+func (i *Uint16) Compare(other Uint16) int             { return compareNative(*i, other) }
 
 func (i *Uint32) Equal(other Uint32) bool              { return *i == other }
 func (i *Uint32) NotEqual(other Uint32) bool           { return *i != other }
@@ -94,6 +125,7 @@ func (i *Uint32) LesserThan(other Uint32) bool         { return *i < other }
 func (i *Uint32) GreaterThan(other Uint32) bool        { return *i > other }
 func (i *Uint32) LesserThanOrEqual(other Uint32) bool  { return *i <= other }
 func (i *Uint32) GreaterThanOrEqual(other Uint32) bool { return *i >= other }
+func (i *Uint32) Compare(other Uint32) int             { return compareNative(*i, other) }
 
 func (i *Uint64) Equal(other Uint64) bool              { return *i == other }
 func (i *Uint64) NotEqual(other Uint64) bool           { return *i != other }
@@ -101,6 +133,7 @@ func (i *Uint64) LesserThan(other Uint64) bool         { return *i < other }
 func (i *Uint64) GreaterThan(other Uint64) bool        { return *i > other }
 func (i *Uint64) LesserThanOrEqual(other Uint64) bool  { return *i <= other }
 func (i *Uint64) GreaterThanOrEqual(other Uint64) bool { return *i >= other }
+func (i *Uint64) Compare(other Uint64) int             { return compareNative(*i, other) }
 
 func (i *UintPtr) Equal(other UintPtr) bool              { return *i == other }
 func (i *UintPtr) NotEqual(other UintPtr) bool           { return *i != other }
@@ -108,26 +141,44 @@ func (i *UintPtr) LesserThan(other UintPtr) bool         { return *i < other }
 func (i *UintPtr) GreaterThan(other UintPtr) bool        { return *i > other }
 func (i *UintPtr) LesserThanOrEqual(other UintPtr) bool  { return *i <= other }
 func (i *UintPtr) GreaterThanOrEqual(other UintPtr) bool { return *i >= other }
+func (i *UintPtr) Compare(other UintPtr) int             { return compareNative(*i, other) }
 
-func (i *Float32) Equal(other Float32) bool              { return *i == other }
-func (i *Float32) NotEqual(other Float32) bool           { return *i != other }
-func (i *Float32) LesserThan(other Float32) bool         { return *i < other }
-func (i *Float32) GreaterThan(other Float32) bool        { return *i > other }
-func (i *Float32) LesserThanOrEqual(other Float32) bool  { return *i <= other }
-func (i *Float32) GreaterThanOrEqual(other Float32) bool { return *i >= other }
+// The Float32 and Float64 wrappers are ordered according to the IEEE 754 totalOrder predicate,
+// so that NaN values can be sorted and searched for. In particular, NaNs with the same bit
+// pattern are equal to each other, and -0 is lesser than +0.
+func (i *Float32) Equal(other Float32) bool              { return i.Compare(other) == 0 }
+func (i *Float32) NotEqual(other Float32) bool           { return i.Compare(other) != 0 }
+func (i *Float32) LesserThan(other Float32) bool         { return i.Compare(other) < 0 }
+func (i *Float32) GreaterThan(other Float32) bool        { return i.Compare(other) > 0 }
+func (i *Float32) LesserThanOrEqual(other Float32) bool  { return i.Compare(other) <= 0 }
+func (i *Float32) GreaterThanOrEqual(other Float32) bool { return i.Compare(other) >= 0 }
+func (i *Float32) Compare(other Float32) int             { return TotalOrderFloat32(float32(*i), float32(other)) }
 
-func (i *Float64) Equal(other Float64) bool              { return *i == other }
-func (i *Float64) NotEqual(other Float64) bool           { return *i != other }
-func (i *Float64) LesserThan(other Float64) bool         { return *i < other }
-func (i *Float64) GreaterThan(other Float64) bool        { return *i > other }
-func (i *Float64) LesserThanOrEqual(other Float64) bool  { return *i <= other }
-func (i *Float64) GreaterThanOrEqual(other Float64) bool { return *i >= other }
+func (i *Float64) Equal(other Float64) bool              { return i.Compare(other) == 0 }
+func (i *Float64) NotEqual(other Float64) bool           { return i.Compare(other) != 0 }
+func (i *Float64) LesserThan(other Float64) bool         { return i.Compare(other) < 0 }
+func (i *Float64) GreaterThan(other Float64) bool        { return i.Compare(other) > 0 }
+func (i *Float64) LesserThanOrEqual(other Float64) bool  { return i.Compare(other) <= 0 }
+func (i *Float64) GreaterThanOrEqual(other Float64) bool { return i.Compare(other) >= 0 }
+func (i *Float64) Compare(other Float64) int             { return TotalOrderFloat64(float64(*i), float64(other)) }
 
-func (i *Complex64) Equal(other Complex64) bool    { return *i == other }
-func (i *Complex64) NotEqual(other Complex64) bool { return *i != other }
+// The Complex64 and Complex128 wrappers are ordered lexicographically, first by their real parts
+// and then by their imaginary parts, using the IEEE 754 totalOrder predicate for both.
+func (i *Complex64) Equal(other Complex64) bool              { return i.Compare(other) == 0 }
+func (i *Complex64) NotEqual(other Complex64) bool           { return i.Compare(other) != 0 }
+func (i *Complex64) LesserThan(other Complex64) bool         { return i.Compare(other) < 0 }
+func (i *Complex64) GreaterThan(other Complex64) bool        { return i.Compare(other) > 0 }
+func (i *Complex64) LesserThanOrEqual(other Complex64) bool  { return i.Compare(other) <= 0 }
+func (i *Complex64) GreaterThanOrEqual(other Complex64) bool { return i.Compare(other) >= 0 }
+func (i *Complex64) Compare(other Complex64) int             { return compareComplex(*i, other) }
 
-func (i *Complex128) Equal(other Complex128) bool    { return *i == other }
-func (i *Complex128) NotEqual(other Complex128) bool { return *i != other }
+func (i *Complex128) Equal(other Complex128) bool              { return i.Compare(other) == 0 }
+func (i *Complex128) NotEqual(other Complex128) bool           { return i.Compare(other) != 0 }
+func (i *Complex128) LesserThan(other Complex128) bool         { return i.Compare(other) < 0 }
+func (i *Complex128) GreaterThan(other Complex128) bool        { return i.Compare(other) > 0 }
+func (i *Complex128) LesserThanOrEqual(other Complex128) bool  { return i.Compare(other) <= 0 }
+func (i *Complex128) GreaterThanOrEqual(other Complex128) bool { return i.Compare(other) >= 0 }
+func (i *Complex128) Compare(other Complex128) int             { return compareComplex(*i, other) }
 
 func (i *Byte) Equal(other Byte) bool              { return *i == other }
 func (i *Byte) NotEqual(other Byte) bool           { return *i != other }
@@ -135,6 +186,7 @@ func (i *Byte) LesserThan(other Byte) bool         { return *i < other }
 func (i *Byte) GreaterThan(other Byte) bool        { return *i > other }
 func (i *Byte) LesserThanOrEqual(other Byte) bool  { return *i <= other }
 func (i *Byte) GreaterThanOrEqual(other Byte) bool { return *i >= other }
+func (i *Byte) Compare(other Byte) int             { return compareNative(*i, other) }
 
 func (i *Rune) Equal(other Rune) bool              { return *i == other }
 func (i *Rune) NotEqual(other Rune) bool           { return *i != other }
@@ -142,6 +194,7 @@ func (i *Rune) LesserThan(other Rune) bool         { return *i < other }
 func (i *Rune) GreaterThan(other Rune) bool        { return *i > other }
 func (i *Rune) LesserThanOrEqual(other Rune) bool  { return *i <= other }
 func (i *Rune) GreaterThanOrEqual(other Rune) bool { return *i >= other }
+func (i *Rune) Compare(other Rune) int             { return compareNative(*i, other) }
 
 func (i *String) Equal(other String) bool              { return *i == other }
 func (i *String) NotEqual(other String) bool           { return *i != other }
@@ -149,3 +202,456 @@ func (i *String) LesserThan(other String) bool         { return *i < other }
 func (i *String) GreaterThan(other String) bool        { return *i > other }
 func (i *String) LesserThanOrEqual(other String) bool  { return *i <= other }
 func (i *String) GreaterThanOrEqual(other String) bool { return *i >= other }
+func (i *String) Compare(other String) int             { return compareNative(*i, other) }
+
+// Equaler is a function that reports whether two elements are equal.
+//
+// Collections such as Vector and List use an Equaler for their value-based searches.
+type Equaler[T any] func(left *T, right *T) bool
+
+// DeepEqualer returns an Equaler that compares elements with reflect.DeepEqual.
+//
+// This is useful for element types that are neither EqualityComparable nor natively
+// comparable, such as structs containing slices or maps.
+func DeepEqualer[T any]() Equaler[T] {
+	return func(left *T, right *T) bool {
+		return reflect.DeepEqual(*left, *right)
+	}
+}
+
+// NativeEqualer returns an Equaler that compares elements with the `==` operator.
+func NativeEqualer[T comparable]() Equaler[T] {
+	return func(left *T, right *T) bool {
+		return *left == *right
+	}
+}
+
+// resolveEqualer returns the Equaler a collection should use for its element type.
+//
+// In order of preference this is custom, the EqualityComparable or Ordered implementation of
+// the element type, or `==` if the element type is comparable. If none of these apply, nil is
+// returned.
+//
+// Interface element types are not considered comparable, since `==` panics when their dynamic
+// values are not, so collections of them need a custom Equaler such as DeepEqualer.
+func resolveEqualer[T any](custom Equaler[T]) Equaler[T] {
+	if custom != nil {
+		return custom
+	}
+	if _, isEqComparable := interface{}(new(T)).(EqualityComparable[T]); isEqComparable {
+		return func(left *T, right *T) bool {
+			return interface{}(left).(EqualityComparable[T]).Equal(*right)
+		}
+	}
+	if _, isOrdered := interface{}(new(T)).(Ordered[T]); isOrdered {
+		return func(left *T, right *T) bool {
+			return interface{}(left).(Ordered[T]).Compare(*right) == 0
+		}
+	}
+	if equal := nativeEqualer[T](); equal != nil {
+		return equal
+	}
+	if elem := reflect.TypeOf(new(T)).Elem(); elem.Comparable() && elem.Kind() != reflect.Interface {
+		return func(left *T, right *T) bool {
+			return interface{}(*left) == interface{}(*right)
+		}
+	}
+	return nil
+}
+
+// resolveComparator returns the Comparator a collection should use for its key type.
+//
+// In order of preference this is custom, the Ordered implementation of the key type, or the
+// native `<` and `>` operators for the predeclared ordered types. If none of these apply, nil is
+// returned.
+func resolveComparator[T any](custom Comparator[T]) Comparator[T] {
+	if custom != nil {
+		return custom
+	}
+	if _, isOrdered := interface{}(new(T)).(Ordered[T]); isOrdered {
+		return func(left *T, right *T) int {
+			return interface{}(left).(Ordered[T]).Compare(*right)
+		}
+	}
+	switch interface{}(new(T)).(type) {
+	case *int:
+		return pointerComparator[T, int]()
+	case *int8:
+		return pointerComparator[T, int8]()
+	case *int16:
+		return pointerComparator[T, int16]()
+	case *int32:
+		return pointerComparator[T, int32]()
+	case *int64:
+		return pointerComparator[T, int64]()
+	case *uint:
+		return pointerComparator[T, uint]()
+	case *uint8:
+		return pointerComparator[T, uint8]()
+	case *uint16:
+		return pointerComparator[T, uint16]()
+	case *uint32:
+		return pointerComparator[T, uint32]()
+	case *uint64:
+		return pointerComparator[T, uint64]()
+	case *uintptr:
+		return pointerComparator[T, uintptr]()
+	case *float32:
+		return pointerComparator[T, float32]()
+	case *float64:
+		return pointerComparator[T, float64]()
+	case *string:
+		return pointerComparator[T, string]()
+	}
+	return nil
+}
+
+// pointerComparator returns a Comparator for T, which must be N, that uses the native `<` and
+// `>` operators.
+func pointerComparator[T any, N constraints.Ordered]() Comparator[T] {
+	return func(left *T, right *T) int {
+		return compareNative(*interface{}(left).(*N), *interface{}(right).(*N))
+	}
+}
+
+// nativeEqualer returns an Equaler using `==` for the predeclared comparable types, which
+// compares through pointers so that the elements are not boxed, or nil for any other type.
+func nativeEqualer[T any]() Equaler[T] {
+	switch interface{}(new(T)).(type) {
+	case *int:
+		return pointerEqualer[T, int]()
+	case *int8:
+		return pointerEqualer[T, int8]()
+	case *int16:
+		return pointerEqualer[T, int16]()
+	case *int32:
+		return pointerEqualer[T, int32]()
+	case *int64:
+		return pointerEqualer[T, int64]()
+	case *uint:
+		return pointerEqualer[T, uint]()
+	case *uint8:
+		return pointerEqualer[T, uint8]()
+	case *uint16:
+		return pointerEqualer[T, uint16]()
+	case *uint32:
+		return pointerEqualer[T, uint32]()
+	case *uint64:
+		return pointerEqualer[T, uint64]()
+	case *uintptr:
+		return pointerEqualer[T, uintptr]()
+	case *float32:
+		return pointerEqualer[T, float32]()
+	case *float64:
+		return pointerEqualer[T, float64]()
+	case *complex64:
+		return pointerEqualer[T, complex64]()
+	case *complex128:
+		return pointerEqualer[T, complex128]()
+	case *string:
+		return pointerEqualer[T, string]()
+	case *bool:
+		return pointerEqualer[T, bool]()
+	}
+	return nil
+}
+
+// pointerEqualer returns an Equaler for T, which must be N, that compares with `==`.
+func pointerEqualer[T any, N comparable]() Equaler[T] {
+	return func(left *T, right *T) bool {
+		return *interface{}(left).(*N) == *interface{}(right).(*N)
+	}
+}
+
+// compareNative performs a three-way comparison using the native `<` and `>` operators.
+//
+// NaN values are treated as equal to each other and lesser than any other value, so that the
+// result is still a valid ordering for floating-point types.
+func compareNative[T constraints.Ordered](left T, right T) int {
+	if left < right {
+		return -1
+	} else if left > right {
+		return 1
+	} else if left != left {
+		if right != right {
+			return 0
+		}
+		return -1
+	} else if right != right {
+		return 1
+	}
+	return 0
+}
+
+// lessNative is a less-than function using the native `<` operator, that orders NaN values the
+// same way as compareNative.
+func lessNative[T constraints.Ordered](left T, right T) bool {
+	return left < right || (left != left && right == right)
+}
+
+// TotalOrderFloat64 performs a three-way comparison of left and right according to the IEEE 754
+// totalOrder predicate.
+//
+// Negative NaNs are lesser than -Inf, positive NaNs are greater than +Inf, -0 is lesser than +0,
+// and NaNs are ordered by their payloads.
+func TotalOrderFloat64(left float64, right float64) int {
+	l := int64(math.Float64bits(left))
+	r := int64(math.Float64bits(right))
+	// Flip every bit except the sign of negative values, so that their two's complement order
+	// matches the totalOrder predicate.
+	l ^= int64(uint64(l>>63) >> 1)
+	r ^= int64(uint64(r>>63) >> 1)
+	return compareNative(l, r)
+}
+
+// TotalOrderFloat32 performs a three-way comparison of left and right according to the IEEE 754
+// totalOrder predicate.
+func TotalOrderFloat32(left float32, right float32) int {
+	l := int32(math.Float32bits(left))
+	r := int32(math.Float32bits(right))
+	l ^= int32(uint32(l>>31) >> 1)
+	r ^= int32(uint32(r>>31) >> 1)
+	return compareNative(l, r)
+}
+
+// compareComplex compares complex numbers lexicographically, using the totalOrder predicate for
+// their real and imaginary parts.
+func compareComplex[T ~complex64 | ~complex128](left T, right T) int {
+	l, r := complex128(left), complex128(right)
+	if c := TotalOrderFloat64(real(l), real(r)); c != 0 {
+		return c
+	}
+	return TotalOrderFloat64(imag(l), imag(r))
+}
+
+// TotalOrderComparator returns a Comparator that orders floating-point values according to the
+// IEEE 754 totalOrder predicate.
+func TotalOrderComparator[T ~float32 | ~float64]() Comparator[T] {
+	var zero T
+	if reflect.TypeOf(zero).Kind() == reflect.Float32 {
+		return func(left *T, right *T) int {
+			return TotalOrderFloat32(float32(*left), float32(*right))
+		}
+	}
+	return func(left *T, right *T) int {
+		return TotalOrderFloat64(float64(*left), float64(*right))
+	}
+}
+
+// ComplexComparator returns a Comparator that orders complex values lexicographically, first by
+// their real parts and then by their imaginary parts.
+func ComplexComparator[T ~complex64 | ~complex128]() Comparator[T] {
+	return func(left *T, right *T) int {
+		return compareComplex(*left, *right)
+	}
+}
+
+// EpsilonEqualer returns an Equaler that considers floating-point values equal if they differ by
+// no more than epsilon.
+//
+// Note, this is not transitive, so it should only be used for searching, not for hashing.
+func EpsilonEqualer[T ~float32 | ~float64](epsilon T) Equaler[T] {
+	return func(left *T, right *T) bool {
+		if *left == *right {
+			return true
+		}
+		diff := *left - *right
+		if diff < 0 {
+			diff = -diff
+		}
+		return diff <= epsilon
+	}
+}
+
+// ULPEqualer returns an Equaler that considers floating-point values equal if there are no more
+// than ulps representable values between them.
+//
+// NaN values are never equal. Like EpsilonEqualer, this is not transitive.
+func ULPEqualer[T ~float32 | ~float64](ulps uint64) Equaler[T] {
+	var zero T
+	is32 := reflect.TypeOf(zero).Kind() == reflect.Float32
+	return func(left *T, right *T) bool {
+		if *left != *left || *right != *right {
+			return false
+		}
+		if *left == *right {
+			return true
+		}
+		var l, r int64
+		if is32 {
+			l, r = int64(orderedFloat32Bits(float32(*left))), int64(orderedFloat32Bits(float32(*right)))
+		} else {
+			l, r = orderedFloat64Bits(float64(*left)), orderedFloat64Bits(float64(*right))
+		}
+		if l > r {
+			l, r = r, l
+		}
+		return uint64(r-l) <= ulps
+	}
+}
+
+// orderedFloat64Bits maps value to an integer such that adjacent floating-point values map to
+// adjacent integers, and -0 and +0 both map to 0.
+func orderedFloat64Bits(value float64) int64 {
+	bits := int64(math.Float64bits(value))
+	if bits < 0 {
+		return math.MinInt64 - bits
+	}
+	return bits
+}
+
+func orderedFloat32Bits(value float32) int32 {
+	bits := int32(math.Float32bits(value))
+	if bits < 0 {
+		return math.MinInt32 - bits
+	}
+	return bits
+}
+
+// Comparator is a function that performs a three-way comparison of two elements.
+//
+// It returns a negative number if left is lesser than right, zero if they are equal, and a
+// positive number if left is greater than right.
+type Comparator[T any] func(left *T, right *T) int
+
+// NativeComparator returns a Comparator that uses the native `<` and `>` operators.
+func NativeComparator[T constraints.Ordered]() Comparator[T] {
+	return func(left *T, right *T) int {
+		return compareNative(*left, *right)
+	}
+}
+
+// OrderedComparator returns a Comparator that uses the Ordered implementation of T.
+func OrderedComparator[T any, PT interface {
+	*T
+	Ordered[T]
+}]() Comparator[T] {
+	return func(left *T, right *T) int {
+		return PT(left).Compare(*right)
+	}
+}
+
+// CompareBy returns a Comparator that orders elements by a key extracted from each of them.
+func CompareBy[T any, K constraints.Ordered](key func(*T) K) Comparator[T] {
+	return func(left *T, right *T) int {
+		return compareNative(key(left), key(right))
+	}
+}
+
+// Less converts the Comparator into a less-than function, as used by SortFunc.
+func (c Comparator[T]) Less(left *T, right *T) bool {
+	return c(left, right) < 0
+}
+
+// Equaler converts the Comparator into an Equaler.
+func (c Comparator[T]) Equaler() Equaler[T] {
+	return func(left *T, right *T) bool {
+		return c(left, right) == 0
+	}
+}
+
+// Reverse returns a Comparator with the opposite ordering.
+func (c Comparator[T]) Reverse() Comparator[T] {
+	return func(left *T, right *T) int {
+		return c(right, left)
+	}
+}
+
+// ComparableValue derives the full Comparable interface for a value from a single Comparator.
+type ComparableValue[T any] struct {
+	Value   T
+	compare Comparator[T]
+}
+
+// NewComparableValue creates a new ComparableValue, by value.
+func NewComparableValue[T any](value T, compare Comparator[T]) ComparableValue[T] {
+	return ComparableValue[T]{Value: value, compare: compare}
+}
+
+func (c *ComparableValue[T]) Compare(other ComparableValue[T]) int {
+	return c.compare(&c.Value, &other.Value)
+}
+func (c *ComparableValue[T]) Equal(other ComparableValue[T]) bool {
+	return c.Compare(other) == 0
+}
+func (c *ComparableValue[T]) NotEqual(other ComparableValue[T]) bool {
+	return c.Compare(other) != 0
+}
+func (c *ComparableValue[T]) LesserThan(other ComparableValue[T]) bool {
+	return c.Compare(other) < 0
+}
+func (c *ComparableValue[T]) GreaterThan(other ComparableValue[T]) bool {
+	return c.Compare(other) > 0
+}
+func (c *ComparableValue[T]) LesserThanOrEqual(other ComparableValue[T]) bool {
+	return c.Compare(other) <= 0
+}
+func (c *ComparableValue[T]) GreaterThanOrEqual(other ComparableValue[T]) bool {
+	return c.Compare(other) >= 0
+}
+
+// Min returns the lesser of left and right, according to their Ordered implementation.
+//
+// If they are equal, left is returned.
+func Min[T any, PT interface {
+	*T
+	Ordered[T]
+}](left T, right T) T {
+	if PT(&right).Compare(left) < 0 {
+		return right
+	}
+	return left
+}
+
+// Max returns the greater of left and right, according to their Ordered implementation.
+//
+// If they are equal, left is returned.
+func Max[T any, PT interface {
+	*T
+	Ordered[T]
+}](left T, right T) T {
+	if PT(&right).Compare(left) > 0 {
+		return right
+	}
+	return left
+}
+
+// Clamp returns value limited to the range [low, high], according to their Ordered
+// implementation.
+func Clamp[T any, PT interface {
+	*T
+	Ordered[T]
+}](value T, low T, high T) T {
+	if PT(&value).Compare(low) < 0 {
+		return low
+	} else if PT(&value).Compare(high) > 0 {
+		return high
+	}
+	return value
+}
+
+// MinFunc returns the lesser of left and right, according to compare.
+func MinFunc[T any](left T, right T, compare Comparator[T]) T {
+	if compare(&right, &left) < 0 {
+		return right
+	}
+	return left
+}
+
+// MaxFunc returns the greater of left and right, according to compare.
+func MaxFunc[T any](left T, right T, compare Comparator[T]) T {
+	if compare(&right, &left) > 0 {
+		return right
+	}
+	return left
+}
+
+// ClampFunc returns value limited to the range [low, high], according to compare.
+func ClampFunc[T any](value T, low T, high T, compare Comparator[T]) T {
+	if compare(&value, &low) < 0 {
+		return low
+	} else if compare(&value, &high) > 0 {
+		return high
+	}
+	return value
+}
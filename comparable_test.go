@@ -0,0 +1,161 @@
+package gollect
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	a, b := Int(1), Int(2)
+	if a.Compare(b) >= 0 || b.Compare(a) <= 0 || a.Compare(a) != 0 {
+		t.Fatalf("Int.Compare is inconsistent")
+	}
+	s := String("abc")
+	if s.Compare("abd") >= 0 {
+		t.Fatalf("\"abc\" should be lesser than \"abd\"")
+	}
+}
+
+func TestMinMaxClamp(t *testing.T) {
+	if m := Min[Int](3, 2); m != 2 {
+		t.Fatalf("Min should be 2, got %v", m)
+	}
+	if m := Max[Int](3, 2); m != 3 {
+		t.Fatalf("Max should be 3, got %v", m)
+	}
+	if c := Clamp[Int](5, 0, 3); c != 3 {
+		t.Fatalf("Clamp should be 3, got %v", c)
+	}
+	if c := Clamp[Int](-5, 0, 3); c != 0 {
+		t.Fatalf("Clamp should be 0, got %v", c)
+	}
+	if c := ClampFunc(2, 0, 3, NativeComparator[int]()); c != 2 {
+		t.Fatalf("ClampFunc should be 2, got %v", c)
+	}
+}
+
+func TestComparableValue(t *testing.T) {
+	byLen := CompareBy(func(s *string) int { return len(*s) })
+	a := NewComparableValue("aaa", byLen)
+	b := NewComparableValue("bb", byLen)
+	if _, isComparable := interface{}(&a).(Comparable[ComparableValue[string]]); !isComparable {
+		t.Fatalf("ComparableValue[string] should be Comparable")
+	}
+	if !b.LesserThan(a) || !a.GreaterThanOrEqual(b) || a.Equal(b) {
+		t.Fatalf("ComparableValue comparisons are inconsistent")
+	}
+	if !a.Equal(NewComparableValue("ccc", byLen)) {
+		t.Fatalf("\"aaa\" and \"ccc\" should be equal by length")
+	}
+}
+
+func TestSortByBinarySearchBy(t *testing.T) {
+	type person struct {
+		Name string
+		Age  int
+	}
+	byAge := CompareBy(func(p *person) int { return p.Age })
+	v := NewVectorFromData(person{"a", 30}, person{"b", 20}, person{"c", 40})
+	v.SortBy(byAge)
+	if !v.IsSortedBy(byAge) || v.At(0).Name != "b" {
+		t.Fatalf("Vector should be sorted by age, got %v", v.String())
+	}
+	if found, idx := v.BinarySearchBy(person{Age: 30}, byAge); !found || idx != 1 {
+		t.Fatalf("BinarySearchBy should find age 30 at 1, got %v at %v", found, idx)
+	}
+	if found, idx := v.BinarySearchBy(person{Age: 35}, byAge); found || idx != 2 {
+		t.Fatalf("BinarySearchBy should not find age 35 and return 2, got %v at %v", found, idx)
+	}
+	v.SortBy(byAge.Reverse())
+	if v.At(0).Name != "c" {
+		t.Fatalf("Vector should be reverse sorted by age, got %v", v.String())
+	}
+
+	sv := NewSortableVectorFromData(5, 1, 3)
+	sv.Sort()
+	if found, idx := sv.BinarySearch(3); !found || idx != 1 {
+		t.Fatalf("BinarySearch should find 3 at 1, got %v at %v", found, idx)
+	}
+}
+
+func TestTotalOrderFloat64(t *testing.T) {
+	nan := math.NaN()
+	negNaN := math.Copysign(nan, -1)
+	ordered := []float64{negNaN, math.Inf(-1), -1, math.Copysign(0, -1), 0, 1, math.Inf(1), nan}
+	for i := 0; i < len(ordered)-1; i++ {
+		if c := TotalOrderFloat64(ordered[i], ordered[i+1]); c >= 0 {
+			t.Fatalf("%v should be lesser than %v, got %v", ordered[i], ordered[i+1], c)
+		}
+		if c := TotalOrderFloat32(float32(ordered[i]), float32(ordered[i+1])); c >= 0 {
+			t.Fatalf("float32 %v should be lesser than %v, got %v", ordered[i], ordered[i+1], c)
+		}
+	}
+	if TotalOrderFloat64(nan, nan) != 0 {
+		t.Fatalf("NaN should be equal to itself")
+	}
+}
+
+func TestFloatWrapperNaN(t *testing.T) {
+	nan := Float64(math.NaN())
+	v := NewVectorFromData[Float64](3, nan, 1, 2)
+	if found, idx := v.OrderedSearch(nan); !found || idx != 1 {
+		t.Fatalf("OrderedSearch should find NaN at 1, got %v at %v", found, idx)
+	}
+	v.SortBy(OrderedComparator[Float64]())
+	if !v.IsSortedBy(OrderedComparator[Float64]()) || v.At(2) != 3 {
+		t.Fatalf("Vector should be sorted with NaN last, got %v", v.String())
+	}
+
+	sv := NewSortableVectorFromData(3, math.NaN(), 1, math.NaN(), 2)
+	sv.Sort()
+	if !sv.IsSorted() || !math.IsNaN(sv.At(0)) || sv.At(2) != 1 {
+		t.Fatalf("SortableVector should be sorted with NaNs first, got %v", sv.String())
+	}
+}
+
+func TestApproximateEqualers(t *testing.T) {
+	v := NewVectorWithEqualer(EpsilonEqualer(0.001), 1.0, 2.0, 3.0)
+	if found, idx := v.OrderedSearch(2.0005); !found || idx != 1 {
+		t.Fatalf("OrderedSearch should find 2.0005 at 1, got %v at %v", found, idx)
+	}
+	ulp := ULPEqualer[float64](2)
+	a, b := 1.0, math.Nextafter(math.Nextafter(1.0, 2), 2)
+	if !ulp(&a, &b) {
+		t.Fatalf("%v and %v should be within 2 ULPs", a, b)
+	}
+	c := math.Nextafter(b, 2)
+	if ulp(&a, &c) {
+		t.Fatalf("%v and %v should not be within 2 ULPs", a, c)
+	}
+	negZero, posZero := float32(math.Copysign(0, -1)), float32(0)
+	if !ULPEqualer[float32](0)(&negZero, &posZero) {
+		t.Fatalf("-0 and +0 should be within 0 ULPs")
+	}
+}
+
+func TestComplexOrdering(t *testing.T) {
+	v := NewVectorFromData[Complex128](complex(1, 2), complex(0, 5), complex(1, -1))
+	v.SortBy(OrderedComparator[Complex128]())
+	if v.At(0) != complex(0, 5) || v.At(1) != complex(1, -1) {
+		t.Fatalf("Vector should be sorted lexicographically, got %v", v.String())
+	}
+	a, b := Complex64(complex(1, 1)), Complex64(complex(1, 2))
+	if !a.LesserThan(b) {
+		t.Fatalf("%v should be lesser than %v", a, b)
+	}
+}
+
+func TestComplexEqualMatchesCompare(t *testing.T) {
+	nan := Complex128(complex(math.NaN(), 1))
+	if !nan.Equal(nan) || nan.NotEqual(nan) {
+		t.Fatalf("%v should be equal to itself under totalOrder", nan)
+	}
+	posZero, negZero := Complex64(complex(0, 0)), Complex64(complex(float32(math.Copysign(0, -1)), 0))
+	if posZero.Equal(negZero) || !posZero.NotEqual(negZero) || posZero.Compare(negZero) == 0 {
+		t.Fatalf("-0 and +0 should differ under totalOrder")
+	}
+	v := NewVectorFromData[Complex128](complex(1, 1), nan)
+	if found, idx := v.OrderedSearch(nan); !found || idx != 1 {
+		t.Fatalf("OrderedSearch should find %v at 1, got %v at %v", nan, found, idx)
+	}
+}
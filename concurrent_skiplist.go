@@ -0,0 +1,234 @@
+package gollect
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+type concurrentSkipListNode[K any, V any] struct {
+	key   K
+	value atomic.Pointer[V]
+	next  []atomic.Pointer[concurrentSkipListNode[K, V]]
+}
+
+func newConcurrentSkipListNode[K any, V any](level int) *concurrentSkipListNode[K, V] {
+	return &concurrentSkipListNode[K, V]{next: make([]atomic.Pointer[concurrentSkipListNode[K, V]], level)}
+}
+
+// ConcurrentSkipList is a SkipList that can be read from any number of goroutines while it is
+// being modified.
+//
+// Readers (Get, ContainsKey, Visit, VisitRange, Size, ...) never take a lock. Writers (Put,
+// Erase, Clear) are serialized by an internal mutex, so the list is intended for a single writer
+// alongside many readers. A reader that runs concurrently with a writer sees each key either
+// before or after the write, never a partially linked node.
+//
+// Unlike SkipList, a ConcurrentSkipList does not support access by rank, and it never calls the
+// Destruct method of replaced or removed values, because lock-free readers may still be using
+// them. Values that need cleanup must be destructed by the caller once no reader can reach them.
+//
+// Because it contains a mutex, a ConcurrentSkipList can only be created by reference, with
+// MakeConcurrentSkipList or MakeConcurrentSkipListWithComparator.
+type ConcurrentSkipList[K any, V any] struct {
+	head     *concurrentSkipListNode[K, V]
+	level    atomic.Int32
+	size     atomic.Int64
+	compare  Comparator[K]
+	writeMtx sync.Mutex
+	rng      *rand.Rand
+}
+
+// MakeConcurrentSkipList creates a new empty ConcurrentSkipList instance ordered by the native
+// `<` operator.
+func MakeConcurrentSkipList[K constraints.Ordered, V any]() *ConcurrentSkipList[K, V] {
+	return MakeConcurrentSkipListWithComparator[K, V](NativeComparator[K]())
+}
+
+// MakeConcurrentSkipListWithComparator creates a new empty ConcurrentSkipList instance ordered by
+// compare.
+func MakeConcurrentSkipListWithComparator[K any, V any](compare Comparator[K]) *ConcurrentSkipList[K, V] {
+	s := &ConcurrentSkipList[K, V]{
+		head:    newConcurrentSkipListNode[K, V](skipListMaxLevel),
+		compare: compare,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	s.level.Store(1)
+	return s
+}
+
+// IsEmpty returns true if the ConcurrentSkipList is empty.
+func (s *ConcurrentSkipList[K, V]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Size returns the number of key/value pairs in the ConcurrentSkipList.
+func (s *ConcurrentSkipList[K, V]) Size() int {
+	return int(s.size.Load())
+}
+
+// findGreaterOrEqual returns the first node whose key is not lesser than key, filling update with
+// the last node before it on every level if update is not nil.
+func (s *ConcurrentSkipList[K, V]) findGreaterOrEqual(key *K, update *[skipListMaxLevel]*concurrentSkipListNode[K, V]) *concurrentSkipListNode[K, V] {
+	node := s.head
+	for i := int(s.level.Load()) - 1; i >= 0; i-- {
+		for {
+			next := node.next[i].Load()
+			if next == nil || s.compare(&next.key, key) >= 0 {
+				break
+			}
+			node = next
+		}
+		if update != nil {
+			update[i] = node
+		}
+	}
+	return node.next[0].Load()
+}
+
+func (s *ConcurrentSkipList[K, V]) findNode(key *K) *concurrentSkipListNode[K, V] {
+	if node := s.findGreaterOrEqual(key, nil); node != nil && s.compare(&node.key, key) == 0 {
+		return node
+	}
+	return nil
+}
+
+// Put sets the value associated with key, replacing any previous value.
+//
+// Note, the previous value does not have its Destruct method called.
+func (s *ConcurrentSkipList[K, V]) Put(key K, value V) {
+	s.writeMtx.Lock()
+	defer s.writeMtx.Unlock()
+
+	var update [skipListMaxLevel]*concurrentSkipListNode[K, V]
+	if node := s.findGreaterOrEqual(&key, &update); node != nil && s.compare(&node.key, &key) == 0 {
+		node.value.Store(&value)
+		return
+	}
+
+	level := skipListRandomLevel(s.rng)
+	if current := int(s.level.Load()); level > current {
+		for i := current; i < level; i++ {
+			update[i] = s.head
+		}
+		s.level.Store(int32(level))
+	}
+
+	node := newConcurrentSkipListNode[K, V](level)
+	node.key = key
+	node.value.Store(&value)
+	// Fully link the new node before publishing it, from the bottom level up, so that readers
+	// only ever see it in its final position.
+	for i := 0; i < level; i++ {
+		node.next[i].Store(update[i].next[i].Load())
+	}
+	for i := 0; i < level; i++ {
+		update[i].next[i].Store(node)
+	}
+	s.size.Add(1)
+}
+
+// PutRef sets the value associated with key, replacing any previous value.
+func (s *ConcurrentSkipList[K, V]) PutRef(key *K, value *V) {
+	s.Put(*key, *value)
+}
+
+// Get returns the value associated with key, and whether it was found.
+func (s *ConcurrentSkipList[K, V]) Get(key K) (value V, found bool) {
+	if node := s.findNode(&key); node != nil {
+		return *node.value.Load(), true
+	}
+	return
+}
+
+// ContainsKey returns true if the ConcurrentSkipList contains key.
+func (s *ConcurrentSkipList[K, V]) ContainsKey(key K) bool {
+	return s.findNode(&key) != nil
+}
+
+// Erase removes key and its value from the ConcurrentSkipList, and returns true if it was present.
+//
+// Note, the value does not have its Destruct method called.
+func (s *ConcurrentSkipList[K, V]) Erase(key K) bool {
+	s.writeMtx.Lock()
+	defer s.writeMtx.Unlock()
+
+	var update [skipListMaxLevel]*concurrentSkipListNode[K, V]
+	node := s.findGreaterOrEqual(&key, &update)
+	if node == nil || s.compare(&node.key, &key) != 0 {
+		return false
+	}
+	// Unlink from the top level down. The erased node keeps its own links, so readers that are
+	// currently on it can still continue past it.
+	for i := len(node.next) - 1; i >= 0; i-- {
+		if update[i].next[i].Load() == node {
+			update[i].next[i].Store(node.next[i].Load())
+		}
+	}
+	for level := s.level.Load(); level > 1 && s.head.next[level-1].Load() == nil; level-- {
+		s.level.Store(level - 1)
+	}
+	s.size.Add(-1)
+	return true
+}
+
+// Clear removes all the key/value pairs from the ConcurrentSkipList.
+//
+// Note, the values do not have their Destruct method called.
+func (s *ConcurrentSkipList[K, V]) Clear() {
+	s.writeMtx.Lock()
+	defer s.writeMtx.Unlock()
+
+	for i := range s.head.next {
+		s.head.next[i].Store(nil)
+	}
+	s.level.Store(1)
+	s.size.Store(0)
+}
+
+// Visit calls a function for every key/value pair in the ConcurrentSkipList, in key order.
+//
+// Note, the visitor receives a copy of each value, so modifying it has no effect on the
+// ConcurrentSkipList.
+func (s *ConcurrentSkipList[K, V]) Visit(visitor MapVisitor[K, V]) {
+	break_out := false
+	for node := s.head.next[0].Load(); (!break_out) && (node != nil); node = node.next[0].Load() {
+		key, value := node.key, *node.value.Load()
+		visitor(&key, &value, &break_out)
+	}
+}
+
+// VisitRange calls a function for every key/value pair in the ConcurrentSkipList with a key in
+// the range [from, to), in key order.
+//
+// Note, the visitor receives a copy of each value, so modifying it has no effect on the
+// ConcurrentSkipList.
+func (s *ConcurrentSkipList[K, V]) VisitRange(from K, to K, visitor MapVisitor[K, V]) {
+	break_out := false
+	for node := s.findGreaterOrEqual(&from, nil); (!break_out) && (node != nil) && s.compare(&node.key, &to) < 0; node = node.next[0].Load() {
+		key, value := node.key, *node.value.Load()
+		visitor(&key, &value, &break_out)
+	}
+}
+
+// String returns a string representation of the ConcurrentSkipList and it's contents.
+func (s *ConcurrentSkipList[K, V]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	first := true
+	s.Visit(func(key *K, value *V, break_out *bool) {
+		if first {
+			fmt.Fprintf(&builder, "%v: %v", *key, *value)
+			first = false
+		} else {
+			fmt.Fprintf(&builder, ", %v: %v", *key, *value)
+		}
+	})
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
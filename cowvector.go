@@ -0,0 +1,201 @@
+package gollect
+
+import (
+	"sync/atomic"
+)
+
+type cowVectorStorage[T any] struct {
+	vector Vector[T]
+	refs   atomic.Int32
+}
+
+func newCowVectorStorage[T any](vector Vector[T]) *cowVectorStorage[T] {
+	storage := &cowVectorStorage[T]{vector: vector}
+	storage.refs.Store(1)
+	return storage
+}
+
+// CowVector is a copy-on-write Vector. Clone is O(1), and the clones share their elements until
+// one of them is modified, which then copies them first.
+//
+// Clones may be used from different goroutines, as long as each CowVector itself is only used by
+// one goroutine at a time. Copy a CowVector with Clone, not by assignment, otherwise both copies
+// modify the same storage.
+//
+// Note, elements are copied by assignment when the storage is separated, as with Vector.Clone.
+type CowVector[T any] struct {
+	storage *cowVectorStorage[T]
+}
+
+// NewCowVector creates a new empty CowVector, by value.
+func NewCowVector[T any]() CowVector[T] {
+	return CowVector[T]{storage: newCowVectorStorage(NewVector[T]())}
+}
+
+// NewCowVectorFromData creates a new CowVector using copies of the elements in values, by value.
+func NewCowVectorFromData[T any](values ...T) CowVector[T] {
+	return CowVector[T]{storage: newCowVectorStorage(NewVectorFromData(values...))}
+}
+
+// NewCowVectorFromVector creates a new CowVector using copies of the elements of a Vector, by
+// value.
+func NewCowVectorFromVector[T any](other Vector[T]) CowVector[T] {
+	return CowVector[T]{storage: newCowVectorStorage(other.Clone())}
+}
+
+// MakeCowVector creates a new empty CowVector instance.
+func MakeCowVector[T any]() *CowVector[T] {
+	v := NewCowVector[T]()
+	return &v
+}
+
+// MakeCowVectorFromData creates a new CowVector instance using copies of the elements in values.
+func MakeCowVectorFromData[T any](values ...T) *CowVector[T] {
+	v := NewCowVectorFromData(values...)
+	return &v
+}
+
+// MakeCowVectorFromVector creates a new CowVector instance using copies of the elements of a
+// Vector.
+func MakeCowVectorFromVector[T any](other Vector[T]) *CowVector[T] {
+	v := NewCowVectorFromVector(other)
+	return &v
+}
+
+// Clone returns a new CowVector sharing the elements of this one, in O(1) time.
+func (v *CowVector[T]) Clone() CowVector[T] {
+	v.storage.refs.Add(1)
+	return CowVector[T]{storage: v.storage}
+}
+
+// IsShared returns true if the elements are currently shared with a clone.
+func (v *CowVector[T]) IsShared() bool {
+	return v.storage.refs.Load() > 1
+}
+
+// Mutable returns the Vector holding the elements, after copying them if they were shared with a
+// clone. Any change to the CowVector can be made through it.
+//
+// Note, the returned Vector is only valid until the CowVector is next cloned.
+func (v *CowVector[T]) Mutable() *Vector[T] {
+	if v.storage.refs.Load() > 1 {
+		storage := newCowVectorStorage(v.storage.vector.Clone())
+		v.storage.refs.Add(-1)
+		v.storage = storage
+	}
+	return &v.storage.vector
+}
+
+// At gets the element at index.
+func (v *CowVector[T]) At(index int) T {
+	return v.storage.vector.At(index)
+}
+
+// AtRef gets a pointer to the element at index, after copying the elements if they were shared.
+func (v *CowVector[T]) AtRef(index int) *T {
+	return v.Mutable().AtRef(index)
+}
+
+// Set sets the element at index to value.
+func (v *CowVector[T]) Set(index int, value T) {
+	*v.Mutable().AtRef(index) = value
+}
+
+// Front gets the first element.
+func (v *CowVector[T]) Front() T {
+	return v.storage.vector.Front()
+}
+
+// Back gets the last element.
+func (v *CowVector[T]) Back() T {
+	return v.storage.vector.Back()
+}
+
+// IsEmpty returns true if the CowVector is empty.
+func (v *CowVector[T]) IsEmpty() bool {
+	return v.storage.vector.IsEmpty()
+}
+
+// Size returns the number of elements in the CowVector.
+func (v *CowVector[T]) Size() int {
+	return v.storage.vector.Size()
+}
+
+// Clear removes all the elements from the CowVector. Shared elements are released without
+// calling Destruct on them, as they are still used by a clone.
+func (v *CowVector[T]) Clear() {
+	if v.storage.refs.Load() > 1 {
+		v.storage.refs.Add(-1)
+		v.storage = newCowVectorStorage(NewVector[T]())
+		return
+	}
+	v.storage.vector.Clear()
+}
+
+// PushBack adds an element to the end of the CowVector.
+func (v *CowVector[T]) PushBack(value T) {
+	v.Mutable().PushBack(value)
+}
+
+// PopBack removes the last element of the CowVector. A shared element is released without
+// calling Destruct on it, as it is still used by a clone.
+func (v *CowVector[T]) PopBack() {
+	if v.IsEmpty() {
+		panic("ERROR: CowVector.PopBack - empty vector")
+	}
+	if v.storage.refs.Load() > 1 {
+		vector := v.Mutable()
+		vector.data = vector.data[:len(vector.data)-1]
+		return
+	}
+	v.storage.vector.PopBack()
+}
+
+// Insert adds an element at the specified index, moving all later elements one further index
+// back.
+func (v *CowVector[T]) Insert(index int, value T) {
+	v.Mutable().Insert(index, value)
+}
+
+// Erase removes the element at the specified index, moving all later elements one index forward.
+// A shared element is released without calling Destruct on it, as it is still used by a clone.
+func (v *CowVector[T]) Erase(index int) {
+	if (index < 0) || (index >= v.Size()) {
+		panic("ERROR: CowVector.Erase - index out of bounds")
+	}
+	if v.storage.refs.Load() > 1 {
+		vector := v.Mutable()
+		vector.data = append(vector.data[:index], vector.data[index+1:]...)
+		return
+	}
+	v.storage.vector.Erase(index)
+}
+
+// Swap swaps the data of two CowVectors.
+func (v *CowVector[T]) Swap(other *CowVector[T]) {
+	v.storage, other.storage = other.storage, v.storage
+}
+
+// DeepClone returns a new CowVector with deep copies of the elements, that does not share them
+// with this one, see Vector.DeepClone.
+func (v *CowVector[T]) DeepClone() CowVector[T] {
+	return CowVector[T]{storage: newCowVectorStorage(v.storage.vector.DeepClone())}
+}
+
+// Visit calls a function for every element, in order.
+//
+// Note, the visitor must not modify the elements, as they may be shared with a clone. Use
+// Mutable().Visit to modify them.
+func (v *CowVector[T]) Visit(visitor CollectionVisitor[T]) {
+	v.storage.vector.Visit(visitor)
+}
+
+// ToVector returns a Vector with copies of all the elements.
+func (v *CowVector[T]) ToVector() Vector[T] {
+	return v.storage.vector.Clone()
+}
+
+// String returns a string representation of the CowVector and it's contents.
+func (v *CowVector[T]) String() string {
+	return v.storage.vector.String()
+}
@@ -0,0 +1,84 @@
+package gollect
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCowVector(t *testing.T) {
+	a := NewCowVectorFromData(1, 2, 3)
+	b := a.Clone()
+	if !a.IsShared() || !b.IsShared() || a.storage != b.storage {
+		t.Fatalf("Clone should share the storage")
+	}
+	b.PushBack(4)
+	b.Set(0, 10)
+	if a.String() != "{1, 2, 3}" || b.String() != "{10, 2, 3, 4}" {
+		t.Fatalf("Writing to a clone should not change the original, got %v and %v", a.String(), b.String())
+	}
+	if a.IsShared() || b.IsShared() {
+		t.Fatalf("Neither should be shared after the write")
+	}
+	*a.AtRef(1) = 20
+	if a.At(1) != 20 {
+		t.Fatalf("AtRef should allow writes when not shared")
+	}
+
+	c := a.Clone()
+	c.Clear()
+	if a.Size() != 3 || !c.IsEmpty() {
+		t.Fatalf("Clear should not change the clone")
+	}
+}
+
+func TestCowVectorConcurrentClones(t *testing.T) {
+	base := NewCowVectorFromData(0, 0, 0, 0)
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		clone := base.Clone()
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for idx := 0; idx < clone.Size(); idx++ {
+				clone.Set(idx, worker)
+			}
+			for idx := 0; idx < clone.Size(); idx++ {
+				if clone.At(idx) != worker {
+					t.Errorf("Clone %v was modified by another goroutine", worker)
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+	if base.String() != "{0, 0, 0, 0}" {
+		t.Fatalf("The original should be unchanged, got %v", base.String())
+	}
+}
+
+func TestCowVectorDestruct(t *testing.T) {
+	Msgs = []string{}
+	a := NewCowVectorFromData[DBool](true, true, true)
+	b := a.Clone()
+	b.PopBack()
+	c := a.Clone()
+	c.Erase(0)
+	if len(Msgs) != 0 {
+		t.Fatalf("Destruct method should not have been called on shared elements, got %v calls", len(Msgs))
+	}
+	if a.Size() != 3 || b.Size() != 2 || c.Size() != 2 {
+		t.Fatalf("Sizes should be 3, 2 and 2, got %v, %v and %v", a.Size(), b.Size(), c.Size())
+	}
+	a.PopBack()
+	a.Erase(0)
+	if len(Msgs) != 2 {
+		t.Fatalf("Destruct method should have been called 2 times, got %v", len(Msgs))
+	}
+
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: CowVector.Erase - index out of bounds" {
+			t.Fatalf("Should have panicked because out of bounds, got \"%v\"", result)
+		}
+	}()
+	b.Erase(2)
+}
@@ -8,6 +8,8 @@ func NewDeque[T any]() Deque[T] {
 	return Deque[T]{data: NewVector[T]()}
 }
 
+// NewDequeFromData creates a new Deque using copies of the elements in values, by value. The
+// Deque never shares storage with values.
 func NewDequeFromData[T any](values ...T) Deque[T] {
 	return Deque[T]{data: NewVectorFromData(values...)}
 }
@@ -16,6 +18,7 @@ func NewDequeFromDataRef[T any](values ...*T) Deque[T] {
 	return Deque[T]{data: NewVectorFromDataRef(values...)}
 }
 
+// NewDequeFromDeque creates a new Deque using copies of the elements of another, by value.
 func NewDequeFromDeque[T any](other Deque[T]) Deque[T] {
 	return Deque[T]{data: NewVectorFromVector(other.data)}
 }
@@ -24,6 +27,8 @@ func MakeDeque[T any]() *Deque[T] {
 	return &Deque[T]{data: NewVector[T]()}
 }
 
+// MakeDequeFromData creates a new Deque instance using copies of the elements in values. The
+// Deque never shares storage with values.
 func MakeDequeFromData[T any](values ...T) *Deque[T] {
 	return &Deque[T]{data: NewVectorFromData(values...)}
 }
@@ -32,6 +37,7 @@ func MakeDequeFromDataRef[T any](values ...*T) *Deque[T] {
 	return &Deque[T]{data: NewVectorFromDataRef(values...)}
 }
 
+// MakeDequeFromDeque creates a new Deque instance using copies of the elements of another.
 func MakeDequeFromDeque[T any](other Deque[T]) *Deque[T] {
 	return &Deque[T]{data: NewVectorFromVector(other.data)}
 }
@@ -52,6 +58,7 @@ func (d *Deque[T]) BackRef() *T {
 	return d.data.BackRef()
 }
 
+// Data gets the underlying slice of the elements, which is still owned by the Deque.
 func (d *Deque[T]) Data() []T {
 	return d.data.Data()
 }
@@ -92,10 +99,70 @@ func (d *Deque[T]) PopFront() {
 	d.data.PopFront()
 }
 
+func (d *Deque[T]) FindIf(predicate CollectionPredicate[T]) *T {
+	return d.data.FindIf(predicate)
+}
+
+func (d *Deque[T]) FindLastIf(predicate CollectionPredicate[T]) *T {
+	return d.data.FindLastIf(predicate)
+}
+
+func (d *Deque[T]) ContainsIf(predicate CollectionPredicate[T]) bool {
+	return d.data.ContainsIf(predicate)
+}
+
+func (d *Deque[T]) CountIf(predicate CollectionPredicate[T]) int {
+	return d.data.CountIf(predicate)
+}
+
+func (d *Deque[T]) IndexIf(predicate CollectionPredicate[T]) int {
+	return d.data.IndexIf(predicate)
+}
+
+// Reserve makes sure the Deque has room for at least capacity elements without allocating.
+func (d *Deque[T]) Reserve(capacity int) {
+	if capacity < 0 {
+		panic("ERROR: Deque.Reserve - negative capacity")
+	}
+	d.data.Reserve(capacity)
+}
+
+// Capacity returns the number of elements the Deque can hold without allocating.
+func (d *Deque[T]) Capacity() int {
+	return d.data.Capacity()
+}
+
+// ShrinkToFit releases any spare capacity of the Deque.
+func (d *Deque[T]) ShrinkToFit() {
+	d.data.ShrinkToFit()
+}
+
+// SetGrowthPolicy sets how the Deque grows when it runs out of capacity.
+func (d *Deque[T]) SetGrowthPolicy(policy GrowthPolicy) {
+	policy.validate("Deque.SetGrowthPolicy")
+	d.data.SetGrowthPolicy(policy)
+}
+
+// GrowthPolicy returns how the Deque grows when it runs out of capacity.
+func (d *Deque[T]) GrowthPolicy() GrowthPolicy {
+	return d.data.GrowthPolicy()
+}
+
 func (d *Deque[T]) Swap(other *Deque[T]) {
 	d.data.Swap(&other.data)
 }
 
+// Clone returns a new Deque with copies of the elements, that does not share storage with this
+// one.
+func (d *Deque[T]) Clone() Deque[T] {
+	return Deque[T]{data: d.data.Clone()}
+}
+
+// DeepClone returns a new Deque with deep copies of the elements, see Vector.DeepClone.
+func (d *Deque[T]) DeepClone() Deque[T] {
+	return Deque[T]{data: d.data.DeepClone()}
+}
+
 func (d *Deque[T]) String() string {
 	return d.data.String()
 }
@@ -0,0 +1,372 @@
+package gollect
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DenseDisjointSet is a union-find structure over the integers 0 to Size()-1, using path
+// compression and union by rank.
+//
+// Use it instead of a DisjointSet when the elements are already dense integer ids, as it avoids
+// hashing entirely.
+type DenseDisjointSet struct {
+	parent    NVector[int]
+	rank      NVector[int]
+	set_size  NVector[int]
+	set_count int
+}
+
+// NewDenseDisjointSet creates a new DenseDisjointSet of size singleton sets, by value.
+func NewDenseDisjointSet(size int) DenseDisjointSet {
+	if size < 0 {
+		panic("ERROR: DenseDisjointSet - negative size")
+	}
+	d := DenseDisjointSet{
+		parent:   NVector[int]{data: make([]int, size)},
+		rank:     NVector[int]{data: make([]int, size)},
+		set_size: NVector[int]{data: make([]int, size)},
+	}
+	for idx := 0; idx < size; idx++ {
+		d.parent.data[idx] = idx
+		d.set_size.data[idx] = 1
+	}
+	d.set_count = size
+	return d
+}
+
+// NewDenseDisjointSetFromDenseDisjointSet creates a new DenseDisjointSet with the same sets as
+// another, by value.
+func NewDenseDisjointSetFromDenseDisjointSet(other DenseDisjointSet) DenseDisjointSet {
+	return DenseDisjointSet{
+		parent:    other.parent.Clone(),
+		rank:      other.rank.Clone(),
+		set_size:  other.set_size.Clone(),
+		set_count: other.set_count,
+	}
+}
+
+// MakeDenseDisjointSet creates a new DenseDisjointSet instance of size singleton sets.
+func MakeDenseDisjointSet(size int) *DenseDisjointSet {
+	d := NewDenseDisjointSet(size)
+	return &d
+}
+
+// MakeDenseDisjointSetFromDenseDisjointSet creates a new DenseDisjointSet instance with the same
+// sets as another.
+func MakeDenseDisjointSetFromDenseDisjointSet(other DenseDisjointSet) *DenseDisjointSet {
+	d := NewDenseDisjointSetFromDenseDisjointSet(other)
+	return &d
+}
+
+// Size returns the number of elements in the DenseDisjointSet.
+func (d *DenseDisjointSet) Size() int {
+	return len(d.parent.data)
+}
+
+// IsEmpty returns true if the DenseDisjointSet has no elements.
+func (d *DenseDisjointSet) IsEmpty() bool {
+	return len(d.parent.data) == 0
+}
+
+// SetCount returns the number of disjoint sets.
+func (d *DenseDisjointSet) SetCount() int {
+	return d.set_count
+}
+
+// Add adds a new element in its own set, and returns it.
+func (d *DenseDisjointSet) Add() int {
+	element := len(d.parent.data)
+	d.parent.PushBack(element)
+	d.rank.PushBack(0)
+	d.set_size.PushBack(1)
+	d.set_count++
+	return element
+}
+
+func (d *DenseDisjointSet) check(element int, method string) {
+	if element < 0 || element >= len(d.parent.data) {
+		panic("ERROR: DenseDisjointSet." + method + " - element out of range")
+	}
+}
+
+func (d *DenseDisjointSet) find(element int) int {
+	root := element
+	for d.parent.data[root] != root {
+		root = d.parent.data[root]
+	}
+	for d.parent.data[element] != root {
+		next := d.parent.data[element]
+		d.parent.data[element] = root
+		element = next
+	}
+	return root
+}
+
+// Find returns the representative element of the set containing element.
+func (d *DenseDisjointSet) Find(element int) int {
+	d.check(element, "Find")
+	return d.find(element)
+}
+
+// Union merges the sets containing a and b, and returns true if they were different sets.
+func (d *DenseDisjointSet) Union(a int, b int) bool {
+	d.check(a, "Union")
+	d.check(b, "Union")
+	a, b = d.find(a), d.find(b)
+	if a == b {
+		return false
+	}
+	if d.rank.data[a] < d.rank.data[b] {
+		a, b = b, a
+	}
+	d.parent.data[b] = a
+	d.set_size.data[a] += d.set_size.data[b]
+	if d.rank.data[a] == d.rank.data[b] {
+		d.rank.data[a]++
+	}
+	d.set_count--
+	return true
+}
+
+// Connected returns true if a and b are in the same set.
+func (d *DenseDisjointSet) Connected(a int, b int) bool {
+	d.check(a, "Connected")
+	d.check(b, "Connected")
+	return d.find(a) == d.find(b)
+}
+
+// SetSize returns the number of elements in the set containing element.
+func (d *DenseDisjointSet) SetSize(element int) int {
+	d.check(element, "SetSize")
+	return d.set_size.data[d.find(element)]
+}
+
+// Sets returns every set, each in increasing order. The sets are ordered by their lowest element.
+func (d *DenseDisjointSet) Sets() Vector[Vector[int]] {
+	sets := NewVector[Vector[int]]()
+	set_index := make(map[int]int, d.set_count)
+	for element := range d.parent.data {
+		root := d.find(element)
+		index, found := set_index[root]
+		if !found {
+			index = sets.Size()
+			set_index[root] = index
+			sets.PushBack(Vector[int]{data: make([]int, 0, d.set_size.data[root])})
+		}
+		sets.AtRef(index).PushBack(element)
+	}
+	return sets
+}
+
+// Clear removes all the elements from the DenseDisjointSet.
+func (d *DenseDisjointSet) Clear() {
+	d.parent.Clear()
+	d.rank.Clear()
+	d.set_size.Clear()
+	d.set_count = 0
+}
+
+// Swap swaps the data of two DenseDisjointSets.
+func (d *DenseDisjointSet) Swap(other *DenseDisjointSet) {
+	*d, *other = *other, *d
+}
+
+// Clone returns a new DenseDisjointSet with the same sets, that does not share storage with this
+// one.
+func (d *DenseDisjointSet) Clone() DenseDisjointSet {
+	return NewDenseDisjointSetFromDenseDisjointSet(*d)
+}
+
+// String returns a string representation of the DenseDisjointSet and it's sets.
+func (d *DenseDisjointSet) String() string {
+	sets := d.Sets()
+	return disjointSetString(&sets)
+}
+
+func disjointSetString[T any](sets *Vector[Vector[T]]) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	for idx := range sets.data {
+		if idx > 0 {
+			fmt.Fprintf(&builder, ", ")
+		}
+		fmt.Fprintf(&builder, "%v", sets.data[idx].String())
+	}
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
+
+// DisjointSet is a union-find structure over arbitrary comparable elements, using path
+// compression and union by rank.
+//
+// Each element is given a dense id in the order it was added, and the sets are kept in a
+// DenseDisjointSet over those ids.
+type DisjointSet[T comparable] struct {
+	ids      map[T]int
+	elements Vector[T]
+	sets     DenseDisjointSet
+}
+
+// NewDisjointSet creates a new empty DisjointSet, by value.
+func NewDisjointSet[T comparable]() DisjointSet[T] {
+	return DisjointSet[T]{ids: map[T]int{}, elements: NewVector[T](), sets: NewDenseDisjointSet(0)}
+}
+
+// NewDisjointSetFromData creates a new DisjointSet with each of values in its own set, by value.
+func NewDisjointSetFromData[T comparable](values ...T) DisjointSet[T] {
+	d := NewDisjointSet[T]()
+	for _, val := range values {
+		d.Add(val)
+	}
+	return d
+}
+
+// NewDisjointSetFromDisjointSet creates a new DisjointSet with the same sets as another, by
+// value.
+func NewDisjointSetFromDisjointSet[T comparable](other DisjointSet[T]) DisjointSet[T] {
+	d := DisjointSet[T]{
+		ids:      make(map[T]int, len(other.ids)),
+		elements: other.elements.Clone(),
+		sets:     NewDenseDisjointSetFromDenseDisjointSet(other.sets),
+	}
+	for key, id := range other.ids {
+		d.ids[key] = id
+	}
+	return d
+}
+
+// MakeDisjointSet creates a new empty DisjointSet instance.
+func MakeDisjointSet[T comparable]() *DisjointSet[T] {
+	d := NewDisjointSet[T]()
+	return &d
+}
+
+// MakeDisjointSetFromData creates a new DisjointSet instance with each of values in its own set.
+func MakeDisjointSetFromData[T comparable](values ...T) *DisjointSet[T] {
+	d := NewDisjointSetFromData(values...)
+	return &d
+}
+
+// MakeDisjointSetFromDisjointSet creates a new DisjointSet instance with the same sets as
+// another.
+func MakeDisjointSetFromDisjointSet[T comparable](other DisjointSet[T]) *DisjointSet[T] {
+	d := NewDisjointSetFromDisjointSet(other)
+	return &d
+}
+
+// Size returns the number of elements in the DisjointSet.
+func (d *DisjointSet[T]) Size() int {
+	return d.elements.Size()
+}
+
+// IsEmpty returns true if the DisjointSet has no elements.
+func (d *DisjointSet[T]) IsEmpty() bool {
+	return d.elements.IsEmpty()
+}
+
+// SetCount returns the number of disjoint sets.
+func (d *DisjointSet[T]) SetCount() int {
+	return d.sets.SetCount()
+}
+
+// Contains returns true if value has been added to the DisjointSet.
+func (d *DisjointSet[T]) Contains(value T) bool {
+	_, found := d.ids[value]
+	return found
+}
+
+func (d *DisjointSet[T]) id(value T) int {
+	id, found := d.ids[value]
+	if !found {
+		id = d.sets.Add()
+		d.ids[value] = id
+		d.elements.PushBack(value)
+	}
+	return id
+}
+
+func (d *DisjointSet[T]) mustID(value T, method string) int {
+	id, found := d.ids[value]
+	if !found {
+		panic("ERROR: DisjointSet." + method + " - element not found")
+	}
+	return id
+}
+
+// Add adds value in its own set, and returns true if it was not already present.
+func (d *DisjointSet[T]) Add(value T) bool {
+	if d.Contains(value) {
+		return false
+	}
+	d.id(value)
+	return true
+}
+
+// Find returns the representative element of the set containing value.
+//
+// Panics if value has not been added.
+func (d *DisjointSet[T]) Find(value T) T {
+	return d.elements.data[d.sets.find(d.mustID(value, "Find"))]
+}
+
+// Union merges the sets containing a and b, and returns true if they were different sets.
+// Elements that have not been added yet are added first.
+func (d *DisjointSet[T]) Union(a T, b T) bool {
+	return d.sets.Union(d.id(a), d.id(b))
+}
+
+// Connected returns true if a and b are in the same set. Elements that have not been added are
+// not connected to anything.
+func (d *DisjointSet[T]) Connected(a T, b T) bool {
+	a_id, a_found := d.ids[a]
+	b_id, b_found := d.ids[b]
+	return a_found && b_found && d.sets.find(a_id) == d.sets.find(b_id)
+}
+
+// SetSize returns the number of elements in the set containing value.
+//
+// Panics if value has not been added.
+func (d *DisjointSet[T]) SetSize(value T) int {
+	return d.sets.SetSize(d.mustID(value, "SetSize"))
+}
+
+// Sets returns every set, each in the order its elements were added. The sets are ordered by
+// their first added element.
+func (d *DisjointSet[T]) Sets() Vector[Vector[T]] {
+	id_sets := d.sets.Sets()
+	sets := Vector[Vector[T]]{data: make([]Vector[T], len(id_sets.data))}
+	for idx, ids := range id_sets.data {
+		set := Vector[T]{data: make([]T, len(ids.data))}
+		for i, id := range ids.data {
+			set.data[i] = d.elements.data[id]
+		}
+		sets.data[idx] = set
+	}
+	return sets
+}
+
+// Clear removes all the elements from the DisjointSet.
+func (d *DisjointSet[T]) Clear() {
+	d.ids = map[T]int{}
+	d.elements.Clear()
+	d.sets.Clear()
+}
+
+// Swap swaps the data of two DisjointSets.
+func (d *DisjointSet[T]) Swap(other *DisjointSet[T]) {
+	*d, *other = *other, *d
+}
+
+// Clone returns a new DisjointSet with the same sets, that does not share storage with this one.
+//
+// Note, the elements are copied by assignment, even by DeepCopy, as they identify the sets.
+func (d *DisjointSet[T]) Clone() DisjointSet[T] {
+	return NewDisjointSetFromDisjointSet(*d)
+}
+
+// String returns a string representation of the DisjointSet and it's sets.
+func (d *DisjointSet[T]) String() string {
+	sets := d.Sets()
+	return disjointSetString(&sets)
+}
@@ -0,0 +1,92 @@
+package gollect
+
+import (
+	"testing"
+)
+
+func TestDenseDisjointSet(t *testing.T) {
+	d := NewDenseDisjointSet(6)
+	if !d.Union(0, 1) || !d.Union(2, 3) || !d.Union(1, 3) || d.Union(0, 2) {
+		t.Fatalf("Union returned the wrong result")
+	}
+	if !d.Connected(0, 3) || d.Connected(0, 4) {
+		t.Fatalf("Connected returned the wrong result")
+	}
+	if d.SetSize(2) != 4 || d.SetSize(5) != 1 || d.SetCount() != 3 {
+		t.Fatalf("Set sizes are wrong, got %v", d.String())
+	}
+	if d.Find(0) != d.Find(3) {
+		t.Fatalf("0 and 3 should have the same representative")
+	}
+	if d.Add() != 6 || d.SetCount() != 4 {
+		t.Fatalf("Add should have created a seventh singleton set")
+	}
+	d.Union(6, 4)
+	if d.String() != "{{0, 1, 2, 3}, {4, 6}, {5}}" {
+		t.Fatalf("Sets should be {{0, 1, 2, 3}, {4, 6}, {5}}, got %v", d.String())
+	}
+}
+
+func TestDenseDisjointSetOutOfRange(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: DenseDisjointSet.Find - element out of range" {
+			t.Fatalf("Should have panicked because out of range, got \"%v\"", result)
+		}
+	}()
+	d := NewDenseDisjointSet(3)
+	d.Find(3)
+}
+
+func TestDenseDisjointSetPathCompression(t *testing.T) {
+	d := NewDenseDisjointSet(1000)
+	for idx := 1; idx < 1000; idx++ {
+		d.Union(idx-1, idx)
+	}
+	root := d.Find(999)
+	for idx := 0; idx < 1000; idx++ {
+		d.Find(idx)
+		if d.parent.At(idx) != root {
+			t.Fatalf("Find(%v) should have pointed it directly at the root", idx)
+		}
+	}
+	if d.rank.At(root) > 10 {
+		t.Fatalf("Union by rank should keep the rank logarithmic, got %v", d.rank.At(root))
+	}
+}
+
+func TestDisjointSet(t *testing.T) {
+	d := NewDisjointSetFromData("a", "b", "c")
+	if d.Add("a") || !d.Add("d") {
+		t.Fatalf("Add returned the wrong result")
+	}
+	d.Union("a", "c")
+	d.Union("e", "d")
+	if !d.Connected("c", "a") || d.Connected("a", "b") || d.Connected("a", "z") {
+		t.Fatalf("Connected returned the wrong result")
+	}
+	if d.Find("e") != d.Find("d") || d.SetSize("e") != 2 || d.Size() != 5 {
+		t.Fatalf("Sets are wrong, got %v", d.String())
+	}
+	sets := d.Sets()
+	if sets.Size() != 3 || sets.AtRef(0).String() != "{a, c}" || sets.AtRef(2).String() != "{d, e}" {
+		t.Fatalf("Sets should be {{a, c}, {b}, {d, e}}, got %v", d.String())
+	}
+
+	copied := NewDisjointSetFromDisjointSet(d)
+	copied.Union("a", "b")
+	if d.Connected("a", "b") || !copied.Connected("c", "b") {
+		t.Fatalf("Copy should be independent of the original")
+	}
+}
+
+func TestDisjointSetNotFound(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: DisjointSet.SetSize - element not found" {
+			t.Fatalf("Should have panicked because not found, got \"%v\"", result)
+		}
+	}()
+	d := NewDisjointSet[int]()
+	d.SetSize(1)
+}
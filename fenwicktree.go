@@ -0,0 +1,179 @@
+package gollect
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FenwickTree (or binary indexed tree) holds a sequence of numbers, and computes the sum of any
+// prefix or range of them in O(log n) time, with O(log n) point updates.
+type FenwickTree[T NativeNumeric] struct {
+	// tree is 1-indexed, tree[i] holds the sum of the i&-i elements ending at element i-1
+	tree []T
+}
+
+// NewFenwickTree creates a new FenwickTree of size zeroes, by value.
+func NewFenwickTree[T NativeNumeric](size int) FenwickTree[T] {
+	if size < 0 {
+		panic("ERROR: FenwickTree - negative size")
+	}
+	return FenwickTree[T]{tree: make([]T, size+1)}
+}
+
+// NewFenwickTreeFromData creates a new FenwickTree holding values, by value, in O(n) time.
+func NewFenwickTreeFromData[T NativeNumeric](values ...T) FenwickTree[T] {
+	f := FenwickTree[T]{tree: make([]T, len(values)+1)}
+	copy(f.tree[1:], values)
+	for i := 1; i < len(f.tree); i++ {
+		if parent := i + i&-i; parent < len(f.tree) {
+			f.tree[parent] += f.tree[i]
+		}
+	}
+	return f
+}
+
+// NewFenwickTreeFromNVector creates a new FenwickTree holding the elements of other, by value, in
+// O(n) time.
+func NewFenwickTreeFromNVector[T NativeNumeric](other NVector[T]) FenwickTree[T] {
+	return NewFenwickTreeFromData(other.data...)
+}
+
+// NewFenwickTreeFromFenwickTree creates a new FenwickTree with the same elements as another, by
+// value.
+func NewFenwickTreeFromFenwickTree[T NativeNumeric](other FenwickTree[T]) FenwickTree[T] {
+	return FenwickTree[T]{tree: append([]T{}, other.tree...)}
+}
+
+// MakeFenwickTree creates a new FenwickTree instance of size zeroes.
+func MakeFenwickTree[T NativeNumeric](size int) *FenwickTree[T] {
+	f := NewFenwickTree[T](size)
+	return &f
+}
+
+// MakeFenwickTreeFromData creates a new FenwickTree instance holding values, in O(n) time.
+func MakeFenwickTreeFromData[T NativeNumeric](values ...T) *FenwickTree[T] {
+	f := NewFenwickTreeFromData(values...)
+	return &f
+}
+
+// MakeFenwickTreeFromNVector creates a new FenwickTree instance holding the elements of other, in
+// O(n) time.
+func MakeFenwickTreeFromNVector[T NativeNumeric](other NVector[T]) *FenwickTree[T] {
+	f := NewFenwickTreeFromNVector(other)
+	return &f
+}
+
+// MakeFenwickTreeFromFenwickTree creates a new FenwickTree instance with the same elements as
+// another.
+func MakeFenwickTreeFromFenwickTree[T NativeNumeric](other FenwickTree[T]) *FenwickTree[T] {
+	f := NewFenwickTreeFromFenwickTree(other)
+	return &f
+}
+
+// Size returns the number of elements in the FenwickTree.
+func (f *FenwickTree[T]) Size() int {
+	return len(f.tree) - 1
+}
+
+// IsEmpty returns true if the FenwickTree has no elements.
+func (f *FenwickTree[T]) IsEmpty() bool {
+	return len(f.tree) <= 1
+}
+
+func (f *FenwickTree[T]) check(index int, method string) {
+	if index < 0 || index >= len(f.tree)-1 {
+		panic("ERROR: FenwickTree." + method + " - index out of range")
+	}
+}
+
+// Add adds delta to the element at index.
+func (f *FenwickTree[T]) Add(index int, delta T) {
+	f.check(index, "Add")
+	for i := index + 1; i < len(f.tree); i += i & -i {
+		f.tree[i] += delta
+	}
+}
+
+// Set sets the element at index to value.
+func (f *FenwickTree[T]) Set(index int, value T) {
+	f.check(index, "Set")
+	f.Add(index, value-f.At(index))
+}
+
+// At returns the element at index.
+func (f *FenwickTree[T]) At(index int) T {
+	f.check(index, "At")
+	return f.RangeSum(index, index+1)
+}
+
+// PrefixSum returns the sum of the first count elements.
+func (f *FenwickTree[T]) PrefixSum(count int) T {
+	if count < 0 || count >= len(f.tree) {
+		panic("ERROR: FenwickTree.PrefixSum - count out of range")
+	}
+	var sum T
+	for i := count; i > 0; i -= i & -i {
+		sum += f.tree[i]
+	}
+	return sum
+}
+
+// RangeSum returns the sum of the elements in the range [from, to).
+func (f *FenwickTree[T]) RangeSum(from int, to int) T {
+	if from < 0 || to >= len(f.tree) || from > to {
+		panic("ERROR: FenwickTree.RangeSum - range out of range")
+	}
+	return f.PrefixSum(to) - f.PrefixSum(from)
+}
+
+// PushBack appends value to the end of the FenwickTree, in O(log n) time.
+func (f *FenwickTree[T]) PushBack(value T) {
+	i := len(f.tree)
+	// tree[i] covers the i&-i elements ending at the new one, all but the last already present
+	sum := value
+	if lower := i & -i; lower > 1 {
+		sum += f.PrefixSum(i-1) - f.PrefixSum(i-lower)
+	}
+	f.tree = append(f.tree, sum)
+}
+
+// Clear removes all the elements from the FenwickTree.
+func (f *FenwickTree[T]) Clear() {
+	f.tree = f.tree[:1]
+}
+
+// Swap swaps the data of two FenwickTrees.
+func (f *FenwickTree[T]) Swap(other *FenwickTree[T]) {
+	*f, *other = *other, *f
+}
+
+// Clone returns a new FenwickTree with the same elements, that does not share storage with this
+// one.
+func (f *FenwickTree[T]) Clone() FenwickTree[T] {
+	return NewFenwickTreeFromFenwickTree(*f)
+}
+
+// ToNVector returns an NVector of all the elements, in O(n) time.
+func (f *FenwickTree[T]) ToNVector() NVector[T] {
+	values := append([]T{}, f.tree[1:]...)
+	for i := len(values); i >= 1; i-- {
+		if parent := i + i&-i; parent <= len(values) {
+			values[parent-1] -= values[i-1]
+		}
+	}
+	return NVector[T]{data: values}
+}
+
+// String returns a string representation of the FenwickTree and it's elements.
+func (f *FenwickTree[T]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	for idx, val := range f.ToNVector().data {
+		if idx > 0 {
+			fmt.Fprintf(&builder, ", ")
+		}
+		fmt.Fprintf(&builder, "%v", val)
+	}
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
@@ -0,0 +1,57 @@
+package gollect
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFenwickTree(t *testing.T) {
+	f := NewFenwickTreeFromNVector(NewNVectorFromData(3, 1, 4, 1, 5, 9, 2, 6))
+	if f.PrefixSum(4) != 9 || f.RangeSum(2, 6) != 19 || f.PrefixSum(0) != 0 {
+		t.Fatalf("Sums are wrong for %v", f.String())
+	}
+	f.Add(2, 10)
+	f.Set(7, 0)
+	if f.At(2) != 14 || f.RangeSum(0, 8) != 35 {
+		t.Fatalf("Updates are wrong, got %v", f.String())
+	}
+	f.PushBack(5)
+	if f.Size() != 9 || f.PrefixSum(9) != 40 || f.String() != "{3, 1, 14, 1, 5, 9, 2, 0, 5}" {
+		t.Fatalf("PushBack is wrong, got %v", f.String())
+	}
+}
+
+func TestFenwickTreeOutOfRange(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: FenwickTree.Add - index out of range" {
+			t.Fatalf("Should have panicked because out of range, got \"%v\"", result)
+		}
+	}()
+	f := NewFenwickTree[float64](4)
+	f.Add(4, 1)
+}
+
+func TestFenwickTreeRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(40))
+	values := make([]int, 0)
+	f := NewFenwickTree[int](0)
+	for idx := 0; idx < 300; idx++ {
+		value := rng.Intn(100)
+		values = append(values, value)
+		f.PushBack(value)
+	}
+	built := NewFenwickTreeFromData(values...)
+	for idx := range values {
+		if f.At(idx) != values[idx] || built.At(idx) != values[idx] {
+			t.Fatalf("At(%v) should be %v", idx, values[idx])
+		}
+	}
+	sum := 0
+	for idx, value := range values {
+		if f.PrefixSum(idx) != sum {
+			t.Fatalf("PrefixSum(%v) should be %v, got %v", idx, sum, f.PrefixSum(idx))
+		}
+		sum += value
+	}
+}
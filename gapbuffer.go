@@ -0,0 +1,541 @@
+package gollect
+
+import (
+	"fmt"
+	"strings"
+)
+
+// gapBufferMinGap is the smallest gap a GapBuffer opens when it grows.
+const gapBufferMinGap = 16
+
+// GapBuffer is a general-purpose sequence optimized for editing around a cursor.
+//
+// The elements are stored in one slice with a gap of unused space at the cursor. Inserting or
+// erasing at the cursor takes amortized O(1) time, and moving the cursor costs time
+// proportional to the distance moved, so edits close to each other are cheap. Insert and Erase
+// at any index move the cursor there first.
+//
+// It does have special functionality for element types that implement the Destructible
+// interface.
+type GapBuffer[T any] struct {
+	data      []T
+	gap_start int
+	gap_end   int
+	equal     Equaler[T]
+}
+
+// NewGapBuffer creates a new empty GapBuffer, by value.
+func NewGapBuffer[T any]() GapBuffer[T] {
+	return GapBuffer[T]{}
+}
+
+// NewGapBufferFromData creates a new GapBuffer holding a copy of values, by value, with the
+// cursor at the end.
+func NewGapBufferFromData[T any](values ...T) GapBuffer[T] {
+	data := make([]T, len(values)+gapBufferMinGap)
+	copy(data, values)
+	return GapBuffer[T]{data: data, gap_start: len(values), gap_end: len(data)}
+}
+
+// NewGapBufferFromGapBuffer creates a new GapBuffer holding a copy of the elements of other, by
+// value, with the cursor at the same index.
+func NewGapBufferFromGapBuffer[T any](other GapBuffer[T]) GapBuffer[T] {
+	return GapBuffer[T]{
+		data:      append([]T{}, other.data...),
+		gap_start: other.gap_start,
+		gap_end:   other.gap_end,
+		equal:     other.equal,
+	}
+}
+
+// NewGapBufferWithEqualer creates a new GapBuffer holding a copy of values, by value, that uses
+// equal in value-based searches.
+func NewGapBufferWithEqualer[T any](equal Equaler[T], values ...T) GapBuffer[T] {
+	g := NewGapBufferFromData(values...)
+	g.equal = equal
+	return g
+}
+
+// MakeGapBuffer creates a new empty GapBuffer instance.
+func MakeGapBuffer[T any]() *GapBuffer[T] {
+	return &GapBuffer[T]{}
+}
+
+// MakeGapBufferFromData creates a new GapBuffer instance holding a copy of values, with the
+// cursor at the end.
+func MakeGapBufferFromData[T any](values ...T) *GapBuffer[T] {
+	g := NewGapBufferFromData(values...)
+	return &g
+}
+
+// MakeGapBufferFromGapBuffer creates a new GapBuffer instance holding a copy of the elements of
+// other, with the cursor at the same index.
+func MakeGapBufferFromGapBuffer[T any](other GapBuffer[T]) *GapBuffer[T] {
+	g := NewGapBufferFromGapBuffer(other)
+	return &g
+}
+
+// MakeGapBufferWithEqualer creates a new GapBuffer instance holding a copy of values, that uses
+// equal in value-based searches.
+func MakeGapBufferWithEqualer[T any](equal Equaler[T], values ...T) *GapBuffer[T] {
+	g := NewGapBufferWithEqualer(equal, values...)
+	return &g
+}
+
+// SetEqualer sets the function used to compare elements in value-based searches.
+//
+// If equal is nil, the GapBuffer falls back to the EqualityComparable implementation of its
+// element type, or to `==` if the element type is comparable.
+func (g *GapBuffer[T]) SetEqualer(equal Equaler[T]) {
+	g.equal = equal
+}
+
+func (g *GapBuffer[T]) equaler(method string) Equaler[T] {
+	equal := resolveEqualer(g.equal)
+	if equal == nil {
+		panic("ERROR: GapBuffer." + method + " - no Equaler for element type")
+	}
+	return equal
+}
+
+// physical returns the index in data of the element at index.
+func (g *GapBuffer[T]) physical(index int) int {
+	if index < g.gap_start {
+		return index
+	}
+	return index + g.gap_end - g.gap_start
+}
+
+// Size returns the number of elements in the GapBuffer.
+func (g *GapBuffer[T]) Size() int {
+	return len(g.data) - (g.gap_end - g.gap_start)
+}
+
+// IsEmpty returns true if the GapBuffer is empty.
+func (g *GapBuffer[T]) IsEmpty() bool {
+	return g.Size() == 0
+}
+
+// Cursor returns the index of the cursor, which is where the gap is.
+func (g *GapBuffer[T]) Cursor() int {
+	return g.gap_start
+}
+
+// SetCursor moves the cursor to index, which may be equal to Size.
+func (g *GapBuffer[T]) SetCursor(index int) {
+	if index < 0 || index > g.Size() {
+		panic("ERROR: GapBuffer.SetCursor - index out of bounds")
+	}
+	g.moveGap(index)
+}
+
+func (g *GapBuffer[T]) moveGap(index int) {
+	var zero T
+	if index < g.gap_start {
+		moved := g.gap_start - index
+		copy(g.data[g.gap_end-moved:g.gap_end], g.data[index:g.gap_start])
+		// Clear the vacated slots, so they do not keep anything alive
+		for idx := index; idx < g.gap_start && idx < g.gap_end-moved; idx++ {
+			g.data[idx] = zero
+		}
+		g.gap_start -= moved
+		g.gap_end -= moved
+	} else if index > g.gap_start {
+		moved := index - g.gap_start
+		copy(g.data[g.gap_start:g.gap_start+moved], g.data[g.gap_end:g.gap_end+moved])
+		for idx := g.gap_end; idx < g.gap_end+moved; idx++ {
+			if idx >= g.gap_start+moved {
+				g.data[idx] = zero
+			}
+		}
+		g.gap_start += moved
+		g.gap_end += moved
+	}
+}
+
+// reserveGap makes sure the gap has room for at least count elements.
+func (g *GapBuffer[T]) reserveGap(count int) {
+	if g.gap_end-g.gap_start >= count {
+		return
+	}
+	size := g.Size()
+	capacity := 2 * len(g.data)
+	if capacity < size+count+gapBufferMinGap {
+		capacity = size + count + gapBufferMinGap
+	}
+	data := make([]T, capacity)
+	copy(data, g.data[:g.gap_start])
+	after := len(g.data) - g.gap_end
+	copy(data[capacity-after:], g.data[g.gap_end:])
+	g.data = data
+	g.gap_end = capacity - after
+}
+
+// InsertAtCursor adds an element at the cursor, and moves the cursor after it.
+func (g *GapBuffer[T]) InsertAtCursor(value T) {
+	g.reserveGap(1)
+	g.data[g.gap_start] = value
+	g.gap_start++
+}
+
+// InsertRangeAtCursor adds elements at the cursor, and moves the cursor after them.
+func (g *GapBuffer[T]) InsertRangeAtCursor(values ...T) {
+	g.reserveGap(len(values))
+	copy(g.data[g.gap_start:], values)
+	g.gap_start += len(values)
+}
+
+// DeleteBeforeCursor removes the element before the cursor, like a backspace key.
+//
+// If the element implements the Destructible interface, it will have the Destruct method called on it.
+func (g *GapBuffer[T]) DeleteBeforeCursor() {
+	if g.gap_start == 0 {
+		panic("ERROR: GapBuffer.DeleteBeforeCursor - cursor at start")
+	}
+	g.gap_start--
+	g.destroy(g.gap_start)
+}
+
+// DeleteAfterCursor removes the element after the cursor, like a delete key.
+//
+// If the element implements the Destructible interface, it will have the Destruct method called on it.
+func (g *GapBuffer[T]) DeleteAfterCursor() {
+	if g.gap_end == len(g.data) {
+		panic("ERROR: GapBuffer.DeleteAfterCursor - cursor at end")
+	}
+	g.destroy(g.gap_end)
+	g.gap_end++
+}
+
+// destroy calls Destruct on the element at the physical index idx if it is Destructible, and
+// clears it.
+func (g *GapBuffer[T]) destroy(idx int) {
+	if e, isDestructible := interface{}(&g.data[idx]).(Destructible); isDestructible {
+		e.Destruct()
+	}
+	var zero T
+	g.data[idx] = zero
+}
+
+// At gets the element at index by value.
+func (g *GapBuffer[T]) At(index int) T {
+	return *g.AtRef(index)
+}
+
+// AtRef gets a pointer to the element at index.
+//
+// Note, the pointer is only valid until the GapBuffer is next modified or its cursor moves.
+func (g *GapBuffer[T]) AtRef(index int) *T {
+	if index < 0 || index >= g.Size() {
+		panic("ERROR: GapBuffer.AtRef - index out of range")
+	}
+	return &g.data[g.physical(index)]
+}
+
+// Front gets the element at the front of the GapBuffer by value.
+func (g *GapBuffer[T]) Front() T {
+	return *g.FrontRef()
+}
+
+// FrontRef gets a pointer to the element at the front of the GapBuffer.
+func (g *GapBuffer[T]) FrontRef() *T {
+	if g.IsEmpty() {
+		panic("ERROR: GapBuffer.FrontRef - empty gap buffer")
+	}
+	return &g.data[g.physical(0)]
+}
+
+// Back gets the element at the back of the GapBuffer by value.
+func (g *GapBuffer[T]) Back() T {
+	return *g.BackRef()
+}
+
+// BackRef gets a pointer to the element at the back of the GapBuffer.
+func (g *GapBuffer[T]) BackRef() *T {
+	if g.IsEmpty() {
+		panic("ERROR: GapBuffer.BackRef - empty gap buffer")
+	}
+	return &g.data[g.physical(g.Size()-1)]
+}
+
+// Clear removes all the elements from the GapBuffer.
+//
+// If the elements implement the Destructible interface, then they will have the Destruct method called on them.
+func (g *GapBuffer[T]) Clear() {
+	for idx := 0; idx < g.gap_start; idx++ {
+		g.destroy(idx)
+	}
+	for idx := g.gap_end; idx < len(g.data); idx++ {
+		g.destroy(idx)
+	}
+	g.gap_start, g.gap_end = 0, len(g.data)
+}
+
+// Insert adds an element at the specified index, and moves the cursor after it.
+func (g *GapBuffer[T]) Insert(index int, value T) {
+	if index < 0 || index > g.Size() {
+		panic("ERROR: GapBuffer.Insert - index out of bounds")
+	}
+	g.moveGap(index)
+	g.InsertAtCursor(value)
+}
+
+// InsertRef adds an element at the specified index, and moves the cursor after it.
+func (g *GapBuffer[T]) InsertRef(index int, value *T) {
+	if index < 0 || index > g.Size() {
+		panic("ERROR: GapBuffer.InsertRef - index out of bounds")
+	}
+	g.moveGap(index)
+	g.InsertAtCursor(*value)
+}
+
+// Erase removes the element at the specified index, and moves the cursor to index.
+//
+// If the element implements the Destructible interface, it will have the Destruct method called on it.
+func (g *GapBuffer[T]) Erase(index int) {
+	if g.IsEmpty() {
+		panic("ERROR: GapBuffer.Erase - empty gap buffer")
+	}
+	if index < 0 || index >= g.Size() {
+		panic("ERROR: GapBuffer.Erase - index out of bounds")
+	}
+	g.moveGap(index)
+	g.DeleteAfterCursor()
+}
+
+// PushBack adds an element to the back of the GapBuffer, and moves the cursor to the end.
+func (g *GapBuffer[T]) PushBack(value T) {
+	g.Insert(g.Size(), value)
+}
+
+// PushBackRef adds an element to the back of the GapBuffer, and moves the cursor to the end.
+func (g *GapBuffer[T]) PushBackRef(value *T) {
+	g.Insert(g.Size(), *value)
+}
+
+// PushFront adds an element to the front of the GapBuffer, and moves the cursor after it.
+func (g *GapBuffer[T]) PushFront(value T) {
+	g.Insert(0, value)
+}
+
+// PushFrontRef adds an element to the front of the GapBuffer, and moves the cursor after it.
+func (g *GapBuffer[T]) PushFrontRef(value *T) {
+	g.Insert(0, *value)
+}
+
+// PopBack removes an element from the back of the GapBuffer.
+//
+// If the element implements the Destructible interface, it will have the Destruct method called on it.
+func (g *GapBuffer[T]) PopBack() {
+	if g.IsEmpty() {
+		panic("ERROR: GapBuffer.PopBack - empty gap buffer")
+	}
+	g.Erase(g.Size() - 1)
+}
+
+// PopFront removes an element from the front of the GapBuffer.
+//
+// If the element implements the Destructible interface, it will have the Destruct method called on it.
+func (g *GapBuffer[T]) PopFront() {
+	if g.IsEmpty() {
+		panic("ERROR: GapBuffer.PopFront - empty gap buffer")
+	}
+	g.Erase(0)
+}
+
+// Swap swaps the data of two GapBuffers.
+func (g *GapBuffer[T]) Swap(other *GapBuffer[T]) {
+	*g, *other = *other, *g
+}
+
+// Clone returns a new GapBuffer with copies of the elements and the same cursor, that does not
+// share storage with this one.
+//
+// Elements are copied by assignment, so pointers, slices and maps inside them are still shared.
+// Use DeepClone to copy those too.
+func (g *GapBuffer[T]) Clone() GapBuffer[T] {
+	return NewGapBufferFromGapBuffer(*g)
+}
+
+// DeepClone returns a new GapBuffer with deep copies of the elements, see Vector.DeepClone.
+func (g *GapBuffer[T]) DeepClone() GapBuffer[T] {
+	result := g.Clone()
+	result.Visit(func(item *T, break_out *bool) {
+		*item = DeepCopy(*item)
+	})
+	return result
+}
+
+// Visit calls a function for every element in the GapBuffer.
+func (g *GapBuffer[T]) Visit(visitor CollectionVisitor[T]) {
+	break_out := false
+	for idx := 0; !break_out && idx < g.gap_start; idx++ {
+		visitor(&g.data[idx], &break_out)
+	}
+	for idx := g.gap_end; !break_out && idx < len(g.data); idx++ {
+		visitor(&g.data[idx], &break_out)
+	}
+}
+
+// VisitReverse calls a function for every element in the GapBuffer in reverse order.
+func (g *GapBuffer[T]) VisitReverse(visitor CollectionVisitor[T]) {
+	break_out := false
+	for idx := len(g.data) - 1; !break_out && idx >= g.gap_end; idx-- {
+		visitor(&g.data[idx], &break_out)
+	}
+	for idx := g.gap_start - 1; !break_out && idx >= 0; idx-- {
+		visitor(&g.data[idx], &break_out)
+	}
+}
+
+// ContainsValue returns true if the GapBuffer contains value.
+//
+// Panics if the GapBuffer has no Equaler for its element type.
+func (g *GapBuffer[T]) ContainsValue(value T) bool {
+	found, _ := g.OrderedSearch(value)
+	return found
+}
+
+// ContainsRef returns true if the GapBuffer contains the exact reference of value.
+func (g *GapBuffer[T]) ContainsRef(value *T) bool {
+	return g.OrderedRefSearchRef(value) != nil
+}
+
+// OrderedSearch searches for a value, and returns the index to the first match.
+//
+// Panics if the GapBuffer has no Equaler for its element type.
+func (g *GapBuffer[T]) OrderedSearch(value T) (found bool, index int) {
+	equal := g.equaler("OrderedSearch")
+	return g.indexWhere(func(vv *T) bool { return equal(vv, &value) })
+}
+
+// OrderedRefSearch searches for an instance, and returns the index to the first match.
+func (g *GapBuffer[T]) OrderedRefSearch(value *T) (found bool, index int) {
+	return g.indexWhere(func(vv *T) bool { return vv == value })
+}
+
+// OrderedSearchRef searches for a value, and returns a pointer to the first match.
+//
+// Panics if the GapBuffer has no Equaler for its element type.
+func (g *GapBuffer[T]) OrderedSearchRef(value T) *T {
+	equal := g.equaler("OrderedSearchRef")
+	if found, index := g.indexWhere(func(vv *T) bool { return equal(vv, &value) }); found {
+		return g.AtRef(index)
+	}
+	return nil
+}
+
+// OrderedRefSearchRef searches for an instance, and returns a pointer to the first match.
+func (g *GapBuffer[T]) OrderedRefSearchRef(value *T) *T {
+	if found, _ := g.indexWhere(func(vv *T) bool { return vv == value }); found {
+		return value
+	}
+	return nil
+}
+
+// Search searches for a value, and returns an index to a match.
+//
+// Panics if the GapBuffer has no Equaler for its element type.
+func (g *GapBuffer[T]) Search(value T) (found bool, index int) {
+	return g.OrderedSearch(value)
+}
+
+// RefSearch searches for an instance, and returns an index to a match.
+func (g *GapBuffer[T]) RefSearch(value *T) (found bool, index int) {
+	return g.OrderedRefSearch(value)
+}
+
+// SearchRef searches for a value, and returns a pointer to a match.
+//
+// Panics if the GapBuffer has no Equaler for its element type.
+func (g *GapBuffer[T]) SearchRef(value T) *T {
+	return g.OrderedSearchRef(value)
+}
+
+// RefSearchRef searches for an instance, and returns a pointer to a match.
+func (g *GapBuffer[T]) RefSearchRef(value *T) *T {
+	return g.OrderedRefSearchRef(value)
+}
+
+func (g *GapBuffer[T]) indexWhere(predicate CollectionPredicate[T]) (found bool, index int) {
+	index = 0
+	g.Visit(func(vv *T, break_out *bool) {
+		if predicate(vv) {
+			found = true
+			*break_out = true
+		} else {
+			index++
+		}
+	})
+	if !found {
+		index = -1
+	}
+	return
+}
+
+// FindIf returns a pointer to the first element that satisfies predicate, or nil if there is none.
+func (g *GapBuffer[T]) FindIf(predicate CollectionPredicate[T]) *T {
+	if found, index := g.indexWhere(predicate); found {
+		return g.AtRef(index)
+	}
+	return nil
+}
+
+// FindLastIf returns a pointer to the last element that satisfies predicate, or nil if there is none.
+func (g *GapBuffer[T]) FindLastIf(predicate CollectionPredicate[T]) *T {
+	var ret *T = nil
+	g.VisitReverse(func(vv *T, break_out *bool) {
+		if predicate(vv) {
+			ret = vv
+			*break_out = true
+		}
+	})
+	return ret
+}
+
+// ContainsIf returns true if any element satisfies predicate.
+func (g *GapBuffer[T]) ContainsIf(predicate CollectionPredicate[T]) bool {
+	found, _ := g.indexWhere(predicate)
+	return found
+}
+
+// CountIf returns the number of elements that satisfy predicate.
+func (g *GapBuffer[T]) CountIf(predicate CollectionPredicate[T]) int {
+	count := 0
+	g.Visit(func(vv *T, break_out *bool) {
+		if predicate(vv) {
+			count++
+		}
+	})
+	return count
+}
+
+// IndexIf returns the index of the first element that satisfies predicate, or -1 if there is none.
+func (g *GapBuffer[T]) IndexIf(predicate CollectionPredicate[T]) int {
+	_, index := g.indexWhere(predicate)
+	return index
+}
+
+// ToVector returns a Vector holding a copy of the elements of the GapBuffer.
+func (g *GapBuffer[T]) ToVector() Vector[T] {
+	data := make([]T, 0, g.Size())
+	data = append(data, g.data[:g.gap_start]...)
+	data = append(data, g.data[g.gap_end:]...)
+	return Vector[T]{data: data, equal: g.equal}
+}
+
+// String returns a string representation of the GapBuffer and it's contents.
+func (g *GapBuffer[T]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	first := true
+	g.Visit(func(value *T, break_out *bool) {
+		if !first {
+			fmt.Fprintf(&builder, ", ")
+		}
+		first = false
+		fmt.Fprintf(&builder, "%v", *value)
+	})
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
@@ -0,0 +1,87 @@
+package gollect
+
+import (
+	"testing"
+)
+
+func TestGapBufferIsGeneralCollector(t *testing.T) {
+	g := NewGapBuffer[int64]()
+	if _, isGeneralCollector := interface{}(&g).(GeneralCollector[int64]); !isGeneralCollector {
+		t.Fatalf("GapBuffer[int64] should be a GeneralCollector[int64]")
+	}
+}
+
+func TestGapBufferCursor(t *testing.T) {
+	g := NewGapBufferFromData([]rune("helo")...)
+	g.SetCursor(3)
+	g.InsertAtCursor('l')
+	g.SetCursor(g.Size())
+	g.InsertRangeAtCursor([]rune(" world")...)
+	g.DeleteBeforeCursor()
+	g.SetCursor(0)
+	g.DeleteAfterCursor()
+	g.InsertAtCursor('j')
+	values := g.ToVector()
+	if s := string(values.Data()); s != "jello worl" {
+		t.Fatalf("GapBuffer should be \"jello worl\", got \"%v\"", s)
+	}
+	if g.Cursor() != 1 {
+		t.Fatalf("Cursor should be 1, got %v", g.Cursor())
+	}
+}
+
+func TestGapBufferMatchesVector(t *testing.T) {
+	g := NewGapBuffer[int]()
+	v := NewVector[int]()
+	for i := 0; i < 1000; i++ {
+		index := (i * 31) % (v.Size() + 1)
+		g.Insert(index, i)
+		v.Insert(index, i)
+		if i%3 == 0 {
+			index = (i * 17) % v.Size()
+			g.Erase(index)
+			v.Erase(index)
+		}
+	}
+	if g.String() != v.String() {
+		t.Fatalf("GapBuffer should match the Vector")
+	}
+	reversed := []int{}
+	g.VisitReverse(func(value *int, break_out *bool) {
+		reversed = append(reversed, *value)
+	})
+	for i := range reversed {
+		if reversed[i] != v.At(v.Size()-1-i) {
+			t.Fatalf("VisitReverse should visit the elements in reverse order")
+		}
+	}
+	if found, index := g.Search(v.At(100)); !found || index != 100 {
+		t.Fatalf("Search should find index 100, got %v (%v)", index, found)
+	}
+	if g.Front() != v.Front() || g.Back() != v.Back() {
+		t.Fatalf("Front and Back should match the Vector")
+	}
+}
+
+func TestGapBufferDestruct(t *testing.T) {
+	Msgs = []string{}
+	g := NewGapBufferFromData[DBool](true, true, true, true)
+	g.PopFront()
+	g.PopBack()
+	g.Erase(1)
+	g.Clear()
+	if len(Msgs) != 4 {
+		t.Fatalf("Destruct method should have been called 4 times, got %v", len(Msgs))
+	}
+}
+
+func TestGapBufferEmptyPop(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: GapBuffer.PopBack - empty gap buffer" {
+			t.Fatalf("Should have panicked because the gap buffer is empty, got \"%v\"", result)
+		}
+	}()
+	g := NewGapBuffer[int]()
+	g.PopBack()
+}
@@ -7,6 +7,19 @@ type Destructible interface {
 	Destruct()
 }
 
+// Cloner identifies a type that knows how to make a deep copy of itself, used by DeepCopy and the
+// DeepClone methods of the collections.
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// DeepCloner identifies a type whose Clone method may be shallow, but that knows how to make a
+// deep copy of itself with DeepClone. It takes precedence over Cloner in DeepCopy and the
+// DeepClone methods of the collections.
+type DeepCloner[T any] interface {
+	DeepClone() T
+}
+
 // CollectionVisitor is a function that will be called on every element of a collection.
 type CollectionVisitor[T any] func(*T, *bool)
 
@@ -52,3 +65,23 @@ type NativeEquatable interface {
 type NativeComparable interface {
 	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr | ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64 | ~string
 }
+
+// NativeNumeric identifies the set of types that can have the `+`, `-`, and `*` operators used on them.
+type NativeNumeric interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr | ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64 | ~complex64 | ~complex128
+}
+
+// CollectionPredicate is a function that tests an element of a collection.
+type CollectionPredicate[T any] func(*T) bool
+
+// PredicateSearcher identifies a collection that can be searched with a CollectionPredicate.
+type PredicateSearcher[T any] interface {
+	FindIf(predicate CollectionPredicate[T]) *T
+	FindLastIf(predicate CollectionPredicate[T]) *T
+	ContainsIf(predicate CollectionPredicate[T]) bool
+	CountIf(predicate CollectionPredicate[T]) int
+	IndexIf(predicate CollectionPredicate[T]) int
+}
+
+// MapVisitor is a function that will be called on every key/value pair of a map-like collection.
+type MapVisitor[K any, V any] func(*K, *V, *bool)
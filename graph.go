@@ -0,0 +1,497 @@
+package gollect
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"strings"
+)
+
+type graphEdge[E any] struct {
+	to    int
+	value E
+}
+
+// Graph is a directed or undirected graph, stored as adjacency lists.
+//
+// Vertices can be any comparable type, and every edge carries a value of type E, such as a weight
+// or a label. Use struct{} for E if edges carry nothing. There is at most one edge from one
+// vertex to another. An undirected edge is stored once in the adjacency list of each of its ends.
+type Graph[V comparable, E any] struct {
+	directed   bool
+	ids        map[V]int
+	vertices   Vector[V]
+	adjacency  Vector[Vector[graphEdge[E]]]
+	edge_count int
+}
+
+func newGraph[V comparable, E any](directed bool) Graph[V, E] {
+	return Graph[V, E]{
+		directed:  directed,
+		ids:       map[V]int{},
+		vertices:  NewVector[V](),
+		adjacency: NewVector[Vector[graphEdge[E]]](),
+	}
+}
+
+// NewDirectedGraph creates a new empty directed Graph, by value.
+func NewDirectedGraph[V comparable, E any]() Graph[V, E] {
+	return newGraph[V, E](true)
+}
+
+// NewUndirectedGraph creates a new empty undirected Graph, by value.
+func NewUndirectedGraph[V comparable, E any]() Graph[V, E] {
+	return newGraph[V, E](false)
+}
+
+// NewGraphFromGraph creates a new Graph with the same vertices and edges as another, by value.
+func NewGraphFromGraph[V comparable, E any](other Graph[V, E]) Graph[V, E] {
+	g := Graph[V, E]{
+		directed:   other.directed,
+		ids:        make(map[V]int, len(other.ids)),
+		vertices:   Vector[V]{data: append([]V{}, other.vertices.data...)},
+		adjacency:  Vector[Vector[graphEdge[E]]]{data: make([]Vector[graphEdge[E]], len(other.adjacency.data))},
+		edge_count: other.edge_count,
+	}
+	for vertex, id := range other.ids {
+		g.ids[vertex] = id
+	}
+	for idx, edges := range other.adjacency.data {
+		g.adjacency.data[idx] = Vector[graphEdge[E]]{data: append([]graphEdge[E]{}, edges.data...)}
+	}
+	return g
+}
+
+// MakeDirectedGraph creates a new empty directed Graph instance.
+func MakeDirectedGraph[V comparable, E any]() *Graph[V, E] {
+	g := NewDirectedGraph[V, E]()
+	return &g
+}
+
+// MakeUndirectedGraph creates a new empty undirected Graph instance.
+func MakeUndirectedGraph[V comparable, E any]() *Graph[V, E] {
+	g := NewUndirectedGraph[V, E]()
+	return &g
+}
+
+// MakeGraphFromGraph creates a new Graph instance with the same vertices and edges as another.
+func MakeGraphFromGraph[V comparable, E any](other Graph[V, E]) *Graph[V, E] {
+	g := NewGraphFromGraph(other)
+	return &g
+}
+
+// IsDirected returns true if the Graph is directed.
+func (g *Graph[V, E]) IsDirected() bool {
+	return g.directed
+}
+
+// VertexCount returns the number of vertices in the Graph.
+func (g *Graph[V, E]) VertexCount() int {
+	return g.vertices.Size()
+}
+
+// EdgeCount returns the number of edges in the Graph. An undirected edge is counted once.
+func (g *Graph[V, E]) EdgeCount() int {
+	return g.edge_count
+}
+
+// IsEmpty returns true if the Graph has no vertices.
+func (g *Graph[V, E]) IsEmpty() bool {
+	return g.vertices.IsEmpty()
+}
+
+// Vertices returns all the vertices of the Graph, in the order they were added.
+func (g *Graph[V, E]) Vertices() Vector[V] {
+	return Vector[V]{data: append([]V{}, g.vertices.data...)}
+}
+
+func (g *Graph[V, E]) id(vertex V) int {
+	id, found := g.ids[vertex]
+	if !found {
+		id = g.vertices.Size()
+		g.ids[vertex] = id
+		g.vertices.PushBack(vertex)
+		g.adjacency.PushBack(NewVector[graphEdge[E]]())
+	}
+	return id
+}
+
+func (g *Graph[V, E]) mustID(vertex V, method string) int {
+	id, found := g.ids[vertex]
+	if !found {
+		panic("ERROR: Graph." + method + " - vertex not found")
+	}
+	return id
+}
+
+// findEdge returns the index of the edge from one vertex id to another in the adjacency list of
+// from, or -1.
+func (g *Graph[V, E]) findEdge(from int, to int) int {
+	for idx, edge := range g.adjacency.data[from].data {
+		if edge.to == to {
+			return idx
+		}
+	}
+	return -1
+}
+
+// AddVertex adds vertex to the Graph, and returns true if it was not already present.
+func (g *Graph[V, E]) AddVertex(vertex V) bool {
+	if g.ContainsVertex(vertex) {
+		return false
+	}
+	g.id(vertex)
+	return true
+}
+
+// ContainsVertex returns true if vertex is in the Graph.
+func (g *Graph[V, E]) ContainsVertex(vertex V) bool {
+	_, found := g.ids[vertex]
+	return found
+}
+
+// AddEdge adds an edge between two vertices, adding the vertices first if they are not already
+// present. If the edge already exists its value is replaced. Returns true if the edge is new.
+func (g *Graph[V, E]) AddEdge(from V, to V, value E) bool {
+	from_id, to_id := g.id(from), g.id(to)
+	if idx := g.findEdge(from_id, to_id); idx >= 0 {
+		g.adjacency.data[from_id].data[idx].value = value
+		if !g.directed && from_id != to_id {
+			g.adjacency.data[to_id].data[g.findEdge(to_id, from_id)].value = value
+		}
+		return false
+	}
+	g.adjacency.data[from_id].PushBack(graphEdge[E]{to: to_id, value: value})
+	if !g.directed && from_id != to_id {
+		g.adjacency.data[to_id].PushBack(graphEdge[E]{to: from_id, value: value})
+	}
+	g.edge_count++
+	return true
+}
+
+// RemoveEdge removes the edge between two vertices, and returns true if it was present.
+func (g *Graph[V, E]) RemoveEdge(from V, to V) bool {
+	from_id, from_found := g.ids[from]
+	to_id, to_found := g.ids[to]
+	if !from_found || !to_found {
+		return false
+	}
+	idx := g.findEdge(from_id, to_id)
+	if idx < 0 {
+		return false
+	}
+	g.adjacency.data[from_id].Erase(idx)
+	if !g.directed && from_id != to_id {
+		g.adjacency.data[to_id].Erase(g.findEdge(to_id, from_id))
+	}
+	g.edge_count--
+	return true
+}
+
+// ContainsEdge returns true if there is an edge between two vertices.
+func (g *Graph[V, E]) ContainsEdge(from V, to V) bool {
+	_, found := g.Edge(from, to)
+	return found
+}
+
+// Edge returns the value of the edge between two vertices.
+func (g *Graph[V, E]) Edge(from V, to V) (value E, found bool) {
+	from_id, from_found := g.ids[from]
+	to_id, to_found := g.ids[to]
+	if from_found && to_found {
+		if idx := g.findEdge(from_id, to_id); idx >= 0 {
+			return g.adjacency.data[from_id].data[idx].value, true
+		}
+	}
+	return
+}
+
+// Degree returns the number of edges leaving vertex.
+func (g *Graph[V, E]) Degree(vertex V) int {
+	return g.adjacency.data[g.mustID(vertex, "Degree")].Size()
+}
+
+// Neighbors returns the vertices that vertex has an edge to, in the order the edges were added.
+func (g *Graph[V, E]) Neighbors(vertex V) Vector[V] {
+	edges := &g.adjacency.data[g.mustID(vertex, "Neighbors")]
+	neighbors := Vector[V]{data: make([]V, len(edges.data))}
+	for idx, edge := range edges.data {
+		neighbors.data[idx] = g.vertices.data[edge.to]
+	}
+	return neighbors
+}
+
+// VisitEdges calls a function for every edge leaving vertex, with the vertex at the other end
+// and the edge value.
+func (g *Graph[V, E]) VisitEdges(vertex V, visitor MapVisitor[V, E]) {
+	break_out := false
+	edges := &g.adjacency.data[g.mustID(vertex, "VisitEdges")]
+	for idx := range edges.data {
+		visitor(&g.vertices.data[edges.data[idx].to], &edges.data[idx].value, &break_out)
+		if break_out {
+			break
+		}
+	}
+}
+
+// BFS calls a function for every vertex reachable from start, in breadth-first order.
+func (g *Graph[V, E]) BFS(start V, visitor CollectionVisitor[V]) {
+	break_out := false
+	visited := make([]bool, g.vertices.Size())
+	queue := NewQueueFromData(g.mustID(start, "BFS"))
+	visited[queue.Front()] = true
+	for !queue.IsEmpty() {
+		id := queue.Front()
+		queue.PopFront()
+		visitor(&g.vertices.data[id], &break_out)
+		if break_out {
+			return
+		}
+		for _, edge := range g.adjacency.data[id].data {
+			if !visited[edge.to] {
+				visited[edge.to] = true
+				queue.PushBack(edge.to)
+			}
+		}
+	}
+}
+
+// DFS calls a function for every vertex reachable from start, in depth-first preorder. Neighbors
+// are explored in the order their edges were added.
+func (g *Graph[V, E]) DFS(start V, visitor CollectionVisitor[V]) {
+	break_out := false
+	visited := make([]bool, g.vertices.Size())
+	stack := NewStackFromData(g.mustID(start, "DFS"))
+	for !stack.IsEmpty() {
+		id := stack.Top()
+		stack.Pop()
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		visitor(&g.vertices.data[id], &break_out)
+		if break_out {
+			return
+		}
+		edges := g.adjacency.data[id].data
+		for idx := len(edges) - 1; idx >= 0; idx-- {
+			if !visited[edges[idx].to] {
+				stack.Push(edges[idx].to)
+			}
+		}
+	}
+}
+
+// TopologicalSort returns the vertices ordered so that every edge goes from an earlier vertex to
+// a later one. If the Graph has a cycle, acyclic is false and order holds only the vertices
+// that are not on or after a cycle.
+//
+// Panics if the Graph is undirected.
+func (g *Graph[V, E]) TopologicalSort() (order Vector[V], acyclic bool) {
+	if !g.directed {
+		panic("ERROR: Graph.TopologicalSort - graph is undirected")
+	}
+	in_degree := make([]int, g.vertices.Size())
+	for _, edges := range g.adjacency.data {
+		for _, edge := range edges.data {
+			in_degree[edge.to]++
+		}
+	}
+	queue := NewQueue[int]()
+	for id, degree := range in_degree {
+		if degree == 0 {
+			queue.PushBack(id)
+		}
+	}
+	order = Vector[V]{data: make([]V, 0, g.vertices.Size())}
+	for !queue.IsEmpty() {
+		id := queue.Front()
+		queue.PopFront()
+		order.PushBack(g.vertices.data[id])
+		for _, edge := range g.adjacency.data[id].data {
+			in_degree[edge.to]--
+			if in_degree[edge.to] == 0 {
+				queue.PushBack(edge.to)
+			}
+		}
+	}
+	return order, order.Size() == g.vertices.Size()
+}
+
+// ConnectedComponents returns the vertices of each connected component, in the order they were
+// added. The components are ordered by their first added vertex. For a directed Graph edge
+// directions are ignored, giving the weakly connected components.
+func (g *Graph[V, E]) ConnectedComponents() Vector[Vector[V]] {
+	sets := NewDenseDisjointSet(g.vertices.Size())
+	for from, edges := range g.adjacency.data {
+		for _, edge := range edges.data {
+			sets.Union(from, edge.to)
+		}
+	}
+	id_sets := sets.Sets()
+	components := Vector[Vector[V]]{data: make([]Vector[V], len(id_sets.data))}
+	for idx, ids := range id_sets.data {
+		component := Vector[V]{data: make([]V, len(ids.data))}
+		for i, id := range ids.data {
+			component.data[i] = g.vertices.data[id]
+		}
+		components.data[idx] = component
+	}
+	return components
+}
+
+// Clear removes all the vertices and edges from the Graph.
+func (g *Graph[V, E]) Clear() {
+	*g = newGraph[V, E](g.directed)
+}
+
+// Swap swaps the data of two Graphs.
+func (g *Graph[V, E]) Swap(other *Graph[V, E]) {
+	*g, *other = *other, *g
+}
+
+// Clone returns a new Graph with the same vertices and edges, that does not share storage with
+// this one.
+//
+// Vertices and edge values are copied by assignment, so pointers, slices and maps inside them are
+// still shared. Use DeepClone to copy the edge values too.
+func (g *Graph[V, E]) Clone() Graph[V, E] {
+	return NewGraphFromGraph(*g)
+}
+
+// DeepClone returns a new Graph with deep copies of the edge values, see Vector.DeepClone.
+//
+// Note, the vertices are still copied by assignment, as they identify the vertices.
+func (g *Graph[V, E]) DeepClone() Graph[V, E] {
+	result := g.Clone()
+	for _, edges := range result.adjacency.data {
+		for idx := range edges.data {
+			edges.data[idx].value = DeepCopy(edges.data[idx].value)
+		}
+	}
+	return result
+}
+
+// String returns a string representation of the Graph, listing the neighbors of every vertex.
+func (g *Graph[V, E]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	for id, vertex := range g.vertices.data {
+		if id > 0 {
+			fmt.Fprintf(&builder, ", ")
+		}
+		fmt.Fprintf(&builder, "%v: {", vertex)
+		for idx, edge := range g.adjacency.data[id].data {
+			if idx > 0 {
+				fmt.Fprintf(&builder, ", ")
+			}
+			fmt.Fprintf(&builder, "%v", g.vertices.data[edge.to])
+		}
+		fmt.Fprintf(&builder, "}")
+	}
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
+
+type graphHeapItem struct {
+	id       int
+	distance float64
+}
+
+// graphHeap is a min-heap of vertex ids by distance, for container/heap.
+type graphHeap []graphHeapItem
+
+func (h graphHeap) Len() int            { return len(h) }
+func (h graphHeap) Less(i, j int) bool  { return h[i].distance < h[j].distance }
+func (h graphHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *graphHeap) Push(x interface{}) { *h = append(*h, x.(graphHeapItem)) }
+func (h *graphHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// ShortestPaths holds the result of a single-source shortest path search on a Graph.
+type ShortestPaths[V comparable] struct {
+	source   V
+	ids      map[V]int
+	vertices []V
+	distance []float64
+	previous []int
+}
+
+// Source returns the vertex the paths start from.
+func (p *ShortestPaths[V]) Source() V {
+	return p.source
+}
+
+// Distance returns the length of the shortest path to a vertex, and false if it is unreachable.
+func (p *ShortestPaths[V]) Distance(to V) (distance float64, reachable bool) {
+	id, found := p.ids[to]
+	if !found || math.IsInf(p.distance[id], 1) {
+		return math.Inf(1), false
+	}
+	return p.distance[id], true
+}
+
+// PathTo returns the vertices on the shortest path to a vertex, starting with the source, and
+// false if it is unreachable.
+func (p *ShortestPaths[V]) PathTo(to V) (path Vector[V], reachable bool) {
+	id, found := p.ids[to]
+	if !found || math.IsInf(p.distance[id], 1) {
+		return NewVector[V](), false
+	}
+	path = NewVector[V]()
+	for ; id >= 0; id = p.previous[id] {
+		path.PushBack(p.vertices[id])
+	}
+	for i, j := 0, len(path.data)-1; i < j; i, j = i+1, j-1 {
+		path.data[i], path.data[j] = path.data[j], path.data[i]
+	}
+	return path, true
+}
+
+// Dijkstra finds the shortest paths from source to every vertex, using weight to get the length
+// of each edge.
+//
+// Panics if an edge has a negative or NaN weight.
+func (g *Graph[V, E]) Dijkstra(source V, weight func(edge *E) float64) ShortestPaths[V] {
+	source_id := g.mustID(source, "Dijkstra")
+	paths := ShortestPaths[V]{
+		source:   source,
+		ids:      make(map[V]int, len(g.ids)),
+		vertices: append([]V{}, g.vertices.data...),
+		distance: make([]float64, g.vertices.Size()),
+		previous: make([]int, g.vertices.Size()),
+	}
+	for vertex, id := range g.ids {
+		paths.ids[vertex] = id
+	}
+	for id := range paths.distance {
+		paths.distance[id] = math.Inf(1)
+		paths.previous[id] = -1
+	}
+	paths.distance[source_id] = 0
+	h := &graphHeap{{id: source_id, distance: 0}}
+	for h.Len() > 0 {
+		item := heap.Pop(h).(graphHeapItem)
+		if item.distance > paths.distance[item.id] {
+			continue
+		}
+		edges := &g.adjacency.data[item.id]
+		for idx := range edges.data {
+			w := weight(&edges.data[idx].value)
+			if !(w >= 0) {
+				panic("ERROR: Graph.Dijkstra - negative edge weight")
+			}
+			to := edges.data[idx].to
+			if distance := item.distance + w; distance < paths.distance[to] {
+				paths.distance[to] = distance
+				paths.previous[to] = item.id
+				heap.Push(h, graphHeapItem{id: to, distance: distance})
+			}
+		}
+	}
+	return paths
+}
@@ -0,0 +1,119 @@
+package gollect
+
+import (
+	"testing"
+)
+
+func TestGraphEdges(t *testing.T) {
+	g := NewUndirectedGraph[string, int]()
+	if !g.AddEdge("a", "b", 1) || g.AddEdge("b", "a", 2) || !g.AddEdge("b", "c", 3) {
+		t.Fatalf("AddEdge returned the wrong result")
+	}
+	g.AddVertex("d")
+	if g.VertexCount() != 4 || g.EdgeCount() != 2 {
+		t.Fatalf("Graph should have 4 vertices and 2 edges, got %v", g.String())
+	}
+	if value, _ := g.Edge("a", "b"); value != 2 || !g.ContainsEdge("c", "b") {
+		t.Fatalf("Undirected edges should be visible from both ends")
+	}
+	if g.String() != "{a: {b}, b: {a, c}, c: {b}, d: {}}" {
+		t.Fatalf("Graph has the wrong adjacency lists, got %v", g.String())
+	}
+	if !g.RemoveEdge("c", "b") || g.RemoveEdge("b", "c") || g.Degree("b") != 1 {
+		t.Fatalf("RemoveEdge should have removed both directions")
+	}
+
+	d := NewDirectedGraph[string, int]()
+	d.AddEdge("a", "b", 1)
+	neighbors := d.Neighbors("a")
+	if d.ContainsEdge("b", "a") || neighbors.String() != "{b}" {
+		t.Fatalf("Directed edges should only go one way")
+	}
+}
+
+func TestGraphTraversals(t *testing.T) {
+	g := NewDirectedGraph[int, struct{}]()
+	g.AddEdge(1, 2, struct{}{})
+	g.AddEdge(1, 3, struct{}{})
+	g.AddEdge(2, 4, struct{}{})
+	g.AddEdge(3, 4, struct{}{})
+	g.AddEdge(4, 5, struct{}{})
+	g.AddEdge(6, 1, struct{}{})
+
+	bfs := NewNVector[int]()
+	g.BFS(1, func(vertex *int, break_out *bool) { bfs.PushBack(*vertex) })
+	if bfs.String() != "{1, 2, 3, 4, 5}" {
+		t.Fatalf("BFS should be {1, 2, 3, 4, 5}, got %v", bfs.String())
+	}
+	dfs := NewNVector[int]()
+	g.DFS(1, func(vertex *int, break_out *bool) {
+		dfs.PushBack(*vertex)
+		*break_out = *vertex == 5
+	})
+	if dfs.String() != "{1, 2, 4, 5}" {
+		t.Fatalf("DFS should be {1, 2, 4, 5}, got %v", dfs.String())
+	}
+}
+
+func TestGraphTopologicalSort(t *testing.T) {
+	g := NewDirectedGraph[string, struct{}]()
+	g.AddEdge("shirt", "tie", struct{}{})
+	g.AddEdge("tie", "jacket", struct{}{})
+	g.AddEdge("pants", "shoes", struct{}{})
+	g.AddEdge("pants", "jacket", struct{}{})
+	order, acyclic := g.TopologicalSort()
+	if !acyclic || order.String() != "{shirt, pants, tie, shoes, jacket}" {
+		t.Fatalf("TopologicalSort returned %v, %v", order.String(), acyclic)
+	}
+	g.AddEdge("jacket", "shirt", struct{}{})
+	if order, acyclic := g.TopologicalSort(); acyclic || order.String() != "{pants, shoes}" {
+		t.Fatalf("TopologicalSort should have found the cycle, got %v", order.String())
+	}
+}
+
+func TestGraphDijkstra(t *testing.T) {
+	g := NewUndirectedGraph[string, float64]()
+	g.AddEdge("a", "b", 7)
+	g.AddEdge("a", "c", 9)
+	g.AddEdge("a", "f", 14)
+	g.AddEdge("b", "c", 10)
+	g.AddEdge("b", "d", 15)
+	g.AddEdge("c", "d", 11)
+	g.AddEdge("c", "f", 2)
+	g.AddEdge("d", "e", 6)
+	g.AddEdge("e", "f", 9)
+	g.AddVertex("z")
+	paths := g.Dijkstra("a", func(edge *float64) float64 { return *edge })
+	if distance, _ := paths.Distance("e"); distance != 20 {
+		t.Fatalf("Distance(e) should be 20, got %v", distance)
+	}
+	if path, _ := paths.PathTo("e"); path.String() != "{a, c, f, e}" {
+		t.Fatalf("PathTo(e) should be {a, c, f, e}, got %v", path.String())
+	}
+	if _, reachable := paths.Distance("z"); reachable {
+		t.Fatalf("z should be unreachable")
+	}
+}
+
+func TestGraphConnectedComponents(t *testing.T) {
+	g := NewUndirectedGraph[int, struct{}]()
+	g.AddEdge(1, 2, struct{}{})
+	g.AddEdge(3, 4, struct{}{})
+	g.AddEdge(2, 5, struct{}{})
+	g.AddVertex(6)
+	components := g.ConnectedComponents()
+	if components.Size() != 3 || components.AtRef(0).String() != "{1, 2, 5}" || components.AtRef(2).String() != "{6}" {
+		t.Fatalf("ConnectedComponents returned the wrong components")
+	}
+}
+
+func TestGraphTopologicalSortUndirected(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: Graph.TopologicalSort - graph is undirected" {
+			t.Fatalf("Should have panicked because the graph is undirected, got \"%v\"", result)
+		}
+	}()
+	g := NewUndirectedGraph[int, int]()
+	g.TopologicalSort()
+}
@@ -0,0 +1,102 @@
+package gollect
+
+import (
+	"math"
+)
+
+// GrowthPolicy controls how much capacity a slice-backed collection allocates when it runs out
+// of room.
+//
+// The new capacity is the old one multiplied by Factor. If MaxStep is positive, at most MaxStep
+// elements are added at a time, so very large collections grow linearly instead of doubling. The
+// collection also grows by at least an eighth of its capacity, or MaxStep if that is smaller, so
+// that a Factor close to 1 does not allocate on every insertion, and always by at least as much
+// as it needs.
+//
+// Factor must be greater than 1 and finite.
+//
+// The zero GrowthPolicy is the same as DefaultGrowthPolicy.
+type GrowthPolicy struct {
+	Factor  float64
+	MaxStep int
+}
+
+// DefaultGrowthPolicy doubles the capacity every time it runs out.
+var DefaultGrowthPolicy = GrowthPolicy{Factor: 2, MaxStep: 0}
+
+// validate panics on behalf of method if the GrowthPolicy would not grow.
+func (p GrowthPolicy) validate(method string) {
+	// Written so that NaN is rejected too
+	valid_factor := p.Factor == 0 || (p.Factor > 1 && !math.IsInf(p.Factor, 1))
+	if !valid_factor || p.MaxStep < 0 {
+		panic("ERROR: " + method + " - invalid GrowthPolicy")
+	}
+}
+
+// nextCapacity returns the capacity to grow to from capacity, to hold at least needed elements.
+func (p GrowthPolicy) nextCapacity(capacity int, needed int) int {
+	factor := p.Factor
+	if factor == 0 {
+		factor = DefaultGrowthPolicy.Factor
+	}
+	next := math.MaxInt
+	if grown := float64(capacity) * factor; grown < math.MaxInt {
+		next = int(grown)
+	}
+	if p.MaxStep > 0 && next-capacity > p.MaxStep {
+		next = capacity + p.MaxStep
+	}
+	min_step := capacity / 8
+	if p.MaxStep > 0 && min_step > p.MaxStep {
+		min_step = p.MaxStep
+	}
+	if min_step < 1 {
+		min_step = 1
+	}
+	if next < capacity+min_step {
+		next = capacity + min_step
+	}
+	if next < needed {
+		next = needed
+	}
+	return next
+}
+
+// reserveSlice returns data, or a copy of it with more capacity chosen by policy if it cannot
+// hold needed elements.
+func reserveSlice[T any](data []T, needed int, policy GrowthPolicy) []T {
+	if needed <= cap(data) {
+		return data
+	}
+	tmp := make([]T, len(data), policy.nextCapacity(cap(data), needed))
+	copy(tmp, data)
+	return tmp
+}
+
+// reserveSliceExact returns data, or a copy of it with a capacity of exactly capacity if it is
+// smaller.
+func reserveSliceExact[T any](data []T, capacity int) []T {
+	if capacity <= cap(data) {
+		return data
+	}
+	tmp := make([]T, len(data), capacity)
+	copy(tmp, data)
+	return tmp
+}
+
+// shrinkSlice returns data, or a copy of it without spare capacity.
+func shrinkSlice[T any](data []T) []T {
+	if cap(data) == len(data) {
+		return data
+	}
+	return append(make([]T, 0, len(data)), data...)
+}
+
+// insertSlice inserts value at index of data, growing it according to policy if needed.
+func insertSlice[T any](data []T, index int, value T, policy GrowthPolicy) []T {
+	data = reserveSlice(data, len(data)+1, policy)
+	data = data[:len(data)+1]
+	copy(data[index+1:], data[index:])
+	data[index] = value
+	return data
+}
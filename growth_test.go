@@ -0,0 +1,221 @@
+package gollect
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVectorCapacity(t *testing.T) {
+	v := NewVector[int]()
+	v.Reserve(100)
+	if v.Capacity() != 100 || v.Size() != 0 {
+		t.Fatalf("Reserve(100) should give a capacity of 100, got %v", v.Capacity())
+	}
+	data := v.Data()
+	for i := 0; i < 100; i++ {
+		v.PushBack(i)
+	}
+	if &v.Data()[0] != &data[:1][0] {
+		t.Fatalf("PushBack should not reallocate within the reserved capacity")
+	}
+	v.Resize(10)
+	v.ShrinkToFit()
+	if v.Capacity() != 10 || v.Back() != 9 {
+		t.Fatalf("ShrinkToFit should give a capacity of 10, got %v", v.Capacity())
+	}
+	v.Resize(12)
+	if v.At(11) != 0 || v.Capacity() != 20 {
+		t.Fatalf("Resize should zero new elements and double the capacity, got %v", v.Capacity())
+	}
+}
+
+func TestVectorGrowthPolicy(t *testing.T) {
+	v := NewVector[int]()
+	v.SetGrowthPolicy(GrowthPolicy{Factor: 1.5, MaxStep: 8})
+	v.Reserve(4)
+	capacities := []int{}
+	for i := 0; i < 40; i++ {
+		if v.Capacity() == v.Size() {
+			v.PushBack(i)
+			capacities = append(capacities, v.Capacity())
+		} else {
+			v.PushBack(i)
+		}
+	}
+	expected := []int{6, 9, 13, 19, 27, 35, 43}
+	if len(capacities) != len(expected) {
+		t.Fatalf("Capacities should be %v, got %v", expected, capacities)
+	}
+	for i := range expected {
+		if capacities[i] != expected[i] {
+			t.Fatalf("Capacities should be %v, got %v", expected, capacities)
+		}
+	}
+	c := v.Clone()
+	if c.GrowthPolicy() != v.GrowthPolicy() {
+		t.Fatalf("Clone should keep the GrowthPolicy")
+	}
+}
+
+func TestInvalidGrowthPolicy(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: Deque.SetGrowthPolicy - invalid GrowthPolicy" {
+			t.Fatalf("Should have panicked because the GrowthPolicy is invalid, got \"%v\"", result)
+		}
+	}()
+	d := NewDeque[int]()
+	d.SetGrowthPolicy(GrowthPolicy{Factor: 1})
+}
+
+func TestNVectorCapacity(t *testing.T) {
+	v := NewNVector[int]()
+	v.SetGrowthPolicy(GrowthPolicy{Factor: 2, MaxStep: 4})
+	for i := 0; i < 20; i++ {
+		v.PushFront(i)
+	}
+	if v.Capacity() > 24 || v.Front() != 19 {
+		t.Fatalf("MaxStep should limit growth to 4 elements, got a capacity of %v", v.Capacity())
+	}
+	v.Insert(5, -1)
+	if v.At(5) != -1 || v.At(6) != 14 {
+		t.Fatalf("Insert should move later elements back, got %v", v.String())
+	}
+	v.ShrinkToFit()
+	if v.Capacity() != v.Size() {
+		t.Fatalf("ShrinkToFit should remove spare capacity")
+	}
+}
+
+func TestQueueCapacity(t *testing.T) {
+	q := NewQueue[int]()
+	q.Reserve(64)
+	for i := 0; i < 64; i++ {
+		q.PushBack(i)
+	}
+	if q.Capacity() != 64 {
+		t.Fatalf("Capacity should be 64, got %v", q.Capacity())
+	}
+	for i := 0; i < 60; i++ {
+		q.PopFront()
+	}
+	q.ShrinkToFit()
+	if q.Capacity() != 4 || q.Front() != 60 {
+		t.Fatalf("ShrinkToFit should trim the Queue to 4 elements, got %v", q.Capacity())
+	}
+}
+
+func TestDequeReservePersistsAcrossPops(t *testing.T) {
+	d := NewDeque[int]()
+	d.Reserve(16)
+	reserved := d.Data()
+	for i := 0; i < 8; i++ {
+		d.PushBack(i)
+	}
+	for i := 8; i < 1000; i++ {
+		d.PopFront()
+		if d.Capacity() != 16 {
+			t.Fatalf("PopFront should not reduce the capacity, got %v", d.Capacity())
+		}
+		d.PushBack(i)
+	}
+	if !slicesAlias(reserved, d.Data()) || d.Capacity() != 16 {
+		t.Fatalf("PushBack should reuse the reserved room, got a capacity of %v", d.Capacity())
+	}
+	if d.Front() != 992 || d.Back() != 999 || d.Size() != 8 {
+		t.Fatalf("Deque should hold 992 to 999, got %v", d.String())
+	}
+	d.PushFront(-1)
+	if d.Front() != -1 || d.Capacity() != 16 {
+		t.Fatalf("PushFront should reuse the reserved room, got a capacity of %v", d.Capacity())
+	}
+}
+
+func TestNVectorReservePersistsAcrossPops(t *testing.T) {
+	v := NewNVector[int]()
+	v.Reserve(16)
+	reserved := v.Data()
+	for i := 0; i < 8; i++ {
+		v.PushBack(i)
+	}
+	for i := 8; i < 1000; i++ {
+		v.PopFront()
+		if v.Capacity() != 16 {
+			t.Fatalf("PopFront should not reduce the capacity, got %v", v.Capacity())
+		}
+		v.PushBack(i)
+	}
+	if !slicesAlias(reserved, v.Data()) || v.Capacity() != 16 {
+		t.Fatalf("PushBack should reuse the reserved room, got a capacity of %v", v.Capacity())
+	}
+	if v.Front() != 992 || v.Back() != 999 || v.Size() != 8 {
+		t.Fatalf("NVector should hold 992 to 999, got %v", v.String())
+	}
+	v.PopFront()
+	v.PopFront()
+	v.InsertRange(0, v.Data()[0:2]...)
+	if v.String() != "{994, 995, 994, 995, 996, 997, 998, 999}" || !slicesAlias(reserved, v.Data()) {
+		t.Fatalf("InsertRange of the NVector's own data should insert {994, 995} in place, got %v", v.String())
+	}
+	v.PopFront()
+	v.ShrinkToFit()
+	if v.Capacity() != 7 || v.Front() != 995 {
+		t.Fatalf("ShrinkToFit should release the room in front, got a capacity of %v", v.Capacity())
+	}
+}
+
+func TestVectorReclaimAliasedRange(t *testing.T) {
+	v := NewVectorFromData(0, 1, 2, 3)
+	v.Reserve(8)
+	v.PushBack(4)
+	v.PushBack(5)
+	v.PushBack(6)
+	v.PushBack(7)
+	reserved := v.Data()
+	v.PopFront()
+	v.PopFront()
+	v.PopFront()
+	v.InsertRange(0, v.Data()[0:3]...)
+	if v.String() != "{3, 4, 5, 3, 4, 5, 6, 7}" || !slicesAlias(reserved, v.Data()) {
+		t.Fatalf("InsertRange of the Vector's own data should insert {3, 4, 5} in place, got %v", v.String())
+	}
+	v.Resize(4)
+	v.PopFront()
+	v.AppendVector(v)
+	if v.String() != "{4, 5, 3, 4, 5, 3}" || !slicesAlias(reserved, v.Data()) {
+		t.Fatalf("AppendVector of itself should double the Vector in place, got %v", v.String())
+	}
+}
+
+func TestGrowthPolicyMinimumStep(t *testing.T) {
+	v := NewVector[int]()
+	v.SetGrowthPolicy(GrowthPolicy{Factor: 1.01})
+	v.Reserve(64)
+	v.Resize(64)
+	v.PushBack(64)
+	if v.Capacity() != 72 {
+		t.Fatalf("A Factor close to 1 should still grow by an eighth, got a capacity of %v", v.Capacity())
+	}
+	limited := GrowthPolicy{Factor: 1.01, MaxStep: 4}
+	if next := limited.nextCapacity(64, 65); next != 68 {
+		t.Fatalf("The minimum step should be limited by MaxStep, got %v", next)
+	}
+	if next := (GrowthPolicy{Factor: 1e300}).nextCapacity(64, 65); next != math.MaxInt {
+		t.Fatalf("A huge Factor should not overflow, got %v", next)
+	}
+}
+
+func TestNonFiniteGrowthPolicy(t *testing.T) {
+	for _, factor := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		func() {
+			defer func() {
+				result, _ := recover().(string)
+				if result != "ERROR: Vector.SetGrowthPolicy - invalid GrowthPolicy" {
+					t.Fatalf("Should have panicked because the Factor %v is invalid, got \"%v\"", factor, result)
+				}
+			}()
+			v := NewVector[int]()
+			v.SetGrowthPolicy(GrowthPolicy{Factor: factor})
+		}()
+	}
+}
@@ -0,0 +1,258 @@
+package gollect
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"io"
+	"math"
+	"reflect"
+)
+
+// Hasher computes hashes for, and compares, the keys of hash-based collections.
+//
+// Keys that are equal must have the same hash.
+type Hasher[T any] interface {
+	Hash(value *T) uint64
+	Equal(left *T, right *T) bool
+}
+
+type funcHasher[T any] struct {
+	hash  func(value *T) uint64
+	equal Equaler[T]
+}
+
+func (h funcHasher[T]) Hash(value *T) uint64 {
+	return h.hash(value)
+}
+
+func (h funcHasher[T]) Equal(left *T, right *T) bool {
+	return h.equal(left, right)
+}
+
+// NewFuncHasher creates a Hasher from a hash function and an Equaler.
+//
+// This allows keys with custom equality, such as composite IDs that ignore some of their
+// fields, to be used with the hash-based collections.
+func NewFuncHasher[T any](hash func(value *T) uint64, equal Equaler[T]) Hasher[T] {
+	return funcHasher[T]{hash: hash, equal: equal}
+}
+
+// NewHashableHasher creates a Hasher that uses the Hashable implementation of T.
+//
+// Panics if *T does not implement Hashable[T].
+func NewHashableHasher[T any]() Hasher[T] {
+	if _, isHashable := interface{}(new(T)).(Hashable[T]); !isHashable {
+		panic("ERROR: NewHashableHasher - element type is not Hashable")
+	}
+	return NewFuncHasher(
+		func(value *T) uint64 { return interface{}(value).(Hashable[T]).Hash() },
+		func(left *T, right *T) bool { return interface{}(left).(Hashable[T]).Equal(*right) },
+	)
+}
+
+// MapHasher is the default Hasher for comparable types, based on hash/maphash.
+//
+// Every MapHasher is created with its own random seed, so hashes are not stable between
+// instances or between runs of a program.
+type MapHasher[T comparable] struct {
+	seed maphash.Seed
+}
+
+// NewMapHasher creates a new MapHasher, by value.
+func NewMapHasher[T comparable]() MapHasher[T] {
+	return MapHasher[T]{seed: maphash.MakeSeed()}
+}
+
+// MakeMapHasher creates a new MapHasher instance.
+func MakeMapHasher[T comparable]() *MapHasher[T] {
+	return &MapHasher[T]{seed: maphash.MakeSeed()}
+}
+
+// Hash returns the hash of value.
+func (h *MapHasher[T]) Hash(value *T) uint64 {
+	return mapHashValue(h.seed, value)
+}
+
+// Equal returns true if left and right are equal according to the `==` operator.
+func (h *MapHasher[T]) Equal(left *T, right *T) bool {
+	return *left == *right
+}
+
+// mapHashValue hashes value with hash/maphash, using type switches on its address so the common
+// key types are neither boxed nor hashed through reflection.
+func mapHashValue[T any](seed maphash.Seed, value *T) uint64 {
+	var mh maphash.Hash
+	mh.SetSeed(seed)
+	switch val := interface{}(value).(type) {
+	case *string:
+		mh.WriteString(*val)
+	case *int:
+		writeUint64(&mh, uint64(*val))
+	case *int64:
+		writeUint64(&mh, uint64(*val))
+	case *int32:
+		writeUint64(&mh, uint64(*val))
+	case *uint:
+		writeUint64(&mh, uint64(*val))
+	case *uint64:
+		writeUint64(&mh, *val)
+	case *uint32:
+		writeUint64(&mh, uint64(*val))
+	default:
+		hashReflectValue(&mh, reflect.ValueOf(value).Elem())
+	}
+	return mh.Sum64()
+}
+
+// hashWriter is the part of maphash.Hash used to hash values, so that other hash functions can
+// share hashReflectValue.
+type hashWriter interface {
+	io.Writer
+	io.ByteWriter
+	io.StringWriter
+}
+
+// fnvHash is a 64-bit FNV-1a hashWriter. Unlike maphash.Hash it has no seed, so its hashes are
+// the same in every run of a program.
+type fnvHash struct {
+	sum uint64
+}
+
+func newFNVHash() fnvHash {
+	return fnvHash{sum: 14695981039346656037}
+}
+
+func (h *fnvHash) Write(data []byte) (int, error) {
+	for _, c := range data {
+		h.WriteByte(c)
+	}
+	return len(data), nil
+}
+
+func (h *fnvHash) WriteByte(c byte) error {
+	h.sum ^= uint64(c)
+	h.sum *= 1099511628211
+	return nil
+}
+
+func (h *fnvHash) WriteString(str string) (int, error) {
+	for idx := 0; idx < len(str); idx++ {
+		h.WriteByte(str[idx])
+	}
+	return len(str), nil
+}
+
+func writeUint64(mh hashWriter, value uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], value)
+	mh.Write(buf[:])
+}
+
+func hashFloat64(mh hashWriter, value float64) {
+	if value == 0 {
+		// -0 == +0, so they must hash the same
+		value = 0
+	}
+	writeUint64(mh, math.Float64bits(value))
+}
+
+// hashReflectValue writes a representation of value into mh that is consistent with `==`.
+func hashReflectValue(mh hashWriter, value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			mh.WriteByte(1)
+		} else {
+			mh.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(mh, uint64(value.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(mh, value.Uint())
+	case reflect.Float32, reflect.Float64:
+		hashFloat64(mh, value.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := value.Complex()
+		hashFloat64(mh, real(c))
+		hashFloat64(mh, imag(c))
+	case reflect.String:
+		writeUint64(mh, uint64(value.Len()))
+		mh.WriteString(value.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint64(mh, uint64(value.Pointer()))
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			hashReflectValue(mh, value.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			hashReflectValue(mh, value.Field(i))
+		}
+	case reflect.Interface:
+		if value.IsNil() {
+			mh.WriteByte(0)
+		} else {
+			mh.WriteString(value.Elem().Type().String())
+			hashReflectValue(mh, value.Elem())
+		}
+	default:
+		panic("ERROR: MapHasher.Hash - value of kind " + value.Kind().String() + " is not comparable")
+	}
+}
+
+// resolveHasher returns the Hasher a collection should use for its key type.
+//
+// In order of preference this is custom, the Hashable implementation of the key type, or
+// MapHasher hashing if the key type is comparable. If none of these apply, nil is returned.
+func resolveHasher[T any](custom Hasher[T]) Hasher[T] {
+	if custom != nil {
+		return custom
+	}
+	if _, isHashable := interface{}(new(T)).(Hashable[T]); isHashable {
+		return NewHashableHasher[T]()
+	}
+	if reflect.TypeOf(new(T)).Elem().Comparable() {
+		// The same hashing as a MapHasher, which cannot be named here as T is not known to be
+		// comparable
+		seed := maphash.MakeSeed()
+		equal := nativeEqualer[T]()
+		if equal == nil {
+			equal = func(left *T, right *T) bool { return interface{}(*left) == interface{}(*right) }
+		}
+		return NewFuncHasher(func(value *T) uint64 { return mapHashValue(seed, value) }, equal)
+	}
+	return nil
+}
+
+func newStableReflectHasher[T any]() Hasher[T] {
+	return NewFuncHasher(
+		func(value *T) uint64 {
+			h := newFNVHash()
+			hashReflectValue(&h, reflect.ValueOf(value).Elem())
+			return h.sum
+		},
+		func(left *T, right *T) bool { return interface{}(*left) == interface{}(*right) },
+	)
+}
+
+// NewStableHasher creates a Hasher for a comparable type whose hashes are the same between
+// instances and between runs of a program, unlike a MapHasher. Pointers and channels are still
+// hashed by address, so their hashes are only stable within one run.
+func NewStableHasher[T comparable]() Hasher[T] {
+	return newStableReflectHasher[T]()
+}
+
+// resolveStableHasher is like resolveHasher, but falls back to a stable Hasher instead of a
+// MapHasher, for collections whose hashes outlive the program.
+func resolveStableHasher[T any](custom Hasher[T]) Hasher[T] {
+	if custom != nil {
+		return custom
+	}
+	if _, isHashable := interface{}(new(T)).(Hashable[T]); isHashable {
+		return NewHashableHasher[T]()
+	}
+	if reflect.TypeOf(new(T)).Elem().Comparable() {
+		return newStableReflectHasher[T]()
+	}
+	return nil
+}
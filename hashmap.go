@@ -0,0 +1,284 @@
+package gollect
+
+import (
+	"fmt"
+	"strings"
+)
+
+const hashMapInitialBuckets = 8
+
+type hashMapEntry[K any, V any] struct {
+	hash  uint64
+	key   K
+	value V
+}
+
+// HashMap is an unordered collection of key/value pairs, based on a hash table.
+//
+// Keys are hashed and compared with a Hasher. Unlike a Go map, this allows keys that are not
+// comparable, or that need custom equality, as long as a suitable Hasher is provided.
+//
+// If the values implement the Destructible interface, they will have the Destruct method
+// called on them when they are removed from the HashMap.
+//
+// The zero value is an empty HashMap using the default Hasher for its key type, which is
+// resolved on first use.
+type HashMap[K any, V any] struct {
+	buckets [][]hashMapEntry[K, V]
+	size    int
+	hasher  Hasher[K]
+}
+
+func newHashMap[K any, V any](hasher Hasher[K]) HashMap[K, V] {
+	hasher = resolveHasher(hasher)
+	if hasher == nil {
+		panic("ERROR: HashMap - no Hasher for key type")
+	}
+	return HashMap[K, V]{buckets: make([][]hashMapEntry[K, V], hashMapInitialBuckets), hasher: hasher}
+}
+
+// NewHashMap creates a new empty HashMap, by value.
+//
+// Keys are hashed through their Hashable implementation if they have one, otherwise they must
+// be comparable.
+func NewHashMap[K any, V any]() HashMap[K, V] {
+	return newHashMap[K, V](nil)
+}
+
+// NewHashMapWithHasher creates a new empty HashMap that uses hasher for its keys, by value.
+func NewHashMapWithHasher[K any, V any](hasher Hasher[K]) HashMap[K, V] {
+	return newHashMap[K, V](hasher)
+}
+
+// NewHashMapFromHashMap creates a new HashMap using the key/value pairs of another, by value.
+func NewHashMapFromHashMap[K any, V any](other HashMap[K, V]) HashMap[K, V] {
+	m := newHashMap[K, V](other.hasher)
+	other.Visit(func(key *K, value *V, break_out *bool) {
+		m.Put(*key, *value)
+	})
+	return m
+}
+
+// MakeHashMap creates a new empty HashMap instance.
+func MakeHashMap[K any, V any]() *HashMap[K, V] {
+	m := newHashMap[K, V](nil)
+	return &m
+}
+
+// MakeHashMapWithHasher creates a new empty HashMap instance that uses hasher for its keys.
+func MakeHashMapWithHasher[K any, V any](hasher Hasher[K]) *HashMap[K, V] {
+	m := newHashMap[K, V](hasher)
+	return &m
+}
+
+// MakeHashMapFromHashMap creates a new HashMap instance using the key/value pairs of another.
+func MakeHashMapFromHashMap[K any, V any](other HashMap[K, V]) *HashMap[K, V] {
+	m := NewHashMapFromHashMap(other)
+	return &m
+}
+
+// IsEmpty returns true if the HashMap is empty.
+func (m *HashMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Size returns the number of key/value pairs in the HashMap.
+func (m *HashMap[K, V]) Size() int {
+	return m.size
+}
+
+// init sets up a zero-valued HashMap.
+func (m *HashMap[K, V]) init() {
+	if m.hasher == nil {
+		m.hasher = resolveHasher[K](nil)
+		if m.hasher == nil {
+			panic("ERROR: HashMap - no Hasher for key type")
+		}
+	}
+	if m.buckets == nil {
+		m.buckets = make([][]hashMapEntry[K, V], hashMapInitialBuckets)
+	}
+}
+
+func (m *HashMap[K, V]) find(key *K) (bucket int, index int, hash uint64) {
+	m.init()
+	hash = m.hasher.Hash(key)
+	bucket = int(hash % uint64(len(m.buckets)))
+	for idx := range m.buckets[bucket] {
+		entry := &m.buckets[bucket][idx]
+		if entry.hash == hash && m.hasher.Equal(&entry.key, key) {
+			return bucket, idx, hash
+		}
+	}
+	return bucket, -1, hash
+}
+
+func (m *HashMap[K, V]) grow() {
+	old := m.buckets
+	m.buckets = make([][]hashMapEntry[K, V], len(old)*2)
+	for _, bucket := range old {
+		for _, entry := range bucket {
+			idx := int(entry.hash % uint64(len(m.buckets)))
+			m.buckets[idx] = append(m.buckets[idx], entry)
+		}
+	}
+}
+
+// Put sets the value associated with key, replacing any previous value.
+//
+// If a previous value implements the Destructible interface, it will have the Destruct method
+// called on it.
+func (m *HashMap[K, V]) Put(key K, value V) {
+	bucket, idx, hash := m.find(&key)
+	if idx >= 0 {
+		entry := &m.buckets[bucket][idx]
+		if e, isDestructible := interface{}(&entry.value).(Destructible); isDestructible {
+			e.Destruct()
+		}
+		entry.value = value
+		return
+	}
+	m.buckets[bucket] = append(m.buckets[bucket], hashMapEntry[K, V]{hash: hash, key: key, value: value})
+	m.size++
+	if m.size > len(m.buckets)*3/4 {
+		m.grow()
+	}
+}
+
+// PutRef sets the value associated with key, replacing any previous value.
+func (m *HashMap[K, V]) PutRef(key *K, value *V) {
+	m.Put(*key, *value)
+}
+
+// Get returns the value associated with key, and whether it was found.
+func (m *HashMap[K, V]) Get(key K) (value V, found bool) {
+	if ref := m.GetRef(key); ref != nil {
+		return *ref, true
+	}
+	return
+}
+
+// GetRef returns a pointer to the value associated with key, or nil if it was not found.
+//
+// Note, the pointer is only valid until the next time the HashMap is modified.
+func (m *HashMap[K, V]) GetRef(key K) *V {
+	bucket, idx, _ := m.find(&key)
+	if idx < 0 {
+		return nil
+	}
+	return &m.buckets[bucket][idx].value
+}
+
+// ContainsKey returns true if the HashMap contains key.
+func (m *HashMap[K, V]) ContainsKey(key K) bool {
+	_, idx, _ := m.find(&key)
+	return idx >= 0
+}
+
+// Erase removes key and its value from the HashMap, and returns true if it was present.
+//
+// If the value implements the Destructible interface, it will have the Destruct method called
+// on it.
+func (m *HashMap[K, V]) Erase(key K) bool {
+	bucket, idx, _ := m.find(&key)
+	if idx < 0 {
+		return false
+	}
+	entries := m.buckets[bucket]
+	if e, isDestructible := interface{}(&entries[idx].value).(Destructible); isDestructible {
+		e.Destruct()
+	}
+	last := len(entries) - 1
+	entries[idx] = entries[last]
+	entries[last] = hashMapEntry[K, V]{}
+	m.buckets[bucket] = entries[:last]
+	m.size--
+	return true
+}
+
+// Clear removes all the key/value pairs from the HashMap.
+//
+// If the values implement the Destructible interface, they will have the Destruct method
+// called on them.
+func (m *HashMap[K, V]) Clear() {
+	m.Visit(func(key *K, value *V, break_out *bool) {
+		if e, isDestructible := interface{}(value).(Destructible); isDestructible {
+			e.Destruct()
+		}
+	})
+	m.buckets = make([][]hashMapEntry[K, V], hashMapInitialBuckets)
+	m.size = 0
+}
+
+// Swap swaps the data of two HashMaps.
+func (m *HashMap[K, V]) Swap(other *HashMap[K, V]) {
+	m.buckets, other.buckets = other.buckets, m.buckets
+	m.size, other.size = other.size, m.size
+	m.hasher, other.hasher = other.hasher, m.hasher
+}
+
+// Clone returns a new HashMap with copies of the key/value pairs, that does not share storage
+// with this one.
+//
+// Keys and values are copied by assignment, so pointers, slices and maps inside them are still
+// shared. Use DeepClone to copy the values too.
+func (m *HashMap[K, V]) Clone() HashMap[K, V] {
+	return NewHashMapFromHashMap(*m)
+}
+
+// DeepClone returns a new HashMap with deep copies of the values, see Vector.DeepClone.
+//
+// Note, the keys are still copied by assignment, as a deep copy of a key could hash differently.
+func (m *HashMap[K, V]) DeepClone() HashMap[K, V] {
+	result := m.Clone()
+	result.Visit(func(key *K, value *V, break_out *bool) {
+		*value = DeepCopy(*value)
+	})
+	return result
+}
+
+// Visit calls a function for every key/value pair in the HashMap, in no particular order.
+func (m *HashMap[K, V]) Visit(visitor MapVisitor[K, V]) {
+	break_out := false
+	for bucket := 0; (!break_out) && (bucket < len(m.buckets)); bucket++ {
+		entries := m.buckets[bucket]
+		for idx := 0; (!break_out) && (idx < len(entries)); idx++ {
+			visitor(&entries[idx].key, &entries[idx].value, &break_out)
+		}
+	}
+}
+
+// Keys returns a Vector of all the keys in the HashMap, in no particular order.
+func (m *HashMap[K, V]) Keys() Vector[K] {
+	keys := NewVector[K]()
+	m.Visit(func(key *K, value *V, break_out *bool) {
+		keys.PushBack(*key)
+	})
+	return keys
+}
+
+// Values returns a Vector of all the values in the HashMap, in no particular order.
+func (m *HashMap[K, V]) Values() Vector[V] {
+	values := NewVector[V]()
+	m.Visit(func(key *K, value *V, break_out *bool) {
+		values.PushBack(*value)
+	})
+	return values
+}
+
+// String returns a string representation of the HashMap and it's contents.
+func (m *HashMap[K, V]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	count := 0
+	m.Visit(func(key *K, value *V, break_out *bool) {
+		if count == m.size-1 {
+			fmt.Fprintf(&builder, "%v: %v", *key, *value)
+		} else {
+			fmt.Fprintf(&builder, "%v: %v, ", *key, *value)
+		}
+		count++
+	})
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
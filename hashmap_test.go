@@ -0,0 +1,163 @@
+package gollect
+
+import (
+	"testing"
+)
+
+func TestHashMapPutGet(t *testing.T) {
+	m := NewHashMap[int, string]()
+	for i := 0; i < 100; i++ {
+		m.Put(i, "v")
+	}
+	m.Put(42, "answer")
+	if m.Size() != 100 {
+		t.Fatalf("Size should be 100, got %v", m.Size())
+	}
+	if v, found := m.Get(42); !found || v != "answer" {
+		t.Fatalf("Get(42) should be \"answer\", got %v (%v)", v, found)
+	}
+	if _, found := m.Get(100); found {
+		t.Fatalf("Get(100) should not be found")
+	}
+	if !m.Erase(42) || m.ContainsKey(42) || m.Size() != 99 {
+		t.Fatalf("42 should have been erased")
+	}
+	if m.Erase(42) {
+		t.Fatalf("Erase of a missing key should return false")
+	}
+}
+
+type compositeID struct {
+	Parts []string
+}
+
+func TestHashMapFuncHasher(t *testing.T) {
+	hasher := NewFuncHasher(
+		func(id *compositeID) uint64 { return uint64(len(id.Parts)) },
+		func(left *compositeID, right *compositeID) bool {
+			if len(left.Parts) != len(right.Parts) {
+				return false
+			}
+			for i := range left.Parts {
+				if left.Parts[i] != right.Parts[i] {
+					return false
+				}
+			}
+			return true
+		},
+	)
+	m := NewHashMapWithHasher[compositeID, int](hasher)
+	m.Put(compositeID{[]string{"a", "b"}}, 1)
+	m.Put(compositeID{[]string{"a", "c"}}, 2)
+	m.Put(compositeID{[]string{"a", "b"}}, 3)
+	if m.Size() != 2 {
+		t.Fatalf("Size should be 2, got %v", m.Size())
+	}
+	if v, _ := m.Get(compositeID{[]string{"a", "b"}}); v != 3 {
+		t.Fatalf("Get({a, b}) should be 3, got %v", v)
+	}
+}
+
+type caseless string
+
+func (c *caseless) lower() string {
+	b := []byte(*c)
+	for i := range b {
+		if b[i] >= 'A' && b[i] <= 'Z' {
+			b[i] += 'a' - 'A'
+		}
+	}
+	return string(b)
+}
+
+func (c *caseless) Equal(other caseless) bool    { return c.lower() == other.lower() }
+func (c *caseless) NotEqual(other caseless) bool { return !c.Equal(other) }
+func (c *caseless) Hash() uint64 {
+	var h uint64 = 14695981039346656037
+	for _, b := range []byte(c.lower()) {
+		h = (h ^ uint64(b)) * 1099511628211
+	}
+	return h
+}
+
+func TestHashMapHashable(t *testing.T) {
+	m := NewHashMap[caseless, int]()
+	m.Put("Hello", 1)
+	m.Put("HELLO", 2)
+	if m.Size() != 1 {
+		t.Fatalf("Size should be 1, got %v", m.Size())
+	}
+	if v, _ := m.Get("hello"); v != 2 {
+		t.Fatalf("Get(hello) should be 2, got %v", v)
+	}
+}
+
+func TestHashMapNoHasher(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: HashMap - no Hasher for key type" {
+			t.Fatalf("Should have panicked because there is no Hasher, got \"%v\"", result)
+		}
+	}()
+	NewHashMap[compositeID, int]()
+}
+
+func TestHashMapDestruct(t *testing.T) {
+	Msgs = []string{}
+	m := NewHashMap[string, DBool]()
+	m.Put("a", true)
+	m.Put("b", true)
+	m.Put("a", true)
+	m.Erase("b")
+	m.Clear()
+	if len(Msgs) != 3 {
+		t.Fatalf("Destruct method should have been called 3 times, got %v", len(Msgs))
+	}
+}
+
+func TestHashSet(t *testing.T) {
+	s := NewHashSetFromData(1.5, 2.5, 1.5, 0.0)
+	if s.Size() != 3 {
+		t.Fatalf("Size should be 3, got %v", s.Size())
+	}
+	if s.Insert(2.5) {
+		t.Fatalf("Insert of an existing value should return false")
+	}
+	if !s.Contains(0.0) {
+		t.Fatalf("HashSet should contain 0")
+	}
+	if !s.Erase(1.5) || s.Contains(1.5) {
+		t.Fatalf("1.5 should have been erased")
+	}
+	if values := s.Values(); values.Size() != 2 {
+		t.Fatalf("Values should have 2 elements, got %v", values.Size())
+	}
+}
+
+func TestHashMapZeroValue(t *testing.T) {
+	var m HashMap[string, int]
+	if _, found := m.Get("a"); found || m.Size() != 0 {
+		t.Fatalf("A zero-valued HashMap should be empty")
+	}
+	m.Put("a", 1)
+	m.Put("b", 2)
+	if v, _ := m.Get("b"); v != 2 || m.Size() != 2 {
+		t.Fatalf("Get(b) should be 2, got %v", v)
+	}
+	var s HashSet[int]
+	s.Insert(3)
+	if !s.Contains(3) {
+		t.Fatalf("A zero-valued HashSet should be usable")
+	}
+}
+
+func TestHashMapZeroValueNoHasher(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: HashMap - no Hasher for key type" {
+			t.Fatalf("Should have panicked because there is no Hasher, got \"%v\"", result)
+		}
+	}()
+	var m HashMap[compositeID, int]
+	m.Put(compositeID{}, 1)
+}
@@ -0,0 +1,166 @@
+package gollect
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HashSet is an unordered collection of unique values, based on a hash table.
+//
+// Values are hashed and compared with a Hasher, the same way as the keys of a HashMap.
+//
+// If the values implement the Destructible interface, they will have the Destruct method
+// called on them when they are removed from the HashSet.
+type HashSet[T any] struct {
+	data HashMap[T, struct{}]
+}
+
+// NewHashSet creates a new empty HashSet, by value.
+func NewHashSet[T any]() HashSet[T] {
+	return HashSet[T]{data: NewHashMap[T, struct{}]()}
+}
+
+// NewHashSetWithHasher creates a new empty HashSet that uses hasher for its values, by value.
+func NewHashSetWithHasher[T any](hasher Hasher[T]) HashSet[T] {
+	return HashSet[T]{data: NewHashMapWithHasher[T, struct{}](hasher)}
+}
+
+// NewHashSetFromData creates a new HashSet using the elements in values, by value.
+func NewHashSetFromData[T any](values ...T) HashSet[T] {
+	s := NewHashSet[T]()
+	for _, val := range values {
+		s.Insert(val)
+	}
+	return s
+}
+
+// NewHashSetFromHashSet creates a new HashSet using the values of another, by value.
+func NewHashSetFromHashSet[T any](other HashSet[T]) HashSet[T] {
+	return HashSet[T]{data: NewHashMapFromHashMap(other.data)}
+}
+
+// MakeHashSet creates a new empty HashSet instance.
+func MakeHashSet[T any]() *HashSet[T] {
+	s := NewHashSet[T]()
+	return &s
+}
+
+// MakeHashSetWithHasher creates a new empty HashSet instance that uses hasher for its values.
+func MakeHashSetWithHasher[T any](hasher Hasher[T]) *HashSet[T] {
+	s := NewHashSetWithHasher(hasher)
+	return &s
+}
+
+// MakeHashSetFromData creates a new HashSet instance using the elements in values.
+func MakeHashSetFromData[T any](values ...T) *HashSet[T] {
+	s := NewHashSetFromData(values...)
+	return &s
+}
+
+// MakeHashSetFromHashSet creates a new HashSet instance using the values of another.
+func MakeHashSetFromHashSet[T any](other HashSet[T]) *HashSet[T] {
+	s := NewHashSetFromHashSet(other)
+	return &s
+}
+
+// IsEmpty returns true if the HashSet is empty.
+func (s *HashSet[T]) IsEmpty() bool {
+	return s.data.IsEmpty()
+}
+
+// Size returns the number of values in the HashSet.
+func (s *HashSet[T]) Size() int {
+	return s.data.Size()
+}
+
+// Insert adds value to the HashSet, and returns true if it was not already present.
+func (s *HashSet[T]) Insert(value T) bool {
+	if s.data.ContainsKey(value) {
+		return false
+	}
+	s.data.Put(value, struct{}{})
+	return true
+}
+
+// InsertRef adds value to the HashSet, and returns true if it was not already present.
+func (s *HashSet[T]) InsertRef(value *T) bool {
+	return s.Insert(*value)
+}
+
+// Contains returns true if the HashSet contains value.
+func (s *HashSet[T]) Contains(value T) bool {
+	return s.data.ContainsKey(value)
+}
+
+// Erase removes value from the HashSet, and returns true if it was present.
+//
+// If the value implements the Destructible interface, it will have the Destruct method called
+// on it.
+func (s *HashSet[T]) Erase(value T) bool {
+	bucket, idx, _ := s.data.find(&value)
+	if idx < 0 {
+		return false
+	}
+	if e, isDestructible := interface{}(&s.data.buckets[bucket][idx].key).(Destructible); isDestructible {
+		e.Destruct()
+	}
+	return s.data.Erase(value)
+}
+
+// Clear removes all the values from the HashSet.
+//
+// If the values implement the Destructible interface, they will have the Destruct method
+// called on them.
+func (s *HashSet[T]) Clear() {
+	s.Visit(func(value *T, break_out *bool) {
+		if e, isDestructible := interface{}(value).(Destructible); isDestructible {
+			e.Destruct()
+		}
+	})
+	s.data.Clear()
+}
+
+// Swap swaps the data of two HashSets.
+func (s *HashSet[T]) Swap(other *HashSet[T]) {
+	s.data.Swap(&other.data)
+}
+
+// Clone returns a new HashSet with copies of the values, that does not share storage with this
+// one.
+//
+// Note, the values are copied by assignment, even by DeepCopy, as a deep copy of a value could
+// hash differently.
+func (s *HashSet[T]) Clone() HashSet[T] {
+	return NewHashSetFromHashSet(*s)
+}
+
+// Visit calls a function for every value in the HashSet, in no particular order.
+//
+// Note, the values must not be modified in a way that changes their hash.
+func (s *HashSet[T]) Visit(visitor CollectionVisitor[T]) {
+	s.data.Visit(func(key *T, value *struct{}, break_out *bool) {
+		visitor(key, break_out)
+	})
+}
+
+// Values returns a Vector of all the values in the HashSet, in no particular order.
+func (s *HashSet[T]) Values() Vector[T] {
+	return s.data.Keys()
+}
+
+// String returns a string representation of the HashSet and it's contents.
+func (s *HashSet[T]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	count := 0
+	s.Visit(func(value *T, break_out *bool) {
+		if count == s.Size()-1 {
+			fmt.Fprintf(&builder, "%v", *value)
+		} else {
+			fmt.Fprintf(&builder, "%v, ", *value)
+		}
+		count++
+	})
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
@@ -0,0 +1,325 @@
+package gollect
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Interval is a closed range of keys from Low to High, with an associated value.
+type Interval[K constraints.Ordered, V any] struct {
+	Low   K
+	High  K
+	Value V
+}
+
+// Overlaps returns true if the interval shares at least one key with the range from low to high.
+func (i *Interval[K, V]) Overlaps(low K, high K) bool {
+	return i.Low <= high && low <= i.High
+}
+
+// String returns a string representation of the Interval.
+func (i Interval[K, V]) String() string {
+	return fmt.Sprintf("[%v, %v]: %v", i.Low, i.High, i.Value)
+}
+
+type intervalTreeNode[K constraints.Ordered, V any] struct {
+	interval Interval[K, V]
+	max_high K
+	height   int
+	left     *intervalTreeNode[K, V]
+	right    *intervalTreeNode[K, V]
+}
+
+func intervalTreeHeight[K constraints.Ordered, V any](node *intervalTreeNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+// update recomputes the height and max_high of node from its children.
+func (node *intervalTreeNode[K, V]) update() {
+	node.height = intervalTreeHeight(node.left)
+	if right := intervalTreeHeight(node.right); right > node.height {
+		node.height = right
+	}
+	node.height++
+	node.max_high = node.interval.High
+	if node.left != nil && node.left.max_high > node.max_high {
+		node.max_high = node.left.max_high
+	}
+	if node.right != nil && node.right.max_high > node.max_high {
+		node.max_high = node.right.max_high
+	}
+}
+
+func (node *intervalTreeNode[K, V]) rotateLeft() *intervalTreeNode[K, V] {
+	root := node.right
+	node.right = root.left
+	root.left = node
+	node.update()
+	root.update()
+	return root
+}
+
+func (node *intervalTreeNode[K, V]) rotateRight() *intervalTreeNode[K, V] {
+	root := node.left
+	node.left = root.right
+	root.right = node
+	node.update()
+	root.update()
+	return root
+}
+
+// balance updates node and restores the AVL invariant at it, returning the new subtree root.
+func (node *intervalTreeNode[K, V]) balance() *intervalTreeNode[K, V] {
+	node.update()
+	factor := intervalTreeHeight(node.left) - intervalTreeHeight(node.right)
+	if factor > 1 {
+		if intervalTreeHeight(node.left.left) < intervalTreeHeight(node.left.right) {
+			node.left = node.left.rotateLeft()
+		}
+		return node.rotateRight()
+	} else if factor < -1 {
+		if intervalTreeHeight(node.right.right) < intervalTreeHeight(node.right.left) {
+			node.right = node.right.rotateRight()
+		}
+		return node.rotateLeft()
+	}
+	return node
+}
+
+// IntervalTree stores intervals of ordered keys, and finds all the intervals overlapping a key or
+// a range of keys in O(log n + m) time, for m results.
+//
+// It is an AVL tree ordered by the low end of the intervals, where every node also tracks the
+// highest key in its subtree. Several intervals may have the same bounds.
+type IntervalTree[K constraints.Ordered, V any] struct {
+	root *intervalTreeNode[K, V]
+	size int
+}
+
+// NewIntervalTree creates a new empty IntervalTree, by value.
+func NewIntervalTree[K constraints.Ordered, V any]() IntervalTree[K, V] {
+	return IntervalTree[K, V]{}
+}
+
+// NewIntervalTreeFromIntervalTree creates a new IntervalTree with the same intervals as another,
+// by value.
+func NewIntervalTreeFromIntervalTree[K constraints.Ordered, V any](other IntervalTree[K, V]) IntervalTree[K, V] {
+	var clone func(node *intervalTreeNode[K, V]) *intervalTreeNode[K, V]
+	clone = func(node *intervalTreeNode[K, V]) *intervalTreeNode[K, V] {
+		if node == nil {
+			return nil
+		}
+		copied := *node
+		copied.left = clone(node.left)
+		copied.right = clone(node.right)
+		return &copied
+	}
+	return IntervalTree[K, V]{root: clone(other.root), size: other.size}
+}
+
+// MakeIntervalTree creates a new empty IntervalTree instance.
+func MakeIntervalTree[K constraints.Ordered, V any]() *IntervalTree[K, V] {
+	return &IntervalTree[K, V]{}
+}
+
+// MakeIntervalTreeFromIntervalTree creates a new IntervalTree instance with the same intervals as
+// another.
+func MakeIntervalTreeFromIntervalTree[K constraints.Ordered, V any](other IntervalTree[K, V]) *IntervalTree[K, V] {
+	t := NewIntervalTreeFromIntervalTree(other)
+	return &t
+}
+
+// IsEmpty returns true if the IntervalTree is empty.
+func (t *IntervalTree[K, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Size returns the number of intervals in the IntervalTree.
+func (t *IntervalTree[K, V]) Size() int {
+	return t.size
+}
+
+// Insert adds the interval from low to high, inclusive, with value.
+//
+// Panics if low is greater than high, or either is NaN.
+func (t *IntervalTree[K, V]) Insert(low K, high K, value V) {
+	if !(low <= high) {
+		panic("ERROR: IntervalTree.Insert - invalid interval")
+	}
+	var insert func(node *intervalTreeNode[K, V]) *intervalTreeNode[K, V]
+	insert = func(node *intervalTreeNode[K, V]) *intervalTreeNode[K, V] {
+		if node == nil {
+			return &intervalTreeNode[K, V]{interval: Interval[K, V]{Low: low, High: high, Value: value}, max_high: high, height: 1}
+		}
+		// Equal intervals go right, so that they are visited in insertion order
+		if low < node.interval.Low || (low == node.interval.Low && high < node.interval.High) {
+			node.left = insert(node.left)
+		} else {
+			node.right = insert(node.right)
+		}
+		return node.balance()
+	}
+	t.root = insert(t.root)
+	t.size++
+}
+
+func intervalTreeRemoveMin[K constraints.Ordered, V any](node *intervalTreeNode[K, V]) (root *intervalTreeNode[K, V], min *intervalTreeNode[K, V]) {
+	if node.left == nil {
+		return node.right, node
+	}
+	node.left, min = intervalTreeRemoveMin(node.left)
+	return node.balance(), min
+}
+
+// Delete removes the first inserted interval from low to high, and returns true if there was
+// one.
+func (t *IntervalTree[K, V]) Delete(low K, high K) bool {
+	deleted := false
+	var remove func(node *intervalTreeNode[K, V]) *intervalTreeNode[K, V]
+	remove = func(node *intervalTreeNode[K, V]) *intervalTreeNode[K, V] {
+		if node == nil {
+			return nil
+		}
+		if low < node.interval.Low || (low == node.interval.Low && high < node.interval.High) {
+			node.left = remove(node.left)
+		} else if low > node.interval.Low || high > node.interval.High {
+			node.right = remove(node.right)
+		} else {
+			// Equal intervals inserted earlier come before this one, in the left subtree
+			node.left = remove(node.left)
+			if deleted {
+				return node.balance()
+			}
+			deleted = true
+			if node.left == nil {
+				return node.right
+			} else if node.right == nil {
+				return node.left
+			}
+			right, min := intervalTreeRemoveMin(node.right)
+			min.left, min.right = node.left, right
+			return min.balance()
+		}
+		return node.balance()
+	}
+	t.root = remove(t.root)
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+// visitOverlapping visits the intervals overlapping low to high in node, in order, and returns
+// true if the visitor broke out.
+func (t *IntervalTree[K, V]) visitOverlapping(node *intervalTreeNode[K, V], low K, high K, visitor CollectionVisitor[Interval[K, V]], break_out *bool) bool {
+	if node == nil || node.max_high < low {
+		return false
+	}
+	if t.visitOverlapping(node.left, low, high, visitor, break_out) {
+		return true
+	}
+	if node.interval.Low > high {
+		// Every interval to the right starts even later
+		return false
+	}
+	if node.interval.Overlaps(low, high) {
+		visitor(&node.interval, break_out)
+		if *break_out {
+			return true
+		}
+	}
+	return t.visitOverlapping(node.right, low, high, visitor, break_out)
+}
+
+// VisitOverlapping calls a function for every interval overlapping the range from low to high,
+// inclusive, ordered by their low end.
+func (t *IntervalTree[K, V]) VisitOverlapping(low K, high K, visitor CollectionVisitor[Interval[K, V]]) {
+	break_out := false
+	t.visitOverlapping(t.root, low, high, visitor, &break_out)
+}
+
+// QueryRange returns every interval overlapping the range from low to high, inclusive, ordered by
+// their low end.
+func (t *IntervalTree[K, V]) QueryRange(low K, high K) Vector[Interval[K, V]] {
+	results := NewVector[Interval[K, V]]()
+	t.VisitOverlapping(low, high, func(interval *Interval[K, V], break_out *bool) {
+		results.PushBack(*interval)
+	})
+	return results
+}
+
+// QueryPoint returns every interval containing point, ordered by their low end.
+func (t *IntervalTree[K, V]) QueryPoint(point K) Vector[Interval[K, V]] {
+	return t.QueryRange(point, point)
+}
+
+// Visit calls a function for every interval in the IntervalTree, ordered by their low end.
+func (t *IntervalTree[K, V]) Visit(visitor CollectionVisitor[Interval[K, V]]) {
+	break_out := false
+	var visit func(node *intervalTreeNode[K, V]) bool
+	visit = func(node *intervalTreeNode[K, V]) bool {
+		if node == nil {
+			return false
+		}
+		if visit(node.left) {
+			return true
+		}
+		visitor(&node.interval, &break_out)
+		if break_out {
+			return true
+		}
+		return visit(node.right)
+	}
+	visit(t.root)
+}
+
+// Clear removes all the intervals from the IntervalTree.
+func (t *IntervalTree[K, V]) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+// Swap swaps the data of two IntervalTrees.
+func (t *IntervalTree[K, V]) Swap(other *IntervalTree[K, V]) {
+	*t, *other = *other, *t
+}
+
+// Clone returns a new IntervalTree with copies of the intervals, that does not share any nodes
+// with this one.
+//
+// Values are copied by assignment, so pointers, slices and maps inside them are still shared.
+// Use DeepClone to copy those too.
+func (t *IntervalTree[K, V]) Clone() IntervalTree[K, V] {
+	return NewIntervalTreeFromIntervalTree(*t)
+}
+
+// DeepClone returns a new IntervalTree with deep copies of the values, see Vector.DeepClone.
+func (t *IntervalTree[K, V]) DeepClone() IntervalTree[K, V] {
+	result := t.Clone()
+	result.Visit(func(interval *Interval[K, V], break_out *bool) {
+		interval.Value = DeepCopy(interval.Value)
+	})
+	return result
+}
+
+// String returns a string representation of the IntervalTree and it's contents.
+func (t *IntervalTree[K, V]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	first := true
+	t.Visit(func(interval *Interval[K, V], break_out *bool) {
+		if first {
+			first = false
+		} else {
+			fmt.Fprintf(&builder, ", ")
+		}
+		fmt.Fprintf(&builder, "%v", interval.String())
+	})
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
@@ -0,0 +1,114 @@
+package gollect
+
+import (
+	"math/rand"
+	"testing"
+
+	"golang.org/x/exp/constraints"
+)
+
+func TestIntervalTreeQueries(t *testing.T) {
+	tree := NewIntervalTree[int, string]()
+	tree.Insert(15, 20, "a")
+	tree.Insert(10, 30, "b")
+	tree.Insert(17, 19, "c")
+	tree.Insert(5, 20, "d")
+	tree.Insert(12, 15, "e")
+	tree.Insert(30, 40, "f")
+	if tree.Size() != 6 {
+		t.Fatalf("Size should be 6, got %v", tree.Size())
+	}
+	point := tree.QueryPoint(16)
+	if point.String() != "{[5, 20]: d, [10, 30]: b, [15, 20]: a}" {
+		t.Fatalf("QueryPoint(16) returned %v", point.String())
+	}
+	overlapping := tree.QueryRange(30, 35)
+	if overlapping.String() != "{[10, 30]: b, [30, 40]: f}" {
+		t.Fatalf("QueryRange(30, 35) returned %v", overlapping.String())
+	}
+	none := tree.QueryRange(41, 50)
+	if !none.IsEmpty() {
+		t.Fatalf("QueryRange(41, 50) should be empty, got %v", none.String())
+	}
+
+	count := 0
+	tree.VisitOverlapping(0, 100, func(interval *Interval[int, string], break_out *bool) {
+		count++
+		*break_out = interval.Value == "e"
+	})
+	if count != 3 {
+		t.Fatalf("VisitOverlapping should have stopped after 3 intervals, got %v", count)
+	}
+}
+
+func TestIntervalTreeDelete(t *testing.T) {
+	tree := NewIntervalTree[float64, int]()
+	tree.Insert(1, 2, 1)
+	tree.Insert(1, 2, 2)
+	tree.Insert(0, 5, 3)
+	if !tree.Delete(1, 2) || tree.Delete(1, 3) {
+		t.Fatalf("Delete returned the wrong result")
+	}
+	if tree.String() != "{[0, 5]: 3, [1, 2]: 2}" {
+		t.Fatalf("Delete should have removed the first inserted [1, 2], got %v", tree.String())
+	}
+}
+
+func TestIntervalTreeInvalid(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: IntervalTree.Insert - invalid interval" {
+			t.Fatalf("Should have panicked because the interval is invalid, got \"%v\"", result)
+		}
+	}()
+	tree := NewIntervalTree[int, int]()
+	tree.Insert(2, 1, 0)
+}
+
+func checkIntervalTree[K constraints.Ordered, V any](t *testing.T, node *intervalTreeNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	left, right := checkIntervalTree(t, node.left), checkIntervalTree(t, node.right)
+	if left-right > 1 || right-left > 1 {
+		t.Fatalf("IntervalTree is unbalanced at %v", node.interval.String())
+	}
+	expected := *node
+	expected.update()
+	if expected.max_high != node.max_high || expected.height != node.height {
+		t.Fatalf("IntervalTree has a stale node at %v", node.interval.String())
+	}
+	return node.height
+}
+
+func TestIntervalTreeRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(39))
+	tree := NewIntervalTree[int, int]()
+	intervals := []Interval[int, int]{}
+	for idx := 0; idx < 2000; idx++ {
+		if len(intervals) > 0 && rng.Intn(3) == 0 {
+			i := rng.Intn(len(intervals))
+			if !tree.Delete(intervals[i].Low, intervals[i].High) {
+				t.Fatalf("Delete should have found %v", intervals[i].String())
+			}
+			intervals = append(intervals[:i], intervals[i+1:]...)
+		} else {
+			low := rng.Intn(1000)
+			interval := Interval[int, int]{Low: low, High: low + rng.Intn(50), Value: idx}
+			tree.Insert(interval.Low, interval.High, interval.Value)
+			intervals = append(intervals, interval)
+		}
+	}
+	checkIntervalTree(t, tree.root)
+	for point := 0; point < 1100; point += 7 {
+		expected := 0
+		for _, interval := range intervals {
+			if interval.Overlaps(point, point+3) {
+				expected++
+			}
+		}
+		if got := tree.QueryRange(point, point+3); got.Size() != expected {
+			t.Fatalf("QueryRange(%v, %v) should have %v results, got %v", point, point+3, expected, got.Size())
+		}
+	}
+}
@@ -5,23 +5,20 @@ import (
 	"strings"
 )
 
-type listNode[T any] struct {
+// ListNode is a node of a List, holding one element. It is exported so that a NodeAllocator for
+// List nodes can be named, as in NewFreeListAllocator[ListNode[T]]().
+type ListNode[T any] struct {
 	data T
-	prev *listNode[T]
-	next *listNode[T]
-}
-
-func newListNode[T any]() *listNode[T] {
-	return &listNode[T]{prev: nil, next: nil}
-}
-
-func newListNodeValue[T any](value T) *listNode[T] {
-	return &listNode[T]{data: value, prev: nil, next: nil}
+	prev *ListNode[T]
+	next *ListNode[T]
 }
 
 type List[T any] struct {
-	front *listNode[T]
-	back  *listNode[T]
+	front     *ListNode[T]
+	back      *ListNode[T]
+	size      int
+	equal     Equaler[T]
+	allocator NodeAllocator[ListNode[T]]
 }
 
 func NewList[T any]() List[T] {
@@ -46,6 +43,7 @@ func NewListFromDataRef[T any](values ...*T) List[T] {
 
 func NewListFromList[T any](other List[T]) List[T] {
 	ret := NewList[T]()
+	ret.equal = other.equal
 	other.Visit(func(item *T, break_out *bool) {
 		ret.PushBack(*item)
 	})
@@ -74,12 +72,73 @@ func MakeListFromDataRef[T any](values ...*T) *List[T] {
 
 func MakeListFromList[T any](other List[T]) *List[T] {
 	ret := MakeList[T]()
+	ret.equal = other.equal
 	other.Visit(func(item *T, break_out *bool) {
 		ret.PushBack(*item)
 	})
 	return ret
 }
 
+func NewListWithEqualer[T any](equal Equaler[T], values ...T) List[T] {
+	ret := NewListFromData(values...)
+	ret.equal = equal
+	return ret
+}
+
+func MakeListWithEqualer[T any](equal Equaler[T], values ...T) *List[T] {
+	ret := MakeListFromData(values...)
+	ret.equal = equal
+	return ret
+}
+
+// NewListWithAllocator creates a new List holding copies of values, that gets its nodes from
+// allocator, by value.
+//
+// A nil allocator allocates every node with new, like a List created any other way. Copies of
+// the List, made with NewListFromList, MakeListFromList or Clone, do not use the allocator, as it
+// may not be safe to share, see FreeListAllocator.
+func NewListWithAllocator[T any](allocator NodeAllocator[ListNode[T]], values ...T) List[T] {
+	ret := List[T]{allocator: allocator}
+	for _, v := range values {
+		ret.PushBack(v)
+	}
+	return ret
+}
+
+// MakeListWithAllocator creates a new List instance holding copies of values, that gets its nodes
+// from allocator.
+func MakeListWithAllocator[T any](allocator NodeAllocator[ListNode[T]], values ...T) *List[T] {
+	ret := NewListWithAllocator(allocator, values...)
+	return &ret
+}
+
+func (l *List[T]) newNode(value T) *ListNode[T] {
+	if l.allocator == nil {
+		return &ListNode[T]{data: value}
+	}
+	node := l.allocator.Allocate()
+	node.data = value
+	return node
+}
+
+func (l *List[T]) freeNode(node *ListNode[T]) {
+	if l.allocator != nil {
+		l.allocator.Free(node)
+	}
+}
+
+func (l *List[T]) SetEqualer(equal Equaler[T]) {
+	l.equal = equal
+}
+
+func (l *List[T]) equaler(method string) Equaler[T] {
+	equal := resolveEqualer(l.equal)
+	if equal == nil {
+		panic("ERROR: List." + method + " - no Equaler for element type")
+	}
+	return equal
+}
+
 func (l *List[T]) Front() T {
 	if l.IsEmpty() {
 		panic("ERROR: List.Front - empty vector")
@@ -113,14 +172,7 @@ func (l *List[T]) IsEmpty() bool {
 }
 
 func (l *List[T]) Size() int {
-	if l.IsEmpty() {
-		return 0
-	}
-	total := 0
-	l.Visit(func(item *T, break_out *bool) {
-		total++
-	})
-	return total
+	return l.size
 }
 
 func (l *List[T]) Clear() {
@@ -129,192 +181,239 @@ func (l *List[T]) Clear() {
 	}
 }
 
-func (l *List[T]) Insert(index int, value T) {
-	if l.IsEmpty() && index != 0 {
-		panic("ERROR: List.Insert - index out of bounds for empty List")
-	} else if l.IsEmpty() {
-		l.front = newListNodeValue(value)
-		l.back = l.front
-	} else {
-		new_node := newListNodeValue(value)
-		idx := 0
-		l.visitNode(func(node *listNode[T], break_out *bool) {
-			if idx < index {
-				idx++
-			} else if idx == index {
-				prev := node.prev
-				next := node.next
-				if next == nil {
-					prev.next = new_node
-					new_node.prev = prev
-					new_node.next = node
-					node.prev = new_node
-					node.next = nil
-					l.back = node
-				} else {
-					prev.next = new_node
-					new_node.prev = prev
-					new_node.next = node
-					node.prev = new_node
-					node.next = next
-				}
-				*break_out = true
-			}
-		})
-		if idx < index {
-			panic("ERROR: List.Insert - index out of bounds")
+// nodeAt returns the node at index, walking from whichever end is closer.
+func (l *List[T]) nodeAt(index int, size int) *ListNode[T] {
+	if index < size/2 {
+		node := l.front
+		for idx := 0; idx < index; idx++ {
+			node = node.next
 		}
+		return node
 	}
+	node := l.back
+	for idx := size - 1; idx > index; idx-- {
+		node = node.prev
+	}
+	return node
 }
 
-func (l *List[T]) InsertRef(index int, value *T) {
-	if l.IsEmpty() && index != 0 {
-		panic("ERROR: List.Insert - index out of bounds for empty List")
-	} else if l.IsEmpty() {
-		l.front = newListNodeValue(*value)
-		l.back = l.front
+// linkBefore links node before next, or at the back if next is nil.
+func (l *List[T]) linkBefore(next *ListNode[T], node *ListNode[T]) {
+	node.next = next
+	if next == nil {
+		node.prev = l.back
+		l.back = node
 	} else {
-		new_node := newListNodeValue(*value)
-		idx := 0
-		l.visitNode(func(node *listNode[T], break_out *bool) {
-			if idx < index {
-				idx++
-			} else if idx == index {
-				prev := node.prev
-				next := node.next
-				if next == nil {
-					prev.next = new_node
-					new_node.prev = prev
-					new_node.next = node
-					node.prev = new_node
-					node.next = nil
-					l.back = node
-				} else {
-					prev.next = new_node
-					new_node.prev = prev
-					new_node.next = node
-					node.prev = new_node
-					node.next = next
-				}
-				*break_out = true
-			}
-		})
-		if idx < index {
-			panic("ERROR: List.Insert - index out of bounds")
-		}
+		node.prev = next.prev
+		next.prev = node
+	}
+	if node.prev == nil {
+		l.front = node
+	} else {
+		node.prev.next = node
+	}
+	l.size++
+}
+
+// unlink removes node from the List, calls Destruct on its element if it is Destructible, and
+// returns it to the NodeAllocator.
+func (l *List[T]) unlink(node *ListNode[T]) {
+	if e, isDestructible := interface{}(&node.data).(Destructible); isDestructible {
+		e.Destruct()
+	}
+	if node.prev == nil {
+		l.front = node.next
+	} else {
+		node.prev.next = node.next
+	}
+	if node.next == nil {
+		l.back = node.prev
+	} else {
+		node.next.prev = node.prev
+	}
+	l.size--
+	l.freeNode(node)
+}
+
+func (l *List[T]) Insert(index int, value T) {
+	size := l.Size()
+	if (index < 0) || (index > size) {
+		panic("ERROR: List.Insert - index out of bounds")
+	}
+	var next *ListNode[T] = nil
+	if index < size {
+		next = l.nodeAt(index, size)
+	}
+	l.linkBefore(next, l.newNode(value))
+}
+
+func (l *List[T]) InsertRef(index int, value *T) {
+	size := l.Size()
+	if (index < 0) || (index > size) {
+		panic("ERROR: List.InsertRef - index out of bounds")
+	}
+	var next *ListNode[T] = nil
+	if index < size {
+		next = l.nodeAt(index, size)
 	}
+	l.linkBefore(next, l.newNode(*value))
 }
 
 func (l *List[T]) Erase(index int) {
 	if l.IsEmpty() {
 		panic("ERROR: List.Erase - empty list")
-	} else {
-		idx := 0
-		l.visitNode(func(node *listNode[T], break_out *bool) {
-			if idx < index {
-				idx++
-			} else if idx == index {
-				prev := node.prev
-				next := node.next
-
-				if e, isDestructible := interface{}(&node.data).(Destructible); isDestructible {
-					e.Destruct()
-				}
-
-				prev.next = next
-				if next != nil {
-					next.prev = prev
-				} else {
-					l.back = prev
-				}
-			}
-			*break_out = true
-		})
-		if idx < index {
-			panic("ERROR: List.Erase - index out of bounds")
+	}
+	size := l.Size()
+	if (index < 0) || (index >= size) {
+		panic("ERROR: List.Erase - index out of bounds")
+	}
+	l.unlink(l.nodeAt(index, size))
+}
+
+// InsertRange adds copies of values at the specified index, before the element currently there.
+func (l *List[T]) InsertRange(index int, values ...T) {
+	size := l.Size()
+	if (index < 0) || (index > size) {
+		panic("ERROR: List.InsertRange - index out of bounds")
+	}
+	var next *ListNode[T] = nil
+	if index < size {
+		next = l.nodeAt(index, size)
+	}
+	for _, value := range values {
+		l.linkBefore(next, l.newNode(value))
+	}
+}
+
+// InsertVector adds copies of the elements of other at the specified index.
+func (l *List[T]) InsertVector(index int, other Vector[T]) {
+	if (index < 0) || (index > l.Size()) {
+		panic("ERROR: List.InsertVector - index out of bounds")
+	}
+	l.InsertRange(index, other.data...)
+}
+
+// AppendVector adds copies of the elements of other to the back of the List.
+func (l *List[T]) AppendVector(other Vector[T]) {
+	for _, value := range other.data {
+		l.linkBefore(nil, l.newNode(value))
+	}
+}
+
+// EraseRange removes the elements in the range [from, to).
+//
+// If the elements implement the Destructible interface, they will have the Destruct method called on them.
+func (l *List[T]) EraseRange(from int, to int) {
+	size := l.Size()
+	if (from < 0) || (to > size) || (from > to) {
+		panic("ERROR: List.EraseRange - range out of bounds")
+	}
+	if from == to {
+		return
+	}
+	node := l.nodeAt(from, size)
+	for idx := from; idx < to; idx++ {
+		next := node.next
+		l.unlink(node)
+		node = next
+	}
+}
+
+// EraseIf removes every element that satisfies predicate in a single pass, and returns the
+// number of elements removed.
+//
+// If the elements implement the Destructible interface, the removed ones will have the Destruct method called on them.
+func (l *List[T]) EraseIf(predicate CollectionPredicate[T]) int {
+	removed := 0
+	for node := l.front; node != nil; {
+		next := node.next
+		if predicate(&node.data) {
+			l.unlink(node)
+			removed++
 		}
+		node = next
 	}
+	return removed
 }
 
-func (l *List[T]) PushBack(value T) {
-	node := newListNodeValue(value)
-	if !l.IsEmpty() {
-		l.back.next = node
-	} else {
-		l.front = node
+// Truncate removes all the elements from index size onwards.
+//
+// If the elements implement the Destructible interface, they will have the Destruct method called on them.
+func (l *List[T]) Truncate(size int) {
+	current := l.Size()
+	if (size < 0) || (size > current) {
+		panic("ERROR: List.Truncate - size out of bounds")
+	}
+	for idx := size; idx < current; idx++ {
+		l.unlink(l.back)
 	}
-	node.prev = l.back
-	l.back = node
 }
 
-func (l *List[T]) PushBackRef(value *T) {
-	node := newListNodeValue(*value)
-	if !l.IsEmpty() {
-		l.back.next = node
-	} else {
-		l.front = node
+// Assign replaces the elements of the List with copies of values.
+//
+// If the old elements implement the Destructible interface, they will have the Destruct method called on them.
+func (l *List[T]) Assign(values ...T) {
+	l.Clear()
+	for _, value := range values {
+		l.linkBefore(nil, l.newNode(value))
 	}
-	node.prev = l.back
-	l.back = node
+}
+
+func (l *List[T]) PushBack(value T) {
+	l.linkBefore(nil, l.newNode(value))
+}
+
+func (l *List[T]) PushBackRef(value *T) {
+	l.linkBefore(nil, l.newNode(*value))
 }
 
 func (l *List[T]) PushFront(value T) {
-	node := newListNodeValue(value)
-	if !l.IsEmpty() {
-		l.front.prev = node
-	} else {
-		l.back = node
-	}
-	node.next = l.front
-	l.front = node
+	l.linkBefore(l.front, l.newNode(value))
 }
 
 func (l *List[T]) PushFrontRef(value *T) {
-	node := newListNodeValue(*value)
-	if !l.IsEmpty() {
-		l.front.prev = node
-	} else {
-		l.back = node
-	}
-	node.next = l.front
-	l.front = node
+	l.linkBefore(l.front, l.newNode(*value))
 }
 
 func (l *List[T]) PopBack() {
 	if l.IsEmpty() {
 		panic("ERROR: List.PopBack - empty list")
 	}
-	if e, isDestructible := interface{}(&l.back.data).(Destructible); isDestructible {
-		e.Destruct()
-	}
-	if l.front == l.back {
-		l.front, l.back = nil, nil
-	} else {
-		l.back.prev.next = nil
-		l.back = l.back.prev
-	}
+	l.unlink(l.back)
 }
 
 func (l *List[T]) PopFront() {
 	if l.IsEmpty() {
 		panic("ERROR: List.PopFront - empty list")
 	}
-	if e, isDestructible := interface{}(&l.front.data).(Destructible); isDestructible {
-		e.Destruct()
-	}
-	if l.front == l.back {
-		l.front, l.back = nil, nil
-	} else {
-		l.front.next.prev = nil
-		l.front = l.front.next
-	}
+	l.unlink(l.front)
 }
 
 func (l *List[T]) Swap(other *List[T]) {
 	l.front, l.back, other.front, other.back = other.front, other.back, l.front, l.back
+	l.size, other.size = other.size, l.size
+	l.allocator, other.allocator = other.allocator, l.allocator
 }
 
-type nodeVisitor[T any] func(*listNode[T], *bool)
+// Clone returns a new List with copies of the elements, that does not share any nodes with this
+// one.
+//
+// Elements are copied by assignment, so pointers, slices and maps inside them are still shared.
+// Use DeepClone to copy those too.
+func (l *List[T]) Clone() List[T] {
+	return NewListFromList(*l)
+}
+
+// DeepClone returns a new List with deep copies of the elements, see Vector.DeepClone.
+func (l *List[T]) DeepClone() List[T] {
+	result := l.Clone()
+	result.Visit(func(item *T, break_out *bool) {
+		*item = DeepCopy(*item)
+	})
+	return result
+}
+
+type nodeVisitor[T any] func(*ListNode[T], *bool)
 
 func (l *List[T]) visitNode(visitor nodeVisitor[T]) {
 	if l.IsEmpty() {
@@ -365,17 +464,14 @@ func (l *List[T]) VisitReverse(visitor CollectionVisitor[T]) {
 }
 
 func (l *List[T]) ContainsValue(value T) bool {
+	equal := l.equaler("ContainsValue")
 	if l.IsEmpty() {
 		return false
 	}
-	if _, isEqComparable := interface{}(l.FrontRef()).(EqualityComparable[T]); !isEqComparable {
-		return false
-	}
 	ret := false
 	idx := 0
 	l.Visit(func(vv *T, break_out *bool) {
-		vvv, _ := interface{}(vv).(EqualityComparable[T])
-		if vvv.Equal(value) {
+		if equal(vv, &value) {
 			ret = true
 			*break_out = true
 		}
@@ -401,17 +497,14 @@ func (l *List[T]) ContainsRef(value *T) bool {
 }
 
 func (l *List[T]) OrderedSearch(value T) (found bool, index int) {
+	equal := l.equaler("OrderedSearch")
 	if l.IsEmpty() {
 		return false, -1
 	}
-	if _, isEqComparable := interface{}(l.FrontRef()).(EqualityComparable[T]); !isEqComparable {
-		return false, -1
-	}
 	found = false
 	index = 0
 	l.Visit(func(vv *T, break_out *bool) {
-		vvv, _ := interface{}(vv).(EqualityComparable[T])
-		if vvv.Equal(value) {
+		if equal(vv, &value) {
 			found = true
 			*break_out = true
 		} else {
@@ -439,16 +532,13 @@ func (l *List[T]) OrderedRefSearch(value *T) (found bool, index int) {
 }
 
 func (l *List[T]) OrderedSearchRef(value T) *T {
+	equal := l.equaler("OrderedSearchRef")
 	if l.IsEmpty() {
 		return nil
 	}
-	if _, isEqComparable := interface{}(l.FrontRef()).(EqualityComparable[T]); !isEqComparable {
-		return nil
-	}
 	var ret *T = nil
 	l.Visit(func(vv *T, break_out *bool) {
-		vvv, _ := interface{}(vv).(EqualityComparable[T])
-		if vvv.Equal(value) {
+		if equal(vv, &value) {
 			ret = vv
 			*break_out = true
 		}
@@ -486,6 +576,59 @@ func (l *List[T]) RefSearchRef(value *T) *T {
 	return l.OrderedRefSearchRef(value)
 }
 
+func (l *List[T]) FindIf(predicate CollectionPredicate[T]) *T {
+	var ret *T = nil
+	l.Visit(func(vv *T, break_out *bool) {
+		if predicate(vv) {
+			ret = vv
+			*break_out = true
+		}
+	})
+	return ret
+}
+
+func (l *List[T]) FindLastIf(predicate CollectionPredicate[T]) *T {
+	var ret *T = nil
+	l.VisitReverse(func(vv *T, break_out *bool) {
+		if predicate(vv) {
+			ret = vv
+			*break_out = true
+		}
+	})
+	return ret
+}
+
+func (l *List[T]) ContainsIf(predicate CollectionPredicate[T]) bool {
+	return l.FindIf(predicate) != nil
+}
+
+func (l *List[T]) CountIf(predicate CollectionPredicate[T]) int {
+	count := 0
+	l.Visit(func(vv *T, break_out *bool) {
+		if predicate(vv) {
+			count++
+		}
+	})
+	return count
+}
+
+func (l *List[T]) IndexIf(predicate CollectionPredicate[T]) int {
+	index := 0
+	found := false
+	l.Visit(func(vv *T, break_out *bool) {
+		if predicate(vv) {
+			found = true
+			*break_out = true
+		} else {
+			index++
+		}
+	})
+	if !found {
+		return -1
+	}
+	return index
+}
+
 func (l *List[T]) String() string {
 	var builder strings.Builder
 	fmt.Fprintf(&builder, "{")
@@ -11,3 +11,118 @@ func TestIsListGeneralCollector(t *testing.T) {
 		t.Fatalf("List[int64] should be a GeneralCollector[int64]")
 	}
 }
+
+func TestListFindIf(t *testing.T) {
+	list := NewListFromData[int64](1, 2, 3, 4, 5)
+
+	if p := list.FindIf(func(v *int64) bool { return *v%2 == 0 }); p == nil || *p != 2 {
+		t.Fatalf("FindIf should find 2, got %v", p)
+	}
+	if p := list.FindLastIf(func(v *int64) bool { return *v%2 == 0 }); p == nil || *p != 4 {
+		t.Fatalf("FindLastIf should find 4, got %v", p)
+	}
+	if idx := list.IndexIf(func(v *int64) bool { return *v == 3 }); idx != 2 {
+		t.Fatalf("IndexIf should return 2, got %v", idx)
+	}
+	if idx := list.IndexIf(func(v *int64) bool { return *v == 9 }); idx != -1 {
+		t.Fatalf("IndexIf should return -1, got %v", idx)
+	}
+	if c := list.CountIf(func(v *int64) bool { return *v > 2 }); c != 3 {
+		t.Fatalf("CountIf should return 3, got %v", c)
+	}
+	if _, isPredicateSearcher := interface{}(&list).(PredicateSearcher[int64]); !isPredicateSearcher {
+		t.Fatalf("List[int64] should be a PredicateSearcher[int64]")
+	}
+}
+
+func TestListContainsValueNative(t *testing.T) {
+	list := NewListFromData("a", "b", "c")
+	if !list.ContainsValue("b") {
+		t.Fatalf("List should contain \"b\"")
+	}
+	if found, idx := list.Search("c"); !found || idx != 2 {
+		t.Fatalf("Search should find \"c\" at 2, got %v at %v", found, idx)
+	}
+	if list.ContainsValue("d") {
+		t.Fatalf("List should not contain \"d\"")
+	}
+}
+
+func TestListRanges(t *testing.T) {
+	l := NewListFromData(1, 2, 3)
+	l.InsertRange(1, 7, 8)
+	l.InsertVector(5, NewVectorFromData(4))
+	l.AppendVector(NewVectorFromData(5))
+	if l.String() != "{1, 7, 8, 2, 3, 4, 5}" {
+		t.Fatalf("List should be {1, 7, 8, 2, 3, 4, 5}, got %v", l.String())
+	}
+	l.EraseRange(1, 3)
+	if removed := l.EraseIf(func(value *int) bool { return *value%2 == 0 }); removed != 2 || l.String() != "{1, 3, 5}" {
+		t.Fatalf("EraseIf should leave {1, 3, 5}, got %v", l.String())
+	}
+	l.Truncate(1)
+	if l.String() != "{1}" || l.Back() != 1 {
+		t.Fatalf("Truncate should leave {1}, got %v", l.String())
+	}
+	l.Assign(6, 7)
+	if l.String() != "{6, 7}" {
+		t.Fatalf("Assign should replace the elements with {6, 7}, got %v", l.String())
+	}
+}
+
+func TestListRangesDestruct(t *testing.T) {
+	Msgs = []string{}
+	l := NewListFromData[DBool](true, false, true, false)
+	l.EraseRange(0, 2)
+	l.EraseIf(func(value *DBool) bool { return !bool(*value) })
+	l.Truncate(0)
+	if len(Msgs) != 4 || !l.IsEmpty() {
+		t.Fatalf("Destruct method should have been called 4 times, got %v", len(Msgs))
+	}
+}
+
+func TestListInsertErase(t *testing.T) {
+	l := NewListFromData(1, 2, 3)
+	l.Insert(0, 0)
+	l.Insert(4, 4)
+	l.Insert(2, 9)
+	if l.String() != "{0, 1, 9, 2, 3, 4}" {
+		t.Fatalf("List should be {0, 1, 9, 2, 3, 4}, got %v", l.String())
+	}
+	l.Erase(2)
+	l.Erase(0)
+	l.Erase(3)
+	if l.String() != "{1, 2, 3}" || l.Back() != 3 {
+		t.Fatalf("List should be {1, 2, 3}, got %v", l.String())
+	}
+}
+
+func TestListSize(t *testing.T) {
+	var l List[int]
+	if l.Size() != 0 {
+		t.Fatalf("A zero List should be empty, got %v", l.Size())
+	}
+	l.PushBack(1)
+	l.PushFront(0)
+	l.InsertRange(1, 5, 6, 7)
+	l.AppendVector(NewVectorFromData(8, 9))
+	if l.Size() != 7 {
+		t.Fatalf("List should hold 7 elements, got %v", l.Size())
+	}
+	l.EraseRange(1, 3)
+	l.EraseIf(func(item *int) bool { return *item > 8 })
+	l.Truncate(3)
+	if l.Size() != 3 || l.String() != "{0, 7, 1}" {
+		t.Fatalf("List should be {0, 7, 1}, got %v with a size of %v", l.String(), l.Size())
+	}
+	other := NewListFromData(1)
+	l.Swap(&other)
+	if l.Size() != 1 || other.Size() != 3 {
+		t.Fatalf("Swap should swap the sizes, got %v and %v", l.Size(), other.Size())
+	}
+	other.Assign(4, 5)
+	other.Clear()
+	if other.Size() != 0 || !other.IsEmpty() {
+		t.Fatalf("Clear should empty the List, got a size of %v", other.Size())
+	}
+}
@@ -8,17 +8,37 @@ import (
 )
 
 type NVector[T NativeEquatable] struct {
-	data []T
+	data   []T
+	growth GrowthPolicy
+	// front is the whole array data was sliced from by PopFront, so that the room in front of
+	// data can be reused when the NVector grows.
+	front []T
 }
 
 func NewNVector[T NativeEquatable]() NVector[T] {
 	return NVector[T]{data: []T{}}
 }
 
+// NewNVectorFromData creates a new NVector using copies of the elements in values, by value.
+//
+// The NVector never shares storage with values, even when called as NewNVectorFromData(slice...).
+// Use NewNVectorAdoptingSlice to avoid the copy.
 func NewNVectorFromData[T NativeEquatable](values ...T) NVector[T] {
-	return NVector[T]{data: values}
+	return NVector[T]{data: append([]T{}, values...)}
 }
 
+// NewNVectorAdoptingSlice creates a new NVector that takes ownership of data, without copying it,
+// by value.
+//
+// The caller must not use data afterwards, as the NVector may modify it or append to it.
+func NewNVectorAdoptingSlice[T NativeEquatable](data []T) NVector[T] {
+	if data == nil {
+		data = []T{}
+	}
+	return NVector[T]{data: data}
+}
+
+// NewNVectorFromNVector creates a new NVector using copies of the elements of another, by value.
 func NewNVectorFromNVector[T NativeEquatable](other NVector[T]) NVector[T] {
 	return NewNVectorFromData(other.data...)
 }
@@ -95,28 +115,42 @@ func (v *NVector[T]) BackRef() *T {
 	panic("ERROR: NVector.BackRef - empty vector")
 }
 
+// Data gets the underlying slice of the elements.
+//
+// Note, the slice is still owned by the NVector. Changes to its elements are visible in the
+// NVector, and it may be reallocated by the next change to the NVector.
 func (v *NVector[T]) Data() []T {
 	return v.data
 }
 
 func (v *NVector[T]) Clear() {
 	v.data = []T{}
+	v.front = nil
+}
+
+// reclaim moves the elements back to the start of their array, if PopFront left enough room in
+// front of them to hold needed elements without allocating. values may be a slice of the
+// NVector's own data, so a copy of it is returned if the elements were moved.
+func (v *NVector[T]) reclaim(needed int, values []T) []T {
+	if v.front == nil || needed <= cap(v.data) {
+		return values
+	}
+	if cap(v.front) >= needed && slicesAlias(v.front, v.data) {
+		if len(values) > 0 {
+			values = append([]T{}, values...)
+		}
+		v.data = v.front[:copy(v.front[:cap(v.front)], v.data)]
+	}
+	v.front = nil
+	return values
 }
 
 func (v *NVector[T]) Insert(index int, value T) {
 	if (index < 0) || (index > v.Size()) {
 		panic("ERROR: NVector.Insert - index out of bounds")
 	}
-	if !v.IsEmpty() {
-		if index == v.Size() {
-			v.data = append(v.data, value)
-		} else {
-			v.data = append(v.data[:index+1], v.data[index:]...)
-			v.data[index] = value
-		}
-	} else {
-		v.data = []T{value}
-	}
+	v.reclaim(len(v.data)+1, nil)
+	v.data = insertSlice(v.data, index, value, v.growth)
 }
 
 func (v *NVector[T]) InsertRef(index int, value *T) {
@@ -135,8 +169,71 @@ func (v *NVector[T]) Erase(index int) {
 	}
 }
 
+// InsertRange adds copies of values at the specified index, moving all later elements back in a
+// single move. values may be a slice of the NVector's own Data.
+func (v *NVector[T]) InsertRange(index int, values ...T) {
+	if (index < 0) || (index > v.Size()) {
+		panic("ERROR: NVector.InsertRange - index out of bounds")
+	}
+	values = v.reclaim(len(v.data)+len(values), values)
+	v.data = insertSliceRange(v.data, index, values, v.growth)
+}
+
+// InsertVector adds copies of the elements of other at the specified index, moving all later
+// elements back in a single move.
+func (v *NVector[T]) InsertVector(index int, other NVector[T]) {
+	if (index < 0) || (index > v.Size()) {
+		panic("ERROR: NVector.InsertVector - index out of bounds")
+	}
+	values := v.reclaim(len(v.data)+len(other.data), other.data)
+	v.data = insertSliceRange(v.data, index, values, v.growth)
+}
+
+// AppendVector adds copies of the elements of other to the back of the NVector.
+func (v *NVector[T]) AppendVector(other NVector[T]) {
+	values := v.reclaim(len(v.data)+len(other.data), other.data)
+	v.data = insertSliceRange(v.data, len(v.data), values, v.growth)
+}
+
+// EraseRange removes the elements in the range [from, to), moving all later elements forward in
+// a single move.
+func (v *NVector[T]) EraseRange(from int, to int) {
+	if (from < 0) || (to > v.Size()) || (from > to) {
+		panic("ERROR: NVector.EraseRange - range out of bounds")
+	}
+	v.data = eraseSliceRange(v.data, from, to)
+}
+
+// EraseIf removes every element that satisfies predicate in a single pass, keeping the order of
+// the rest, and returns the number of elements removed.
+func (v *NVector[T]) EraseIf(predicate CollectionPredicate[T]) int {
+	var removed int
+	v.data, removed = eraseSliceIf(v.data, predicate, false)
+	return removed
+}
+
+// Truncate removes all the elements from index size onwards, keeping the capacity.
+func (v *NVector[T]) Truncate(size int) {
+	if (size < 0) || (size > v.Size()) {
+		panic("ERROR: NVector.Truncate - size out of bounds")
+	}
+	v.data = v.data[:size]
+}
+
+// Assign replaces the elements of the NVector with copies of values, reusing its storage when it
+// is large enough. values may be a slice of the NVector's own Data.
+func (v *NVector[T]) Assign(values ...T) {
+	if slicesAlias(v.data, values) {
+		values = append([]T{}, values...)
+	}
+	v.data = v.data[:0]
+	values = v.reclaim(len(values), values)
+	v.data = insertSliceRange(v.data, 0, values, v.growth)
+}
+
 func (v *NVector[T]) PushBack(value T) {
-	v.data = append(v.data, value)
+	v.reclaim(len(v.data)+1, nil)
+	v.data = append(reserveSlice(v.data, len(v.data)+1, v.growth), value)
 }
 
 func (v *NVector[T]) PushBackRef(value *T) {
@@ -144,7 +241,8 @@ func (v *NVector[T]) PushBackRef(value *T) {
 }
 
 func (v *NVector[T]) PushFront(value T) {
-	v.data = append([]T{value}, v.data...)
+	v.reclaim(len(v.data)+1, nil)
+	v.data = insertSlice(v.data, 0, value, v.growth)
 }
 
 func (v *NVector[T]) PushFrontRef(value *T) {
@@ -159,9 +257,21 @@ func (v *NVector[T]) PopBack() {
 	}
 }
 
+// PopFront removes an element from the front of the NVector, moving all later elements one index
+// forward.
+//
+// The elements are not moved right away. The room left in front of them is reused once the
+// NVector needs it to grow, so it still counts towards Capacity.
 func (v *NVector[T]) PopFront() {
 	if !v.IsEmpty() {
+		if v.front == nil || !slicesAlias(v.front, v.data) {
+			v.front = v.data
+		}
 		v.data = v.data[1:]
+		if len(v.data) == 0 {
+			v.data = v.front[:0]
+			v.front = nil
+		}
 	} else {
 		panic("ERROR: NVector.PopFront - empty vector")
 	}
@@ -174,18 +284,64 @@ func (v *NVector[T]) Resize(new_size int) {
 	if new_size == v.Size() {
 		return
 	} else if new_size > v.Size() {
-		tmp := make([]T, new_size)
-		copy(tmp, v.data)
-		v.data = tmp
-	} else {
-		for v.Size() > new_size {
-			v.PopBack()
+		size := v.Size()
+		v.reclaim(new_size, nil)
+		v.data = reserveSlice(v.data, new_size, v.growth)[:new_size]
+		var zero T
+		for idx := size; idx < new_size; idx++ {
+			v.data[idx] = zero
 		}
+	} else {
+		v.data = v.data[:new_size]
+	}
+}
+
+// Reserve makes sure the NVector has room for at least capacity elements without allocating.
+func (v *NVector[T]) Reserve(capacity int) {
+	if capacity < 0 {
+		panic("ERROR: NVector.Reserve - negative capacity")
+	}
+	v.reclaim(capacity, nil)
+	v.data = reserveSliceExact(v.data, capacity)
+}
+
+// Capacity returns the number of elements the NVector can hold without allocating.
+func (v *NVector[T]) Capacity() int {
+	if v.front != nil && slicesAlias(v.front, v.data) {
+		return cap(v.front)
+	}
+	return cap(v.data)
+}
+
+// ShrinkToFit releases any spare capacity of the NVector.
+func (v *NVector[T]) ShrinkToFit() {
+	if v.Capacity() != cap(v.data) {
+		v.data = append(make([]T, 0, len(v.data)), v.data...)
 	}
+	v.front = nil
+	v.data = shrinkSlice(v.data)
+}
+
+// SetGrowthPolicy sets how the NVector grows when it runs out of capacity.
+func (v *NVector[T]) SetGrowthPolicy(policy GrowthPolicy) {
+	policy.validate("NVector.SetGrowthPolicy")
+	v.growth = policy
+}
+
+// GrowthPolicy returns how the NVector grows when it runs out of capacity.
+func (v *NVector[T]) GrowthPolicy() GrowthPolicy {
+	return v.growth
 }
 
 func (v *NVector[T]) Swap(other *NVector[T]) {
 	v.data, other.data = other.data, v.data
+	v.front, other.front = other.front, v.front
+}
+
+// Clone returns a new NVector with copies of the elements, that does not share storage with this
+// one.
+func (v *NVector[T]) Clone() NVector[T] {
+	return NVector[T]{data: append(make([]T, 0, len(v.data)), v.data...), growth: v.growth}
 }
 
 func (v *NVector[T]) String() string {
@@ -481,3 +637,44 @@ func (v *NVector[T]) RefSearchRef(value *T) (ret *T) {
 
 	return
 }
+
+func (v *NVector[T]) FindIf(predicate CollectionPredicate[T]) *T {
+	for idx := range v.data {
+		if predicate(&v.data[idx]) {
+			return &v.data[idx]
+		}
+	}
+	return nil
+}
+
+func (v *NVector[T]) FindLastIf(predicate CollectionPredicate[T]) *T {
+	for idx := v.Size() - 1; idx >= 0; idx-- {
+		if predicate(&v.data[idx]) {
+			return &v.data[idx]
+		}
+	}
+	return nil
+}
+
+func (v *NVector[T]) ContainsIf(predicate CollectionPredicate[T]) bool {
+	return v.IndexIf(predicate) >= 0
+}
+
+func (v *NVector[T]) CountIf(predicate CollectionPredicate[T]) int {
+	count := 0
+	for idx := range v.data {
+		if predicate(&v.data[idx]) {
+			count++
+		}
+	}
+	return count
+}
+
+func (v *NVector[T]) IndexIf(predicate CollectionPredicate[T]) int {
+	for idx := range v.data {
+		if predicate(&v.data[idx]) {
+			return idx
+		}
+	}
+	return -1
+}
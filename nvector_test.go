@@ -14,6 +14,17 @@ func TestIsNVectorGeneralCollector(t *testing.T) {
 	}
 }
 
+func TestIsNVectorPredicateSearcher(t *testing.T) {
+	vec := NewNVectorFromData[int64](1, 2, 3, 4, 5)
+
+	if _, isPredicateSearcher := interface{}(&vec).(PredicateSearcher[int64]); !isPredicateSearcher {
+		t.Fatalf("NVector[int64] should be a PredicateSearcher[int64]")
+	}
+	if c := vec.CountIf(func(v *int64) bool { return *v > 3 }); c != 2 {
+		t.Fatalf("CountIf should return 2, got %v", c)
+	}
+}
+
 const nVectorItemsCount = 50000000
 
 var nVectorSearchIndex int
@@ -119,3 +130,46 @@ func SubBenchmarkNVectorRefSearchRef(b *testing.B) {
 	// 	b.Logf("Did not find %v(%v)", *searched_for, searched_for)
 	// }
 }
+
+func TestNVectorRanges(t *testing.T) {
+	v := NewNVectorFromData(1, 2, 3)
+	v.InsertRange(3, 4, 5, 6)
+	v.InsertVector(0, NewNVectorFromData(-1, 0))
+	v.EraseRange(1, 3)
+	if v.String() != "{-1, 2, 3, 4, 5, 6}" {
+		t.Fatalf("NVector should be {-1, 2, 3, 4, 5, 6}, got %v", v.String())
+	}
+	if removed := v.EraseIf(func(value *int) bool { return *value > 4 }); removed != 2 {
+		t.Fatalf("EraseIf should remove 2 elements, got %v", removed)
+	}
+	v.Truncate(2)
+	v.AppendVector(NewNVectorFromData(9))
+	if v.String() != "{-1, 2, 9}" {
+		t.Fatalf("NVector should be {-1, 2, 9}, got %v", v.String())
+	}
+	v.Assign(v.Data()[1:]...)
+	if v.String() != "{2, 9}" {
+		t.Fatalf("Assign should replace the elements with {2, 9}, got %v", v.String())
+	}
+}
+
+func TestNVectorRangesCappedAlias(t *testing.T) {
+	v := NewNVectorFromData(1, 2, 3, 4)
+	v.Reserve(16)
+	v.InsertRange(0, v.Data()[1:3:3]...)
+	if v.String() != "{2, 3, 1, 2, 3, 4}" {
+		t.Fatalf("InsertRange of a capped slice of the NVector's own Data should work, got %v", v.String())
+	}
+	v.AppendVector(NewNVectorAdoptingSlice(v.Data()[0:2:2]))
+	if v.String() != "{2, 3, 1, 2, 3, 4, 2, 3}" {
+		t.Fatalf("AppendVector of an NVector adopting the NVector's own Data should work, got %v", v.String())
+	}
+	v.InsertVector(1, NewNVectorAdoptingSlice(v.Data()[4:6:6]))
+	if v.String() != "{2, 3, 4, 3, 1, 2, 3, 4, 2, 3}" {
+		t.Fatalf("InsertVector of an NVector adopting the NVector's own Data should work, got %v", v.String())
+	}
+	v.Assign(v.Data()[2:4:4]...)
+	if v.String() != "{4, 3}" {
+		t.Fatalf("Assign of a capped slice of the NVector's own Data should work, got %v", v.String())
+	}
+}
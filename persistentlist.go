@@ -0,0 +1,233 @@
+package gollect
+
+import (
+	"fmt"
+	"strings"
+)
+
+type persistentListNode[T any] struct {
+	value T
+	next  *persistentListNode[T]
+	size  int
+}
+
+// PersistentList is an immutable singly linked list. PushFront and PopFront return a new version
+// in O(1) time that shares the rest of the list with the old version, which stays valid.
+//
+// Versions are never modified, so they can be copied by value cheaply and read from any number of
+// goroutines without locking.
+type PersistentList[T any] struct {
+	head  *persistentListNode[T]
+	equal Equaler[T]
+}
+
+// NewPersistentList creates a new empty PersistentList, by value.
+func NewPersistentList[T any]() PersistentList[T] {
+	return PersistentList[T]{equal: resolveEqualer[T](nil)}
+}
+
+// NewPersistentListWithEqualer creates a new empty PersistentList that compares elements with
+// equal, by value.
+func NewPersistentListWithEqualer[T any](equal Equaler[T]) PersistentList[T] {
+	return PersistentList[T]{equal: resolveEqualer(equal)}
+}
+
+// NewPersistentListFromData creates a new PersistentList with copies of the elements in values,
+// in the same order, by value.
+func NewPersistentListFromData[T any](values ...T) PersistentList[T] {
+	l := NewPersistentList[T]()
+	for idx := len(values) - 1; idx >= 0; idx-- {
+		l = l.PushFront(values[idx])
+	}
+	return l
+}
+
+// NewPersistentListFromVector creates a new PersistentList with copies of the elements of a
+// Vector, in the same order, by value.
+func NewPersistentListFromVector[T any](other Vector[T]) PersistentList[T] {
+	l := NewPersistentListWithEqualer(other.equal)
+	for idx := len(other.data) - 1; idx >= 0; idx-- {
+		l = l.PushFront(other.data[idx])
+	}
+	return l
+}
+
+// MakePersistentList creates a new empty PersistentList instance.
+func MakePersistentList[T any]() *PersistentList[T] {
+	l := NewPersistentList[T]()
+	return &l
+}
+
+// MakePersistentListWithEqualer creates a new empty PersistentList instance that compares
+// elements with equal.
+func MakePersistentListWithEqualer[T any](equal Equaler[T]) *PersistentList[T] {
+	l := NewPersistentListWithEqualer(equal)
+	return &l
+}
+
+// MakePersistentListFromData creates a new PersistentList instance with copies of the elements in
+// values, in the same order.
+func MakePersistentListFromData[T any](values ...T) *PersistentList[T] {
+	l := NewPersistentListFromData(values...)
+	return &l
+}
+
+// MakePersistentListFromVector creates a new PersistentList instance with copies of the elements
+// of a Vector, in the same order.
+func MakePersistentListFromVector[T any](other Vector[T]) *PersistentList[T] {
+	l := NewPersistentListFromVector(other)
+	return &l
+}
+
+// Size returns the number of elements in the PersistentList.
+func (l PersistentList[T]) Size() int {
+	if l.head == nil {
+		return 0
+	}
+	return l.head.size
+}
+
+// IsEmpty returns true if the PersistentList is empty.
+func (l PersistentList[T]) IsEmpty() bool {
+	return l.head == nil
+}
+
+// Front gets the first element.
+func (l PersistentList[T]) Front() T {
+	if l.head == nil {
+		panic("ERROR: PersistentList.Front - empty list")
+	}
+	return l.head.value
+}
+
+// PushFront returns a new version with value added to the front.
+func (l PersistentList[T]) PushFront(value T) PersistentList[T] {
+	l.head = &persistentListNode[T]{value: value, next: l.head, size: l.Size() + 1}
+	return l
+}
+
+// PopFront returns a new version without the first element.
+func (l PersistentList[T]) PopFront() PersistentList[T] {
+	if l.head == nil {
+		panic("ERROR: PersistentList.PopFront - empty list")
+	}
+	l.head = l.head.next
+	return l
+}
+
+// Reverse returns a new version with the elements in reverse order.
+func (l PersistentList[T]) Reverse() PersistentList[T] {
+	result := PersistentList[T]{equal: l.equal}
+	for node := l.head; node != nil; node = node.next {
+		result = result.PushFront(node.value)
+	}
+	return result
+}
+
+// Visit calls a function for every element, from front to back.
+//
+// Note, the visitor receives a copy of each element, so modifying it has no effect on the
+// PersistentList.
+func (l PersistentList[T]) Visit(visitor CollectionVisitor[T]) {
+	break_out := false
+	for node := l.head; node != nil; node = node.next {
+		value := node.value
+		visitor(&value, &break_out)
+		if break_out {
+			break
+		}
+	}
+}
+
+// ToVector returns a Vector with copies of all the elements, from front to back.
+func (l PersistentList[T]) ToVector() Vector[T] {
+	result := Vector[T]{data: make([]T, 0, l.Size()), equal: l.equal}
+	for node := l.head; node != nil; node = node.next {
+		result.data = append(result.data, node.value)
+	}
+	return result
+}
+
+// Diff compares this version with newer, and returns the elements at the front of each that are
+// not part of their longest common tail.
+//
+// Tails shared between the versions are recognised without comparing their elements, so diffing
+// versions derived from each other takes time proportional to the changes between them. Without
+// an Equaler only shared tails count as common.
+func (l PersistentList[T]) Diff(newer PersistentList[T]) (removed Vector[T], added Vector[T]) {
+	a, b := l.head, newer.head
+	removed, added = NewVector[T](), NewVector[T]()
+	for ; l.sizeOf(a) > newer.sizeOf(b); a = a.next {
+		removed.PushBack(a.value)
+	}
+	for ; newer.sizeOf(b) > l.sizeOf(a); b = b.next {
+		added.PushBack(b.value)
+	}
+	// Walk both in step, remembering where the current run of equal elements began
+	common_a, common_b := a, b
+	for a != b {
+		if l.equal == nil || !l.equal(&a.value, &b.value) {
+			for ; common_a != a.next; common_a, common_b = common_a.next, common_b.next {
+				removed.PushBack(common_a.value)
+				added.PushBack(common_b.value)
+			}
+		}
+		a, b = a.next, b.next
+	}
+	return removed, added
+}
+
+func (l PersistentList[T]) sizeOf(node *persistentListNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+// Equal returns true if both versions have equal elements in the same order.
+//
+// Panics if the element type has no Equaler.
+func (l PersistentList[T]) Equal(other PersistentList[T]) bool {
+	if l.equal == nil {
+		panic("ERROR: PersistentList.Equal - no Equaler for element type")
+	}
+	if l.Size() != other.Size() {
+		return false
+	}
+	for a, b := l.head, other.head; a != b; a, b = a.next, b.next {
+		if !l.equal(&a.value, &b.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// NotEqual returns true if the versions have different elements or orders.
+func (l PersistentList[T]) NotEqual(other PersistentList[T]) bool {
+	return !l.Equal(other)
+}
+
+// DeepClone returns a new PersistentList with deep copies of the elements, that shares no nodes
+// with this one, see Vector.DeepClone.
+//
+// Versions are never modified, so copying one by value is enough unless the elements themselves
+// hold pointers, slices or maps that are modified elsewhere.
+func (l PersistentList[T]) DeepClone() PersistentList[T] {
+	values := l.ToVector()
+	return NewPersistentListFromVector(values.DeepClone())
+}
+
+// String returns a string representation of the PersistentList and it's contents.
+func (l PersistentList[T]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	for node := l.head; node != nil; node = node.next {
+		if node.next == nil {
+			fmt.Fprintf(&builder, "%v", node.value)
+		} else {
+			fmt.Fprintf(&builder, "%v, ", node.value)
+		}
+	}
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
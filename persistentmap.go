@@ -0,0 +1,534 @@
+package gollect
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+const (
+	persistentMapBits = 5
+	persistentMapMask = 1<<persistentMapBits - 1
+	// persistentMapMaxShift is the first shift with no hash bits left, where keys with the same
+	// hash are kept in a collision node
+	persistentMapMaxShift = 65
+)
+
+type persistentMapEntry[K any, V any] struct {
+	hash  uint64
+	key   K
+	value V
+}
+
+// persistentMapSlot holds either an entry or a child node.
+type persistentMapSlot[K any, V any] struct {
+	entry *persistentMapEntry[K, V]
+	child *persistentMapNode[K, V]
+}
+
+// persistentMapNode is a node of the hash array mapped trie. Only slots that are in use are
+// stored, and bitmap records which of the 32 possible slots they are. Past the last hash bits a
+// node stores colliding entries in collisions instead.
+type persistentMapNode[K any, V any] struct {
+	bitmap     uint32
+	slots      []persistentMapSlot[K, V]
+	collisions []*persistentMapEntry[K, V]
+}
+
+// persistentMapKeys is shared by every version derived from the same PersistentMap, which lets
+// Diff know that their tries have the same shape for the same keys.
+type persistentMapKeys[K any] struct {
+	hasher Hasher[K]
+}
+
+type persistentMapConfig[K any, V any] struct {
+	*persistentMapKeys[K]
+	equal Equaler[V]
+}
+
+func persistentMapFragment(hash uint64, shift uint) uint32 {
+	return uint32(1) << ((hash >> shift) & persistentMapMask)
+}
+
+func (node *persistentMapNode[K, V]) index(bit uint32) int {
+	return bits.OnesCount32(node.bitmap & (bit - 1))
+}
+
+// single returns the only entry of node, if it has exactly one entry and no children.
+func (node *persistentMapNode[K, V]) single() *persistentMapEntry[K, V] {
+	if len(node.collisions) == 1 {
+		return node.collisions[0]
+	}
+	if len(node.slots) == 1 && node.slots[0].entry != nil {
+		return node.slots[0].entry
+	}
+	return nil
+}
+
+func (node *persistentMapNode[K, V]) withSlot(index int, slot persistentMapSlot[K, V]) *persistentMapNode[K, V] {
+	result := &persistentMapNode[K, V]{bitmap: node.bitmap, slots: append([]persistentMapSlot[K, V]{}, node.slots...)}
+	result.slots[index] = slot
+	return result
+}
+
+func persistentMapMerge[K any, V any](shift uint, a *persistentMapEntry[K, V], b *persistentMapEntry[K, V]) *persistentMapNode[K, V] {
+	if shift >= persistentMapMaxShift {
+		return &persistentMapNode[K, V]{collisions: []*persistentMapEntry[K, V]{a, b}}
+	}
+	a_bit, b_bit := persistentMapFragment(a.hash, shift), persistentMapFragment(b.hash, shift)
+	if a_bit == b_bit {
+		return &persistentMapNode[K, V]{bitmap: a_bit, slots: []persistentMapSlot[K, V]{{child: persistentMapMerge(shift+persistentMapBits, a, b)}}}
+	}
+	if b_bit < a_bit {
+		a, b = b, a
+	}
+	return &persistentMapNode[K, V]{bitmap: a_bit | b_bit, slots: []persistentMapSlot[K, V]{{entry: a}, {entry: b}}}
+}
+
+func (c *persistentMapConfig[K, V]) find(node *persistentMapNode[K, V], hash uint64, key *K) *persistentMapEntry[K, V] {
+	for shift := uint(0); node != nil; shift += persistentMapBits {
+		if shift >= persistentMapMaxShift {
+			for _, entry := range node.collisions {
+				if c.hasher.Equal(&entry.key, key) {
+					return entry
+				}
+			}
+			return nil
+		}
+		bit := persistentMapFragment(hash, shift)
+		if node.bitmap&bit == 0 {
+			return nil
+		}
+		slot := node.slots[node.index(bit)]
+		if slot.entry != nil {
+			if slot.entry.hash == hash && c.hasher.Equal(&slot.entry.key, key) {
+				return slot.entry
+			}
+			return nil
+		}
+		node = slot.child
+	}
+	return nil
+}
+
+func (c *persistentMapConfig[K, V]) put(node *persistentMapNode[K, V], shift uint, entry *persistentMapEntry[K, V]) (result *persistentMapNode[K, V], added bool) {
+	if shift >= persistentMapMaxShift {
+		for idx, existing := range node.collisions {
+			if c.hasher.Equal(&existing.key, &entry.key) {
+				result = &persistentMapNode[K, V]{collisions: append([]*persistentMapEntry[K, V]{}, node.collisions...)}
+				result.collisions[idx] = entry
+				return result, false
+			}
+		}
+		return &persistentMapNode[K, V]{collisions: append(append([]*persistentMapEntry[K, V]{}, node.collisions...), entry)}, true
+	}
+	bit := persistentMapFragment(entry.hash, shift)
+	index := node.index(bit)
+	if node.bitmap&bit == 0 {
+		result = &persistentMapNode[K, V]{bitmap: node.bitmap | bit, slots: make([]persistentMapSlot[K, V], len(node.slots)+1)}
+		copy(result.slots, node.slots[:index])
+		result.slots[index] = persistentMapSlot[K, V]{entry: entry}
+		copy(result.slots[index+1:], node.slots[index:])
+		return result, true
+	}
+	slot := node.slots[index]
+	if slot.child != nil {
+		child, added := c.put(slot.child, shift+persistentMapBits, entry)
+		return node.withSlot(index, persistentMapSlot[K, V]{child: child}), added
+	}
+	if slot.entry.hash == entry.hash && c.hasher.Equal(&slot.entry.key, &entry.key) {
+		return node.withSlot(index, persistentMapSlot[K, V]{entry: entry}), false
+	}
+	return node.withSlot(index, persistentMapSlot[K, V]{child: persistentMapMerge(shift+persistentMapBits, slot.entry, entry)}), true
+}
+
+// erase returns node without key, or nil if that leaves it empty. Nodes left with a single entry
+// are collapsed into their parent, so the shape of the trie only depends on the keys.
+func (c *persistentMapConfig[K, V]) erase(node *persistentMapNode[K, V], shift uint, hash uint64, key *K) (result *persistentMapNode[K, V], erased bool) {
+	if shift >= persistentMapMaxShift {
+		for idx, existing := range node.collisions {
+			if c.hasher.Equal(&existing.key, key) {
+				if len(node.collisions) == 1 {
+					return nil, true
+				}
+				result = &persistentMapNode[K, V]{collisions: make([]*persistentMapEntry[K, V], 0, len(node.collisions)-1)}
+				result.collisions = append(append(result.collisions, node.collisions[:idx]...), node.collisions[idx+1:]...)
+				return result, true
+			}
+		}
+		return node, false
+	}
+	bit := persistentMapFragment(hash, shift)
+	if node.bitmap&bit == 0 {
+		return node, false
+	}
+	index := node.index(bit)
+	slot := node.slots[index]
+	if slot.child != nil {
+		child, erased := c.erase(slot.child, shift+persistentMapBits, hash, key)
+		if !erased {
+			return node, false
+		}
+		if child != nil {
+			if single := child.single(); single != nil {
+				return node.withSlot(index, persistentMapSlot[K, V]{entry: single}), true
+			}
+			return node.withSlot(index, persistentMapSlot[K, V]{child: child}), true
+		}
+	} else if slot.entry.hash != hash || !c.hasher.Equal(&slot.entry.key, key) {
+		return node, false
+	}
+	if len(node.slots) == 1 {
+		return nil, true
+	}
+	result = &persistentMapNode[K, V]{bitmap: node.bitmap &^ bit, slots: make([]persistentMapSlot[K, V], 0, len(node.slots)-1)}
+	result.slots = append(append(result.slots, node.slots[:index]...), node.slots[index+1:]...)
+	return result, true
+}
+
+// visit visits every entry in node, and returns true if the visitor broke out.
+func persistentMapVisit[K any, V any](node *persistentMapNode[K, V], visitor func(entry *persistentMapEntry[K, V]) bool) bool {
+	if node == nil {
+		return false
+	}
+	for _, entry := range node.collisions {
+		if visitor(entry) {
+			return true
+		}
+	}
+	for _, slot := range node.slots {
+		if slot.entry != nil {
+			if visitor(slot.entry) {
+				return true
+			}
+		} else if persistentMapVisit(slot.child, visitor) {
+			return true
+		}
+	}
+	return false
+}
+
+// PersistentMapDiffVisitor is called for every key that differs between two versions of a
+// PersistentMap. old_value is nil for an added key, and new_value is nil for a removed key.
+type PersistentMapDiffVisitor[K any, V any] func(key *K, old_value *V, new_value *V, break_out *bool)
+
+// PersistentMap is an immutable map. Every change returns a new version in O(log32 n) time,
+// sharing most of its storage with the old version, which stays valid.
+//
+// It is a hash array mapped trie, where each level uses 5 bits of the key's hash to choose one of
+// 32 children. Keys are hashed and compared with a Hasher, as in a HashMap. Versions are never
+// modified, so they can be copied by value cheaply and read from any number of goroutines
+// without locking.
+type PersistentMap[K any, V any] struct {
+	config *persistentMapConfig[K, V]
+	root   *persistentMapNode[K, V]
+	size   int
+}
+
+// NewPersistentMap creates a new empty PersistentMap, by value.
+//
+// Panics if there is no default Hasher for the key type.
+func NewPersistentMap[K any, V any]() PersistentMap[K, V] {
+	return NewPersistentMapWithHasher[K, V](nil)
+}
+
+// NewPersistentMapWithHasher creates a new empty PersistentMap that uses hasher for its keys, by
+// value.
+func NewPersistentMapWithHasher[K any, V any](hasher Hasher[K]) PersistentMap[K, V] {
+	hasher = resolveHasher(hasher)
+	if hasher == nil {
+		panic("ERROR: PersistentMap - no Hasher for key type")
+	}
+	return PersistentMap[K, V]{config: &persistentMapConfig[K, V]{persistentMapKeys: &persistentMapKeys[K]{hasher: hasher}, equal: resolveEqualer[V](nil)}}
+}
+
+// NewPersistentMapFromHashMap creates a new PersistentMap with the key/value pairs of a HashMap,
+// using the same Hasher, by value.
+func NewPersistentMapFromHashMap[K any, V any](other HashMap[K, V]) PersistentMap[K, V] {
+	m := NewPersistentMapWithHasher[K, V](other.hasher)
+	other.Visit(func(key *K, value *V, break_out *bool) {
+		m = m.Put(*key, *value)
+	})
+	return m
+}
+
+// MakePersistentMap creates a new empty PersistentMap instance.
+func MakePersistentMap[K any, V any]() *PersistentMap[K, V] {
+	m := NewPersistentMap[K, V]()
+	return &m
+}
+
+// MakePersistentMapWithHasher creates a new empty PersistentMap instance that uses hasher for its
+// keys.
+func MakePersistentMapWithHasher[K any, V any](hasher Hasher[K]) *PersistentMap[K, V] {
+	m := NewPersistentMapWithHasher[K, V](hasher)
+	return &m
+}
+
+// MakePersistentMapFromHashMap creates a new PersistentMap instance with the key/value pairs of a
+// HashMap, using the same Hasher.
+func MakePersistentMapFromHashMap[K any, V any](other HashMap[K, V]) *PersistentMap[K, V] {
+	m := NewPersistentMapFromHashMap(other)
+	return &m
+}
+
+// WithValueEqualer returns a version that compares values with equal in Equal and Diff.
+func (m PersistentMap[K, V]) WithValueEqualer(equal Equaler[V]) PersistentMap[K, V] {
+	m.config = &persistentMapConfig[K, V]{persistentMapKeys: m.config.persistentMapKeys, equal: equal}
+	return m
+}
+
+// Size returns the number of key/value pairs in the PersistentMap.
+func (m PersistentMap[K, V]) Size() int {
+	return m.size
+}
+
+// IsEmpty returns true if the PersistentMap is empty.
+func (m PersistentMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Get returns the value stored under key.
+func (m PersistentMap[K, V]) Get(key K) (value V, found bool) {
+	if entry := m.config.find(m.root, m.config.hasher.Hash(&key), &key); entry != nil {
+		return entry.value, true
+	}
+	return
+}
+
+// ContainsKey returns true if there is a value stored under key.
+func (m PersistentMap[K, V]) ContainsKey(key K) bool {
+	return m.config.find(m.root, m.config.hasher.Hash(&key), &key) != nil
+}
+
+// Put returns a new version with value stored under key.
+func (m PersistentMap[K, V]) Put(key K, value V) PersistentMap[K, V] {
+	entry := &persistentMapEntry[K, V]{hash: m.config.hasher.Hash(&key), key: key, value: value}
+	if m.root == nil {
+		m.root = &persistentMapNode[K, V]{}
+	}
+	var added bool
+	m.root, added = m.config.put(m.root, 0, entry)
+	if added {
+		m.size++
+	}
+	return m
+}
+
+// Erase returns a new version without key.
+func (m PersistentMap[K, V]) Erase(key K) PersistentMap[K, V] {
+	if m.root == nil {
+		return m
+	}
+	var erased bool
+	m.root, erased = m.config.erase(m.root, 0, m.config.hasher.Hash(&key), &key)
+	if erased {
+		m.size--
+	}
+	return m
+}
+
+// Visit calls a function for every key/value pair, in an unspecified but consistent order.
+//
+// Note, the visitor receives copies of the keys and values, so modifying them has no effect on
+// the PersistentMap.
+func (m PersistentMap[K, V]) Visit(visitor MapVisitor[K, V]) {
+	break_out := false
+	persistentMapVisit(m.root, func(entry *persistentMapEntry[K, V]) bool {
+		key, value := entry.key, entry.value
+		visitor(&key, &value, &break_out)
+		return break_out
+	})
+}
+
+// Keys returns a Vector of all the keys.
+func (m PersistentMap[K, V]) Keys() Vector[K] {
+	keys := Vector[K]{data: make([]K, 0, m.size)}
+	persistentMapVisit(m.root, func(entry *persistentMapEntry[K, V]) bool {
+		keys.data = append(keys.data, entry.key)
+		return false
+	})
+	return keys
+}
+
+// Values returns a Vector of all the values.
+func (m PersistentMap[K, V]) Values() Vector[V] {
+	values := Vector[V]{data: make([]V, 0, m.size)}
+	persistentMapVisit(m.root, func(entry *persistentMapEntry[K, V]) bool {
+		values.data = append(values.data, entry.value)
+		return false
+	})
+	return values
+}
+
+func (m PersistentMap[K, V]) valuesEqual(a *persistentMapEntry[K, V], b *persistentMapEntry[K, V]) bool {
+	return a == b || (m.config.equal != nil && m.config.equal(&a.value, &b.value))
+}
+
+// diffNodes reports the differences between two nodes at the same position of tries with the
+// same hasher, skipping shared subtrees. Returns true if the visitor broke out.
+func (m PersistentMap[K, V]) diffNodes(a *persistentMapNode[K, V], b *persistentMapNode[K, V], shift uint, visitor func(key *K, old *persistentMapEntry[K, V], new *persistentMapEntry[K, V]) bool) bool {
+	if a == b {
+		return false
+	}
+	if a == nil || b == nil || shift >= persistentMapMaxShift {
+		return m.diffSlow(a, b, visitor)
+	}
+	for all := a.bitmap | b.bitmap; all != 0; all &= all - 1 {
+		bit := all & -all
+		var a_slot, b_slot persistentMapSlot[K, V]
+		if a.bitmap&bit != 0 {
+			a_slot = a.slots[a.index(bit)]
+		}
+		if b.bitmap&bit != 0 {
+			b_slot = b.slots[b.index(bit)]
+		}
+		var stop bool
+		if a_slot.child != nil && b_slot.child != nil {
+			stop = m.diffNodes(a_slot.child, b_slot.child, shift+persistentMapBits, visitor)
+		} else if a_slot.entry != nil && a_slot.entry == b_slot.entry {
+			continue
+		} else {
+			stop = m.diffSlow(m.slotNode(a_slot), m.slotNode(b_slot), visitor)
+		}
+		if stop {
+			return true
+		}
+	}
+	return false
+}
+
+// slotNode returns a node holding the contents of slot, or nil if it is empty.
+func (m PersistentMap[K, V]) slotNode(slot persistentMapSlot[K, V]) *persistentMapNode[K, V] {
+	if slot.entry != nil {
+		return &persistentMapNode[K, V]{collisions: []*persistentMapEntry[K, V]{slot.entry}}
+	}
+	return slot.child
+}
+
+// diffSlow reports the differences between two small subtrees by looking up every key.
+func (m PersistentMap[K, V]) diffSlow(a *persistentMapNode[K, V], b *persistentMapNode[K, V], visitor func(key *K, old *persistentMapEntry[K, V], new *persistentMapEntry[K, V]) bool) bool {
+	b_entries := []*persistentMapEntry[K, V]{}
+	persistentMapVisit(b, func(entry *persistentMapEntry[K, V]) bool {
+		b_entries = append(b_entries, entry)
+		return false
+	})
+	matched := make([]bool, len(b_entries))
+	if persistentMapVisit(a, func(old *persistentMapEntry[K, V]) bool {
+		for idx, new := range b_entries {
+			if !matched[idx] && old.hash == new.hash && m.config.hasher.Equal(&old.key, &new.key) {
+				matched[idx] = true
+				return !m.valuesEqual(old, new) && visitor(&old.key, old, new)
+			}
+		}
+		return visitor(&old.key, old, nil)
+	}) {
+		return true
+	}
+	for idx, new := range b_entries {
+		if !matched[idx] && visitor(&new.key, nil, new) {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff calls a function for every key that was added, removed or changed between this version
+// and newer.
+//
+// Subtrees shared between the two versions are skipped, so diffing versions derived from each
+// other takes time proportional to the changes between them. Values are compared with the
+// Equaler of the value type if it has one, otherwise every value that was Put again is reported
+// as changed.
+func (m PersistentMap[K, V]) Diff(newer PersistentMap[K, V], visitor PersistentMapDiffVisitor[K, V]) {
+	break_out := false
+	report := func(key *K, old *persistentMapEntry[K, V], new *persistentMapEntry[K, V]) bool {
+		key_copy := *key
+		var old_value, new_value *V
+		if old != nil {
+			value := old.value
+			old_value = &value
+		}
+		if new != nil {
+			value := new.value
+			new_value = &value
+		}
+		visitor(&key_copy, old_value, new_value, &break_out)
+		return break_out
+	}
+	if m.config.persistentMapKeys == newer.config.persistentMapKeys {
+		m.diffNodes(m.root, newer.root, 0, report)
+		return
+	}
+	// The tries have different shapes, so look up every key in the other version instead
+	if persistentMapVisit(m.root, func(old *persistentMapEntry[K, V]) bool {
+		if new := m.config.find(newer.root, newer.config.hasher.Hash(&old.key), &old.key); new == nil {
+			return report(&old.key, old, nil)
+		} else if !m.valuesEqual(old, new) {
+			return report(&old.key, old, new)
+		}
+		return false
+	}) {
+		return
+	}
+	persistentMapVisit(newer.root, func(new *persistentMapEntry[K, V]) bool {
+		if m.config.find(m.root, m.config.hasher.Hash(&new.key), &new.key) == nil {
+			return report(&new.key, nil, new)
+		}
+		return false
+	})
+}
+
+// Equal returns true if both versions have the same keys, with equal values.
+//
+// Panics if the value type has no Equaler, and none was set with WithValueEqualer.
+func (m PersistentMap[K, V]) Equal(other PersistentMap[K, V]) bool {
+	if m.config.equal == nil {
+		panic("ERROR: PersistentMap.Equal - no Equaler for value type")
+	}
+	if m.size != other.size {
+		return false
+	}
+	equal := true
+	m.Diff(other, func(key *K, old_value *V, new_value *V, break_out *bool) {
+		equal = false
+		*break_out = true
+	})
+	return equal
+}
+
+// NotEqual returns true if the versions have different keys or values.
+func (m PersistentMap[K, V]) NotEqual(other PersistentMap[K, V]) bool {
+	return !m.Equal(other)
+}
+
+// DeepClone returns a new PersistentMap with deep copies of the values, that shares no nodes with
+// this one, see Vector.DeepClone.
+//
+// Versions are never modified, so copying one by value is enough unless the values themselves
+// hold pointers, slices or maps that are modified elsewhere. The keys are still copied by
+// assignment, as a deep copy of a key could hash differently.
+func (m PersistentMap[K, V]) DeepClone() PersistentMap[K, V] {
+	result := PersistentMap[K, V]{config: m.config}
+	m.Visit(func(key *K, value *V, break_out *bool) {
+		result = result.Put(*key, DeepCopy(*value))
+	})
+	return result
+}
+
+// String returns a string representation of the PersistentMap and it's contents.
+func (m PersistentMap[K, V]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	count := 0
+	m.Visit(func(key *K, value *V, break_out *bool) {
+		if count == m.size-1 {
+			fmt.Fprintf(&builder, "%v: %v", *key, *value)
+		} else {
+			fmt.Fprintf(&builder, "%v: %v, ", *key, *value)
+		}
+		count++
+	})
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
@@ -0,0 +1,155 @@
+package gollect
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPersistentMapVersions(t *testing.T) {
+	m0 := NewPersistentMap[string, int]()
+	m1 := m0.Put("a", 1).Put("b", 2)
+	m2 := m1.Put("a", 10).Erase("b").Put("c", 3)
+	if m0.Size() != 0 || m1.Size() != 2 || m2.Size() != 2 {
+		t.Fatalf("Sizes are wrong, got %v, %v, %v", m0.Size(), m1.Size(), m2.Size())
+	}
+	if v, _ := m1.Get("a"); v != 1 || !m1.ContainsKey("b") {
+		t.Fatalf("Old version should be unchanged, got %v", m1.String())
+	}
+	if v, _ := m2.Get("a"); v != 10 || m2.ContainsKey("b") {
+		t.Fatalf("New version is wrong, got %v", m2.String())
+	}
+	if m2.Erase("zzz").Size() != 2 {
+		t.Fatalf("Erasing a missing key should not change the size")
+	}
+}
+
+func TestPersistentMapCollisions(t *testing.T) {
+	hasher := NewFuncHasher(
+		func(value *int) uint64 { return uint64(*value % 3) },
+		func(left *int, right *int) bool { return *left == *right },
+	)
+	m := NewPersistentMapWithHasher[int, int](hasher)
+	for idx := 0; idx < 30; idx++ {
+		m = m.Put(idx, idx*idx)
+	}
+	for idx := 0; idx < 30; idx += 2 {
+		m = m.Erase(idx)
+	}
+	for idx := 0; idx < 30; idx++ {
+		value, found := m.Get(idx)
+		if found != (idx%2 == 1) || (found && value != idx*idx) {
+			t.Fatalf("Get(%v) returned %v, %v", idx, value, found)
+		}
+	}
+}
+
+func TestPersistentMapRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	m := NewPersistentMap[int, int]()
+	model := map[int]int{}
+	for step := 0; step < 20000; step++ {
+		key := rng.Intn(3000)
+		if rng.Intn(3) == 0 {
+			m = m.Erase(key)
+			delete(model, key)
+		} else {
+			m = m.Put(key, step)
+			model[key] = step
+		}
+	}
+	if m.Size() != len(model) {
+		t.Fatalf("Size should be %v, got %v", len(model), m.Size())
+	}
+	for key, expected := range model {
+		if value, _ := m.Get(key); value != expected {
+			t.Fatalf("Get(%v) should be %v, got %v", key, expected, value)
+		}
+	}
+
+	rebuilt := NewPersistentMap[int, int]()
+	for key, value := range model {
+		rebuilt = rebuilt.Put(key, value)
+	}
+	if !m.Equal(rebuilt) || m.NotEqual(rebuilt) {
+		t.Fatalf("Maps with the same contents should be equal")
+	}
+	keys := m.Keys()
+	if m.Equal(rebuilt.Put(-1, 0)) || m.Equal(rebuilt.Put(keys.At(0), -1)) {
+		t.Fatalf("Maps with different contents should not be equal")
+	}
+}
+
+func TestPersistentMapDiff(t *testing.T) {
+	old := NewPersistentMap[int, string]()
+	for idx := 0; idx < 1000; idx++ {
+		old = old.Put(idx, "x")
+	}
+	newer := old.Erase(5).Put(7, "y").Put(2000, "z").Put(8, "x")
+	added, removed, changed := 0, 0, 0
+	old.Diff(newer, func(key *int, old_value *string, new_value *string, break_out *bool) {
+		if old_value == nil {
+			added++
+			if *key != 2000 || *new_value != "z" {
+				t.Fatalf("Wrong key added, got %v", *key)
+			}
+		} else if new_value == nil {
+			removed++
+		} else {
+			changed++
+			if *key != 7 || *old_value != "x" || *new_value != "y" {
+				t.Fatalf("Wrong key changed, got %v", *key)
+			}
+		}
+	})
+	if added != 1 || removed != 1 || changed != 1 {
+		t.Fatalf("Diff should report 1 of each change, got %v added, %v removed, %v changed", added, removed, changed)
+	}
+
+	other := NewPersistentMap[int, string]().Put(1, "x")
+	count := 0
+	other.Diff(old, func(key *int, old_value *string, new_value *string, break_out *bool) {
+		count++
+		*break_out = count == 10
+	})
+	if count != 10 {
+		t.Fatalf("Diff should have stopped after 10 changes, got %v", count)
+	}
+}
+
+func TestPersistentMapNoEqualer(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: PersistentMap.Equal - no Equaler for value type" {
+			t.Fatalf("Should have panicked because there is no Equaler, got \"%v\"", result)
+		}
+	}()
+	m := NewPersistentMap[int, []int]()
+	m.Equal(m)
+}
+
+func TestPersistentList(t *testing.T) {
+	base := NewPersistentListFromData(3, 4, 5)
+	a := base.PushFront(2).PushFront(1)
+	b := base.PushFront(9)
+	if base.String() != "{3, 4, 5}" || a.String() != "{1, 2, 3, 4, 5}" || b.Front() != 9 {
+		t.Fatalf("Versions are wrong, got %v, %v, %v", base, a, b)
+	}
+	if a.PopFront().PopFront().head != base.head {
+		t.Fatalf("Versions should share their tails")
+	}
+	removed, added := a.Diff(b)
+	if removed.String() != "{1, 2}" || added.String() != "{9}" {
+		t.Fatalf("Diff should be {1, 2} and {9}, got %v and %v", removed.String(), added.String())
+	}
+	removed, added = NewPersistentListFromData(1, 2, 3, 4).Diff(NewPersistentListFromData(0, 5, 3, 4))
+	if removed.String() != "{1, 2}" || added.String() != "{0, 5}" {
+		t.Fatalf("Diff should compare unshared tails by value, got %v and %v", removed.String(), added.String())
+	}
+	if !a.Equal(NewPersistentListFromData(1, 2, 3, 4, 5)) || a.Equal(b) {
+		t.Fatalf("Equal returned the wrong result")
+	}
+	reversed := a.Reverse().ToVector()
+	if reversed.String() != "{5, 4, 3, 2, 1}" {
+		t.Fatalf("Reverse should be {5, 4, 3, 2, 1}, got %v", reversed.String())
+	}
+}
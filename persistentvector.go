@@ -0,0 +1,457 @@
+package gollect
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	persistentVectorBits  = 5
+	persistentVectorWidth = 1 << persistentVectorBits
+	persistentVectorMask  = persistentVectorWidth - 1
+)
+
+// persistentVectorOwner marks the nodes a TransientVector has created, and may therefore modify in
+// place.
+type persistentVectorOwner struct {
+	// Pointers to distinct zero-size values may compare equal, so this must not be zero-size
+	_ byte
+}
+
+type persistentVectorNode[T any] struct {
+	children []*persistentVectorNode[T]
+	values   []T
+	owner    *persistentVectorOwner
+}
+
+// editable returns node itself if it belongs to owner, otherwise a copy of node that does.
+// A nil owner always gets a copy.
+func (node *persistentVectorNode[T]) editable(owner *persistentVectorOwner) *persistentVectorNode[T] {
+	if owner != nil && node.owner == owner {
+		return node
+	}
+	copied := &persistentVectorNode[T]{owner: owner}
+	if node.children != nil {
+		copied.children = make([]*persistentVectorNode[T], len(node.children), persistentVectorWidth)
+		copy(copied.children, node.children)
+	}
+	if node.values != nil {
+		copied.values = make([]T, len(node.values), persistentVectorWidth)
+		copy(copied.values, node.values)
+	}
+	return copied
+}
+
+// persistentVectorData is the trie shared by PersistentVector and TransientVector.
+type persistentVectorData[T any] struct {
+	size  int
+	shift uint
+	root  *persistentVectorNode[T]
+	tail  []T
+}
+
+func newPersistentVectorData[T any]() persistentVectorData[T] {
+	return persistentVectorData[T]{shift: persistentVectorBits, root: &persistentVectorNode[T]{children: []*persistentVectorNode[T]{}}, tail: []T{}}
+}
+
+// tailOffset returns the index of the first element in the tail.
+func (d *persistentVectorData[T]) tailOffset() int {
+	if d.size < persistentVectorWidth {
+		return 0
+	}
+	return ((d.size - 1) >> persistentVectorBits) << persistentVectorBits
+}
+
+// leafFor returns the values of the leaf, or the tail, holding index.
+func (d *persistentVectorData[T]) leafFor(index int) []T {
+	if index >= d.tailOffset() {
+		return d.tail
+	}
+	node := d.root
+	for level := d.shift; level > 0; level -= persistentVectorBits {
+		node = node.children[(index>>level)&persistentVectorMask]
+	}
+	return node.values
+}
+
+func (d *persistentVectorData[T]) at(index int, method string) T {
+	if index < 0 || index >= d.size {
+		panic("ERROR: " + method + " - index out of range")
+	}
+	return d.leafFor(index)[index&persistentVectorMask]
+}
+
+func persistentVectorNewPath[T any](owner *persistentVectorOwner, level uint, node *persistentVectorNode[T]) *persistentVectorNode[T] {
+	if level == 0 {
+		return node
+	}
+	return &persistentVectorNode[T]{children: []*persistentVectorNode[T]{persistentVectorNewPath(owner, level-persistentVectorBits, node)}, owner: owner}
+}
+
+func (d *persistentVectorData[T]) pushTail(owner *persistentVectorOwner, level uint, parent *persistentVectorNode[T], tail_node *persistentVectorNode[T]) *persistentVectorNode[T] {
+	result := parent.editable(owner)
+	sub_index := ((d.size - 1) >> level) & persistentVectorMask
+	var inserted *persistentVectorNode[T]
+	if level == persistentVectorBits {
+		inserted = tail_node
+	} else if sub_index < len(parent.children) {
+		inserted = d.pushTail(owner, level-persistentVectorBits, parent.children[sub_index], tail_node)
+	} else {
+		inserted = persistentVectorNewPath(owner, level-persistentVectorBits, tail_node)
+	}
+	if sub_index < len(result.children) {
+		result.children[sub_index] = inserted
+	} else {
+		result.children = append(result.children, inserted)
+	}
+	return result
+}
+
+// pushBack appends value. The tail is only modified in place if owner is not nil.
+func (d *persistentVectorData[T]) pushBack(owner *persistentVectorOwner, value T) {
+	if d.size-d.tailOffset() < persistentVectorWidth {
+		if owner == nil {
+			tail := make([]T, len(d.tail)+1)
+			copy(tail, d.tail)
+			tail[len(d.tail)] = value
+			d.tail = tail
+		} else {
+			d.tail = append(d.tail, value)
+		}
+		d.size++
+		return
+	}
+	tail_node := &persistentVectorNode[T]{values: d.tail, owner: owner}
+	if (d.size >> persistentVectorBits) > (1 << d.shift) {
+		d.root = &persistentVectorNode[T]{
+			children: []*persistentVectorNode[T]{d.root, persistentVectorNewPath(owner, d.shift, tail_node)},
+			owner:    owner,
+		}
+		d.shift += persistentVectorBits
+	} else {
+		d.root = d.pushTail(owner, d.shift, d.root, tail_node)
+	}
+	if owner == nil {
+		d.tail = []T{value}
+	} else {
+		d.tail = make([]T, 1, persistentVectorWidth)
+		d.tail[0] = value
+	}
+	d.size++
+}
+
+func (d *persistentVectorData[T]) doSet(owner *persistentVectorOwner, level uint, node *persistentVectorNode[T], index int, value T) *persistentVectorNode[T] {
+	result := node.editable(owner)
+	if level == 0 {
+		result.values[index&persistentVectorMask] = value
+	} else {
+		sub_index := (index >> level) & persistentVectorMask
+		result.children[sub_index] = d.doSet(owner, level-persistentVectorBits, node.children[sub_index], index, value)
+	}
+	return result
+}
+
+// set replaces the element at index. The tail is only modified in place if owner is not nil.
+func (d *persistentVectorData[T]) set(owner *persistentVectorOwner, index int, value T, method string) {
+	if index < 0 || index >= d.size {
+		panic("ERROR: " + method + " - index out of range")
+	}
+	if index >= d.tailOffset() {
+		if owner == nil {
+			d.tail = append([]T{}, d.tail...)
+		}
+		d.tail[index&persistentVectorMask] = value
+		return
+	}
+	d.root = d.doSet(owner, d.shift, d.root, index, value)
+}
+
+func (d *persistentVectorData[T]) popTail(owner *persistentVectorOwner, level uint, node *persistentVectorNode[T]) *persistentVectorNode[T] {
+	sub_index := ((d.size - 2) >> level) & persistentVectorMask
+	if level > persistentVectorBits {
+		child := d.popTail(owner, level-persistentVectorBits, node.children[sub_index])
+		if child == nil && sub_index == 0 {
+			return nil
+		}
+		result := node.editable(owner)
+		if child == nil {
+			result.children = result.children[:sub_index]
+		} else {
+			result.children[sub_index] = child
+		}
+		return result
+	} else if sub_index == 0 {
+		return nil
+	}
+	result := node.editable(owner)
+	result.children = result.children[:sub_index]
+	return result
+}
+
+// popBack removes the last element. The tail is only modified in place if owner is not nil.
+func (d *persistentVectorData[T]) popBack(owner *persistentVectorOwner, method string) {
+	if d.size == 0 {
+		panic("ERROR: " + method + " - empty vector")
+	}
+	if d.size == 1 {
+		*d = newPersistentVectorData[T]()
+		return
+	}
+	if d.size-d.tailOffset() > 1 {
+		var zero T
+		if owner != nil {
+			// Clear the removed element, so it can be garbage collected
+			d.tail[len(d.tail)-1] = zero
+		}
+		d.tail = d.tail[:len(d.tail)-1]
+		d.size--
+		return
+	}
+	new_tail := d.leafFor(d.size - 2)
+	if owner != nil {
+		new_tail = append(make([]T, 0, persistentVectorWidth), new_tail...)
+	}
+	new_root := d.popTail(owner, d.shift, d.root)
+	if new_root == nil {
+		new_root = &persistentVectorNode[T]{children: []*persistentVectorNode[T]{}, owner: owner}
+	}
+	if d.shift > persistentVectorBits && len(new_root.children) == 1 {
+		new_root = new_root.children[0]
+		d.shift -= persistentVectorBits
+	}
+	d.root = new_root
+	d.tail = new_tail
+	d.size--
+}
+
+func (d *persistentVectorData[T]) visit(visitor CollectionVisitor[T]) {
+	break_out := false
+	for index := 0; index < d.size; index += persistentVectorWidth {
+		for _, val := range d.leafFor(index) {
+			visitor(&val, &break_out)
+			if break_out {
+				return
+			}
+		}
+	}
+}
+
+func (d *persistentVectorData[T]) string() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	first := true
+	d.visit(func(value *T, break_out *bool) {
+		if first {
+			fmt.Fprintf(&builder, "%v", *value)
+			first = false
+		} else {
+			fmt.Fprintf(&builder, ", %v", *value)
+		}
+	})
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
+
+// PersistentVector is an immutable indexed collection. Every change returns a new version in
+// O(log32 n) time, sharing most of its storage with the old version, which stays valid.
+//
+// It is a 32-way trie with the last (up to) 32 elements kept in a separate tail, so that
+// PushBack and PopBack are usually O(1). Because versions are never modified, they can be
+// copied by value cheaply and read from any number of goroutines without locking.
+//
+// Use a TransientVector to make many changes in a row without creating a version for each.
+type PersistentVector[T any] struct {
+	data persistentVectorData[T]
+}
+
+// NewPersistentVector creates a new empty PersistentVector, by value.
+func NewPersistentVector[T any]() PersistentVector[T] {
+	return PersistentVector[T]{data: newPersistentVectorData[T]()}
+}
+
+// NewPersistentVectorFromData creates a new PersistentVector with copies of the elements in
+// values, by value.
+func NewPersistentVectorFromData[T any](values ...T) PersistentVector[T] {
+	t := NewTransientVector[T]()
+	for _, val := range values {
+		t.PushBack(val)
+	}
+	return t.Persistent()
+}
+
+// NewPersistentVectorFromVector creates a new PersistentVector with copies of the elements of a
+// Vector, by value.
+func NewPersistentVectorFromVector[T any](other Vector[T]) PersistentVector[T] {
+	return NewPersistentVectorFromData(other.data...)
+}
+
+// MakePersistentVector creates a new empty PersistentVector instance.
+func MakePersistentVector[T any]() *PersistentVector[T] {
+	v := NewPersistentVector[T]()
+	return &v
+}
+
+// MakePersistentVectorFromData creates a new PersistentVector instance with copies of the
+// elements in values.
+func MakePersistentVectorFromData[T any](values ...T) *PersistentVector[T] {
+	v := NewPersistentVectorFromData(values...)
+	return &v
+}
+
+// MakePersistentVectorFromVector creates a new PersistentVector instance with copies of the
+// elements of a Vector.
+func MakePersistentVectorFromVector[T any](other Vector[T]) *PersistentVector[T] {
+	v := NewPersistentVectorFromVector(other)
+	return &v
+}
+
+// Size returns the number of elements in the PersistentVector.
+func (v PersistentVector[T]) Size() int {
+	return v.data.size
+}
+
+// IsEmpty returns true if the PersistentVector is empty.
+func (v PersistentVector[T]) IsEmpty() bool {
+	return v.data.size == 0
+}
+
+// At gets the element at index.
+func (v PersistentVector[T]) At(index int) T {
+	return v.data.at(index, "PersistentVector.At")
+}
+
+// Front gets the first element.
+func (v PersistentVector[T]) Front() T {
+	if v.data.size == 0 {
+		panic("ERROR: PersistentVector.Front - empty vector")
+	}
+	return v.data.at(0, "PersistentVector.Front")
+}
+
+// Back gets the last element.
+func (v PersistentVector[T]) Back() T {
+	if v.data.size == 0 {
+		panic("ERROR: PersistentVector.Back - empty vector")
+	}
+	return v.data.tail[len(v.data.tail)-1]
+}
+
+// Set returns a new version with the element at index replaced by value.
+func (v PersistentVector[T]) Set(index int, value T) PersistentVector[T] {
+	v.data.set(nil, index, value, "PersistentVector.Set")
+	return v
+}
+
+// PushBack returns a new version with value added to the end.
+func (v PersistentVector[T]) PushBack(value T) PersistentVector[T] {
+	v.data.pushBack(nil, value)
+	return v
+}
+
+// PopBack returns a new version with the last element removed.
+func (v PersistentVector[T]) PopBack() PersistentVector[T] {
+	v.data.popBack(nil, "PersistentVector.PopBack")
+	return v
+}
+
+// Transient returns a TransientVector starting with the elements of this version, which is not
+// affected by changes to the TransientVector.
+func (v PersistentVector[T]) Transient() *TransientVector[T] {
+	t := &TransientVector[T]{data: v.data, owner: &persistentVectorOwner{}}
+	// The tail is modified in place, so it must not be shared
+	t.data.tail = append(make([]T, 0, persistentVectorWidth), v.data.tail...)
+	return t
+}
+
+// Visit calls a function for every element, in order.
+//
+// Note, the visitor receives a copy of each element, so modifying it has no effect on the
+// PersistentVector.
+func (v PersistentVector[T]) Visit(visitor CollectionVisitor[T]) {
+	v.data.visit(visitor)
+}
+
+// ToVector returns a Vector with copies of all the elements.
+func (v PersistentVector[T]) ToVector() Vector[T] {
+	result := Vector[T]{data: make([]T, 0, v.data.size)}
+	v.data.visit(func(value *T, break_out *bool) {
+		result.data = append(result.data, *value)
+	})
+	return result
+}
+
+// DeepClone returns a new PersistentVector with deep copies of the elements, that shares no
+// storage with this one, see Vector.DeepClone.
+//
+// Versions are never modified, so copying one by value is enough unless the elements themselves
+// hold pointers, slices or maps that are modified elsewhere.
+func (v PersistentVector[T]) DeepClone() PersistentVector[T] {
+	values := v.ToVector()
+	return NewPersistentVectorFromVector(values.DeepClone())
+}
+
+// String returns a string representation of the PersistentVector and it's contents.
+func (v PersistentVector[T]) String() string {
+	return v.data.string()
+}
+
+// TransientVector is a mutable builder for a PersistentVector. It changes its own nodes in place
+// instead of copying them, so batches of changes are much faster than on a PersistentVector.
+//
+// A TransientVector must not be used from several goroutines at once, or after Persistent has
+// been called on it.
+type TransientVector[T any] struct {
+	data  persistentVectorData[T]
+	owner *persistentVectorOwner
+}
+
+// NewTransientVector creates a new empty TransientVector instance.
+func NewTransientVector[T any]() *TransientVector[T] {
+	return NewPersistentVector[T]().Transient()
+}
+
+func (t *TransientVector[T]) ensureEditable(method string) {
+	if t.owner == nil {
+		panic("ERROR: TransientVector." + method + " - used after Persistent")
+	}
+}
+
+// Size returns the number of elements in the TransientVector.
+func (t *TransientVector[T]) Size() int {
+	t.ensureEditable("Size")
+	return t.data.size
+}
+
+// At gets the element at index.
+func (t *TransientVector[T]) At(index int) T {
+	t.ensureEditable("At")
+	return t.data.at(index, "TransientVector.At")
+}
+
+// Set replaces the element at index with value.
+func (t *TransientVector[T]) Set(index int, value T) {
+	t.ensureEditable("Set")
+	t.data.set(t.owner, index, value, "TransientVector.Set")
+}
+
+// PushBack adds value to the end.
+func (t *TransientVector[T]) PushBack(value T) {
+	t.ensureEditable("PushBack")
+	t.data.pushBack(t.owner, value)
+}
+
+// PopBack removes the last element.
+func (t *TransientVector[T]) PopBack() {
+	t.ensureEditable("PopBack")
+	t.data.popBack(t.owner, "TransientVector.PopBack")
+}
+
+// Persistent returns a PersistentVector with the elements of the TransientVector, which must not
+// be used afterwards.
+func (t *TransientVector[T]) Persistent() PersistentVector[T] {
+	t.ensureEditable("Persistent")
+	t.owner = nil
+	data := t.data
+	data.tail = data.tail[:len(data.tail):len(data.tail)]
+	return PersistentVector[T]{data: data}
+}
@@ -0,0 +1,138 @@
+package gollect
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestPersistentVectorVersions(t *testing.T) {
+	v0 := NewPersistentVector[int]()
+	v1 := v0.PushBack(1)
+	v2 := v1.PushBack(2).PushBack(3)
+	v3 := v2.Set(0, 10)
+	v4 := v3.PopBack()
+	if v0.String() != "{}" || v1.String() != "{1}" || v2.String() != "{1, 2, 3}" {
+		t.Fatalf("Old versions should be unchanged, got %v, %v, %v", v0, v1, v2)
+	}
+	if v3.String() != "{10, 2, 3}" || v4.String() != "{10, 2}" || v4.Back() != 2 {
+		t.Fatalf("New versions are wrong, got %v, %v", v3, v4)
+	}
+}
+
+func TestPersistentVectorLarge(t *testing.T) {
+	rng := rand.New(rand.NewSource(41))
+	versions := []PersistentVector[int]{NewPersistentVector[int]()}
+	models := [][]int{{}}
+	for step := 0; step < 5000; step++ {
+		v := versions[len(versions)-1]
+		model := append([]int{}, models[len(models)-1]...)
+		switch op := rng.Intn(10); {
+		case op < 6 || len(model) == 0:
+			v = v.PushBack(step)
+			model = append(model, step)
+		case op < 8:
+			index := rng.Intn(len(model))
+			v = v.Set(index, -step)
+			model[index] = -step
+		default:
+			v = v.PopBack()
+			model = model[:len(model)-1]
+		}
+		versions = append(versions, v)
+		models = append(models, model)
+	}
+	for idx := 0; idx < len(versions); idx += 97 {
+		v, model := versions[idx], models[idx]
+		if v.Size() != len(model) {
+			t.Fatalf("Version %v should have %v elements, got %v", idx, len(model), v.Size())
+		}
+		for i, expected := range model {
+			if v.At(i) != expected {
+				t.Fatalf("Version %v should have %v at %v, got %v", idx, expected, i, v.At(i))
+			}
+		}
+	}
+}
+
+func TestPersistentVectorDeepPop(t *testing.T) {
+	v := NewPersistentVector[int]()
+	for idx := 0; idx < 33*32+5; idx++ {
+		v = v.PushBack(idx)
+	}
+	full := v
+	for v.Size() > 0 {
+		if v.Back() != v.Size()-1 {
+			t.Fatalf("Back should be %v, got %v", v.Size()-1, v.Back())
+		}
+		v = v.PopBack()
+	}
+	if full.Size() != 33*32+5 || full.At(1000) != 1000 {
+		t.Fatalf("Popping should not have changed the original")
+	}
+}
+
+func TestTransientVector(t *testing.T) {
+	base := NewPersistentVectorFromData(1, 2, 3)
+	tr := base.Transient()
+	for idx := 4; idx <= 100; idx++ {
+		tr.PushBack(idx)
+	}
+	tr.Set(0, 0)
+	tr.Set(50, -1)
+	tr.PopBack()
+	result := tr.Persistent()
+	if base.String() != "{1, 2, 3}" {
+		t.Fatalf("Transient should not modify its source, got %v", base)
+	}
+	if result.Size() != 99 || result.At(0) != 0 || result.At(50) != -1 || result.Back() != 99 {
+		t.Fatalf("Transient edits are wrong, got %v", result)
+	}
+	again := result.Transient()
+	again.Set(50, 50)
+	if result.At(50) != -1 {
+		t.Fatalf("A second Transient should not modify the first result")
+	}
+
+	defer func() {
+		r, _ := recover().(string)
+		if r != "ERROR: TransientVector.PushBack - used after Persistent" {
+			t.Fatalf("Should have panicked because used after Persistent, got \"%v\"", r)
+		}
+	}()
+	tr.PushBack(1)
+}
+
+func TestPersistentVectorConversion(t *testing.T) {
+	source := NewVectorFromData("a", "b", "c")
+	v := NewPersistentVectorFromVector(source)
+	source.data[0] = "z"
+	back := v.ToVector()
+	if back.String() != "{a, b, c}" {
+		t.Fatalf("PersistentVector should copy the Vector elements, got %v", back.String())
+	}
+}
+
+func TestPersistentVectorConcurrentReaders(t *testing.T) {
+	v := NewPersistentVector[int]()
+	for idx := 0; idx < 2000; idx++ {
+		v = v.PushBack(idx)
+	}
+	snapshot := v
+	var wg sync.WaitGroup
+	for reader := 0; reader < 4; reader++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sum := 0
+			snapshot.Visit(func(value *int, break_out *bool) { sum += *value })
+			if sum != 1999*2000/2 {
+				t.Errorf("Snapshot sum should be %v, got %v", 1999*2000/2, sum)
+			}
+		}()
+	}
+	for idx := 0; idx < 2000; idx++ {
+		v = v.Set(idx, 0)
+	}
+	wg.Wait()
+}
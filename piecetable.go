@@ -0,0 +1,657 @@
+package gollect
+
+import (
+	"fmt"
+	"strings"
+)
+
+// pieceTablePiece is a span of one of the buffers of a PieceTable.
+type pieceTablePiece struct {
+	added  bool
+	start  int
+	length int
+}
+
+// pieceTableState is a version of the piece list of a PieceTable. Piece lists are never
+// modified once built, so versions can share them.
+type pieceTableState struct {
+	pieces []pieceTablePiece
+	size   int
+}
+
+// splitPieces returns a copy of pieces in which a piece starts at index, and the position of
+// that piece, which is len(pieces) if index is at the end.
+func splitPieces(pieces []pieceTablePiece, index int) ([]pieceTablePiece, int) {
+	ret := make([]pieceTablePiece, 0, len(pieces)+2)
+	position := -1
+	for _, piece := range pieces {
+		if position < 0 {
+			if index < piece.length {
+				if index > 0 {
+					ret = append(ret, pieceTablePiece{added: piece.added, start: piece.start, length: index})
+					piece.start += index
+					piece.length -= index
+				}
+				position = len(ret)
+			} else {
+				index -= piece.length
+			}
+		}
+		ret = append(ret, piece)
+	}
+	if position < 0 {
+		position = len(ret)
+	}
+	return ret, position
+}
+
+// PieceTable is a general-purpose sequence made for editors that need undo.
+//
+// The elements live in two buffers that are only ever appended to: the original elements, and
+// every element added since. The sequence itself is a list of pieces, each referring to a span of
+// one of the buffers, so an edit only changes the piece list, and a previous version can be
+// restored in O(1) time with Undo.
+//
+// Every edit keeps the previous piece list for Undo, and there is no limit on the number of edits
+// kept unless one is set with SetHistoryLimit.
+//
+// Because erased elements may be restored by Undo, they stay in the buffers, and Erase does not
+// call Destruct on them. Elements that implement the Destructible interface are destructed by
+// Clear, and by Compact if they are no longer part of the sequence.
+type PieceTable[T any] struct {
+	original      []T
+	added         []T
+	state         pieceTableState
+	undo          []pieceTableState
+	redo          []pieceTableState
+	equal         Equaler[T]
+	history_limit int
+	limit_history bool
+}
+
+// NewPieceTable creates a new empty PieceTable, by value.
+func NewPieceTable[T any]() PieceTable[T] {
+	return PieceTable[T]{}
+}
+
+// NewPieceTableFromData creates a new PieceTable whose original buffer holds a copy of values,
+// by value.
+func NewPieceTableFromData[T any](values ...T) PieceTable[T] {
+	p := PieceTable[T]{original: append([]T{}, values...)}
+	if len(values) > 0 {
+		p.state = pieceTableState{pieces: []pieceTablePiece{{start: 0, length: len(values)}}, size: len(values)}
+	}
+	return p
+}
+
+// NewPieceTableFromPieceTable creates a new PieceTable holding a copy of the buffers of other,
+// by value. The copy shares the undo history of other, but later edits to one of them do not
+// affect the other.
+func NewPieceTableFromPieceTable[T any](other PieceTable[T]) PieceTable[T] {
+	return PieceTable[T]{
+		original:      append([]T{}, other.original...),
+		added:         append([]T{}, other.added...),
+		state:         other.state,
+		undo:          append([]pieceTableState{}, other.undo...),
+		redo:          append([]pieceTableState{}, other.redo...),
+		equal:         other.equal,
+		history_limit: other.history_limit,
+		limit_history: other.limit_history,
+	}
+}
+
+// NewPieceTableWithEqualer creates a new PieceTable holding a copy of values, by value, that
+// uses equal in value-based searches.
+func NewPieceTableWithEqualer[T any](equal Equaler[T], values ...T) PieceTable[T] {
+	p := NewPieceTableFromData(values...)
+	p.equal = equal
+	return p
+}
+
+// MakePieceTable creates a new empty PieceTable instance.
+func MakePieceTable[T any]() *PieceTable[T] {
+	return &PieceTable[T]{}
+}
+
+// MakePieceTableFromData creates a new PieceTable instance whose original buffer holds a copy
+// of values.
+func MakePieceTableFromData[T any](values ...T) *PieceTable[T] {
+	p := NewPieceTableFromData(values...)
+	return &p
+}
+
+// MakePieceTableFromPieceTable creates a new PieceTable instance holding a copy of the buffers
+// of other.
+func MakePieceTableFromPieceTable[T any](other PieceTable[T]) *PieceTable[T] {
+	p := NewPieceTableFromPieceTable(other)
+	return &p
+}
+
+// MakePieceTableWithEqualer creates a new PieceTable instance holding a copy of values, that
+// uses equal in value-based searches.
+func MakePieceTableWithEqualer[T any](equal Equaler[T], values ...T) *PieceTable[T] {
+	p := NewPieceTableWithEqualer(equal, values...)
+	return &p
+}
+
+// SetEqualer sets the function used to compare elements in value-based searches.
+//
+// If equal is nil, the PieceTable falls back to the EqualityComparable implementation of its
+// element type, or to `==` if the element type is comparable.
+func (p *PieceTable[T]) SetEqualer(equal Equaler[T]) {
+	p.equal = equal
+}
+
+func (p *PieceTable[T]) equaler(method string) Equaler[T] {
+	equal := resolveEqualer(p.equal)
+	if equal == nil {
+		panic("ERROR: PieceTable." + method + " - no Equaler for element type")
+	}
+	return equal
+}
+
+func (p *PieceTable[T]) buffer(piece pieceTablePiece) []T {
+	if piece.added {
+		return p.added[piece.start : piece.start+piece.length]
+	}
+	return p.original[piece.start : piece.start+piece.length]
+}
+
+// edit makes state the current version, recording the previous one for Undo.
+func (p *PieceTable[T]) edit(state pieceTableState) {
+	p.undo = append(p.undo, p.state)
+	p.redo = nil
+	p.state = state
+	p.trimHistory()
+}
+
+// trimHistory drops the oldest versions recorded for Undo, until there are no more than the
+// history limit.
+func (p *PieceTable[T]) trimHistory() {
+	if !p.limit_history || len(p.undo) <= p.history_limit {
+		return
+	}
+	excess := len(p.undo) - p.history_limit
+	copy(p.undo, p.undo[excess:])
+	for idx := len(p.undo) - excess; idx < len(p.undo); idx++ {
+		p.undo[idx] = pieceTableState{}
+	}
+	p.undo = p.undo[:len(p.undo)-excess]
+}
+
+// SetHistoryLimit sets the number of edits kept for Undo, dropping the oldest ones if there are
+// more. A limit of 0 turns Undo off, and a negative limit keeps every edit, which is the default.
+//
+// Note, the limit only bounds the piece lists kept for Undo. Erased elements stay in the buffers
+// until Compact or Clear.
+func (p *PieceTable[T]) SetHistoryLimit(limit int) {
+	p.history_limit = limit
+	p.limit_history = limit >= 0
+	p.trimHistory()
+}
+
+// HistoryLimit returns the number of edits kept for Undo, or -1 if every edit is kept.
+func (p *PieceTable[T]) HistoryLimit() int {
+	if !p.limit_history {
+		return -1
+	}
+	return p.history_limit
+}
+
+// Size returns the number of elements in the PieceTable.
+func (p *PieceTable[T]) Size() int {
+	return p.state.size
+}
+
+// IsEmpty returns true if the PieceTable is empty.
+func (p *PieceTable[T]) IsEmpty() bool {
+	return p.state.size == 0
+}
+
+// PieceCount returns the number of pieces the sequence is made of.
+func (p *PieceTable[T]) PieceCount() int {
+	return len(p.state.pieces)
+}
+
+// At gets the element at index by value.
+func (p *PieceTable[T]) At(index int) T {
+	return *p.AtRef(index)
+}
+
+// AtRef gets a pointer to the element at index, which takes time proportional to the number of
+// pieces.
+//
+// Note, the element is shared by every version of the PieceTable that contains it, so changes
+// through the pointer are not undone by Undo. The pointer becomes stale when an insertion
+// reallocates the buffer of added elements, or after Compact or Clear, so it should not be kept
+// across edits.
+func (p *PieceTable[T]) AtRef(index int) *T {
+	if index < 0 || index >= p.state.size {
+		panic("ERROR: PieceTable.AtRef - index out of range")
+	}
+	for _, piece := range p.state.pieces {
+		if index < piece.length {
+			return &p.buffer(piece)[index]
+		}
+		index -= piece.length
+	}
+	return nil
+}
+
+// Front gets the element at the front of the PieceTable by value.
+func (p *PieceTable[T]) Front() T {
+	return *p.FrontRef()
+}
+
+// FrontRef gets a pointer to the element at the front of the PieceTable, see AtRef.
+func (p *PieceTable[T]) FrontRef() *T {
+	if p.IsEmpty() {
+		panic("ERROR: PieceTable.FrontRef - empty piece table")
+	}
+	return &p.buffer(p.state.pieces[0])[0]
+}
+
+// Back gets the element at the back of the PieceTable by value.
+func (p *PieceTable[T]) Back() T {
+	return *p.BackRef()
+}
+
+// BackRef gets a pointer to the element at the back of the PieceTable, see AtRef.
+func (p *PieceTable[T]) BackRef() *T {
+	if p.IsEmpty() {
+		panic("ERROR: PieceTable.BackRef - empty piece table")
+	}
+	piece := p.state.pieces[len(p.state.pieces)-1]
+	return &p.buffer(piece)[piece.length-1]
+}
+
+// InsertRange adds elements at the specified index, moving all later elements further back, as
+// a single edit.
+func (p *PieceTable[T]) InsertRange(index int, values ...T) {
+	if index < 0 || index > p.state.size {
+		panic("ERROR: PieceTable.InsertRange - index out of bounds")
+	}
+	if len(values) == 0 {
+		return
+	}
+	start := len(p.added)
+	p.added = append(p.added, values...)
+	pieces, position := splitPieces(p.state.pieces, index)
+	if position > 0 && pieces[position-1].added && pieces[position-1].start+pieces[position-1].length == start {
+		// Typing at the end of the last insertion extends its piece
+		pieces[position-1].length += len(values)
+	} else {
+		pieces = append(pieces, pieceTablePiece{})
+		copy(pieces[position+1:], pieces[position:])
+		pieces[position] = pieceTablePiece{added: true, start: start, length: len(values)}
+	}
+	p.edit(pieceTableState{pieces: pieces, size: p.state.size + len(values)})
+}
+
+// Insert adds an element at the specified index, moving all later elements one further index back.
+func (p *PieceTable[T]) Insert(index int, value T) {
+	if index < 0 || index > p.state.size {
+		panic("ERROR: PieceTable.Insert - index out of bounds")
+	}
+	p.InsertRange(index, value)
+}
+
+// InsertRef adds an element at the specified index, moving all later elements one further index back.
+func (p *PieceTable[T]) InsertRef(index int, value *T) {
+	if index < 0 || index > p.state.size {
+		panic("ERROR: PieceTable.InsertRef - index out of bounds")
+	}
+	p.InsertRange(index, *value)
+}
+
+// EraseRange removes the elements in the range [from, to), as a single edit.
+func (p *PieceTable[T]) EraseRange(from int, to int) {
+	if from < 0 || to > p.state.size || from > to {
+		panic("ERROR: PieceTable.EraseRange - range out of bounds")
+	}
+	if from == to {
+		return
+	}
+	pieces, first := splitPieces(p.state.pieces, from)
+	pieces, last := splitPieces(pieces, to)
+	pieces = append(pieces[:first], pieces[last:]...)
+	p.edit(pieceTableState{pieces: pieces, size: p.state.size - (to - from)})
+}
+
+// Erase removes an element at the specified index, moving all later elements one index forward.
+func (p *PieceTable[T]) Erase(index int) {
+	if p.IsEmpty() {
+		panic("ERROR: PieceTable.Erase - empty piece table")
+	}
+	if index < 0 || index >= p.state.size {
+		panic("ERROR: PieceTable.Erase - index out of bounds")
+	}
+	p.EraseRange(index, index+1)
+}
+
+// PushBack adds an element to the back of the PieceTable.
+func (p *PieceTable[T]) PushBack(value T) {
+	p.InsertRange(p.state.size, value)
+}
+
+// PushBackRef adds an element to the back of the PieceTable.
+func (p *PieceTable[T]) PushBackRef(value *T) {
+	p.InsertRange(p.state.size, *value)
+}
+
+// PushFront adds an element to the front of the PieceTable.
+func (p *PieceTable[T]) PushFront(value T) {
+	p.InsertRange(0, value)
+}
+
+// PushFrontRef adds an element to the front of the PieceTable.
+func (p *PieceTable[T]) PushFrontRef(value *T) {
+	p.InsertRange(0, *value)
+}
+
+// PopBack removes an element from the back of the PieceTable.
+func (p *PieceTable[T]) PopBack() {
+	if p.IsEmpty() {
+		panic("ERROR: PieceTable.PopBack - empty piece table")
+	}
+	p.EraseRange(p.state.size-1, p.state.size)
+}
+
+// PopFront removes an element from the front of the PieceTable.
+func (p *PieceTable[T]) PopFront() {
+	if p.IsEmpty() {
+		panic("ERROR: PieceTable.PopFront - empty piece table")
+	}
+	p.EraseRange(0, 1)
+}
+
+// CanUndo returns true if there is an edit to undo.
+func (p *PieceTable[T]) CanUndo() bool {
+	return len(p.undo) > 0
+}
+
+// CanRedo returns true if there is an undone edit to redo.
+func (p *PieceTable[T]) CanRedo() bool {
+	return len(p.redo) > 0
+}
+
+// Undo reverts the last edit in O(1) time, and returns false if there was none.
+func (p *PieceTable[T]) Undo() bool {
+	if len(p.undo) == 0 {
+		return false
+	}
+	p.redo = append(p.redo, p.state)
+	p.state = p.undo[len(p.undo)-1]
+	p.undo = p.undo[:len(p.undo)-1]
+	return true
+}
+
+// Redo reapplies the last undone edit in O(1) time, and returns false if there was none. Any
+// edit after an Undo discards the edits that could be redone.
+func (p *PieceTable[T]) Redo() bool {
+	if len(p.redo) == 0 {
+		return false
+	}
+	p.undo = append(p.undo, p.state)
+	p.state = p.redo[len(p.redo)-1]
+	p.redo = p.redo[:len(p.redo)-1]
+	return true
+}
+
+// Compact copies the current sequence into a new original buffer made of a single piece, and
+// discards the undo history.
+//
+// If the elements that are no longer part of the sequence implement the Destructible interface,
+// they will have the Destruct method called on them.
+func (p *PieceTable[T]) Compact() {
+	original := make([]T, 0, p.state.size)
+	live_original := make([]bool, len(p.original))
+	live_added := make([]bool, len(p.added))
+	for _, piece := range p.state.pieces {
+		original = append(original, p.buffer(piece)...)
+		live := live_original
+		if piece.added {
+			live = live_added
+		}
+		for idx := piece.start; idx < piece.start+piece.length; idx++ {
+			live[idx] = true
+		}
+	}
+	destructUnless(p.original, live_original)
+	destructUnless(p.added, live_added)
+	p.reset()
+	p.original = original
+	if len(original) > 0 {
+		p.state = pieceTableState{pieces: []pieceTablePiece{{start: 0, length: len(original)}}, size: len(original)}
+	}
+}
+
+// destructUnless calls Destruct on every Destructible element of values that is not marked live.
+func destructUnless[T any](values []T, live []bool) {
+	for idx := range values {
+		if live == nil || !live[idx] {
+			if e, isDestructible := interface{}(&values[idx]).(Destructible); isDestructible {
+				e.Destruct()
+			}
+		}
+	}
+}
+
+// reset makes the PieceTable empty and discards the undo history, keeping its Equaler and history
+// limit.
+func (p *PieceTable[T]) reset() {
+	*p = PieceTable[T]{equal: p.equal, history_limit: p.history_limit, limit_history: p.limit_history}
+}
+
+// Clear removes all the elements from the PieceTable, and discards the undo history.
+//
+// If the elements implement the Destructible interface, then they will have the Destruct method
+// called on them, including erased elements that were kept for Undo.
+func (p *PieceTable[T]) Clear() {
+	destructUnless(p.original, nil)
+	destructUnless(p.added, nil)
+	p.reset()
+}
+
+// Swap swaps the data of two PieceTables.
+func (p *PieceTable[T]) Swap(other *PieceTable[T]) {
+	*p, *other = *other, *p
+}
+
+// Clone returns a new PieceTable with copies of the elements and the same undo history, that does
+// not share storage with this one.
+//
+// Elements are copied by assignment, so pointers, slices and maps inside them are still shared.
+// Use DeepClone to copy those too.
+func (p *PieceTable[T]) Clone() PieceTable[T] {
+	return NewPieceTableFromPieceTable(*p)
+}
+
+// DeepClone returns a new PieceTable with deep copies of the elements, including the ones only
+// reachable through the undo history, see Vector.DeepClone.
+func (p *PieceTable[T]) DeepClone() PieceTable[T] {
+	result := p.Clone()
+	for idx := range result.original {
+		result.original[idx] = DeepCopy(result.original[idx])
+	}
+	for idx := range result.added {
+		result.added[idx] = DeepCopy(result.added[idx])
+	}
+	return result
+}
+
+// Visit calls a function for every element in the PieceTable.
+func (p *PieceTable[T]) Visit(visitor CollectionVisitor[T]) {
+	break_out := false
+	for _, piece := range p.state.pieces {
+		values := p.buffer(piece)
+		for idx := 0; !break_out && idx < len(values); idx++ {
+			visitor(&values[idx], &break_out)
+		}
+		if break_out {
+			return
+		}
+	}
+}
+
+// VisitReverse calls a function for every element in the PieceTable in reverse order.
+func (p *PieceTable[T]) VisitReverse(visitor CollectionVisitor[T]) {
+	break_out := false
+	for piece_idx := len(p.state.pieces) - 1; piece_idx >= 0; piece_idx-- {
+		values := p.buffer(p.state.pieces[piece_idx])
+		for idx := len(values) - 1; !break_out && idx >= 0; idx-- {
+			visitor(&values[idx], &break_out)
+		}
+		if break_out {
+			return
+		}
+	}
+}
+
+func (p *PieceTable[T]) indexWhere(predicate CollectionPredicate[T]) (found bool, index int, ref *T) {
+	index = 0
+	p.Visit(func(vv *T, break_out *bool) {
+		if predicate(vv) {
+			found = true
+			ref = vv
+			*break_out = true
+		} else {
+			index++
+		}
+	})
+	if !found {
+		index = -1
+	}
+	return
+}
+
+// ContainsValue returns true if the PieceTable contains value.
+//
+// Panics if the PieceTable has no Equaler for its element type.
+func (p *PieceTable[T]) ContainsValue(value T) bool {
+	return p.OrderedSearchRef(value) != nil
+}
+
+// ContainsRef returns true if the PieceTable contains the exact reference of value.
+func (p *PieceTable[T]) ContainsRef(value *T) bool {
+	return p.OrderedRefSearchRef(value) != nil
+}
+
+// OrderedSearch searches for a value, and returns the index to the first match.
+//
+// Panics if the PieceTable has no Equaler for its element type.
+func (p *PieceTable[T]) OrderedSearch(value T) (found bool, index int) {
+	equal := p.equaler("OrderedSearch")
+	found, index, _ = p.indexWhere(func(vv *T) bool { return equal(vv, &value) })
+	return
+}
+
+// OrderedRefSearch searches for an instance, and returns the index to the first match.
+func (p *PieceTable[T]) OrderedRefSearch(value *T) (found bool, index int) {
+	found, index, _ = p.indexWhere(func(vv *T) bool { return vv == value })
+	return
+}
+
+// OrderedSearchRef searches for a value, and returns a pointer to the first match.
+//
+// Panics if the PieceTable has no Equaler for its element type.
+func (p *PieceTable[T]) OrderedSearchRef(value T) *T {
+	equal := p.equaler("OrderedSearchRef")
+	_, _, ref := p.indexWhere(func(vv *T) bool { return equal(vv, &value) })
+	return ref
+}
+
+// OrderedRefSearchRef searches for an instance, and returns a pointer to the first match.
+func (p *PieceTable[T]) OrderedRefSearchRef(value *T) *T {
+	_, _, ref := p.indexWhere(func(vv *T) bool { return vv == value })
+	return ref
+}
+
+// Search searches for a value, and returns an index to a match.
+//
+// Panics if the PieceTable has no Equaler for its element type.
+func (p *PieceTable[T]) Search(value T) (found bool, index int) {
+	return p.OrderedSearch(value)
+}
+
+// RefSearch searches for an instance, and returns an index to a match.
+func (p *PieceTable[T]) RefSearch(value *T) (found bool, index int) {
+	return p.OrderedRefSearch(value)
+}
+
+// SearchRef searches for a value, and returns a pointer to a match.
+//
+// Panics if the PieceTable has no Equaler for its element type.
+func (p *PieceTable[T]) SearchRef(value T) *T {
+	return p.OrderedSearchRef(value)
+}
+
+// RefSearchRef searches for an instance, and returns a pointer to a match.
+func (p *PieceTable[T]) RefSearchRef(value *T) *T {
+	return p.OrderedRefSearchRef(value)
+}
+
+// FindIf returns a pointer to the first element that satisfies predicate, or nil if there is none.
+func (p *PieceTable[T]) FindIf(predicate CollectionPredicate[T]) *T {
+	_, _, ref := p.indexWhere(predicate)
+	return ref
+}
+
+// FindLastIf returns a pointer to the last element that satisfies predicate, or nil if there is none.
+func (p *PieceTable[T]) FindLastIf(predicate CollectionPredicate[T]) *T {
+	var ret *T = nil
+	p.VisitReverse(func(vv *T, break_out *bool) {
+		if predicate(vv) {
+			ret = vv
+			*break_out = true
+		}
+	})
+	return ret
+}
+
+// ContainsIf returns true if any element satisfies predicate.
+func (p *PieceTable[T]) ContainsIf(predicate CollectionPredicate[T]) bool {
+	return p.FindIf(predicate) != nil
+}
+
+// CountIf returns the number of elements that satisfy predicate.
+func (p *PieceTable[T]) CountIf(predicate CollectionPredicate[T]) int {
+	count := 0
+	p.Visit(func(vv *T, break_out *bool) {
+		if predicate(vv) {
+			count++
+		}
+	})
+	return count
+}
+
+// IndexIf returns the index of the first element that satisfies predicate, or -1 if there is none.
+func (p *PieceTable[T]) IndexIf(predicate CollectionPredicate[T]) int {
+	_, index, _ := p.indexWhere(predicate)
+	return index
+}
+
+// ToVector returns a Vector holding a copy of the elements of the PieceTable.
+func (p *PieceTable[T]) ToVector() Vector[T] {
+	data := make([]T, 0, p.state.size)
+	for _, piece := range p.state.pieces {
+		data = append(data, p.buffer(piece)...)
+	}
+	return Vector[T]{data: data, equal: p.equal}
+}
+
+// String returns a string representation of the PieceTable and it's contents.
+func (p *PieceTable[T]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	first := true
+	p.Visit(func(value *T, break_out *bool) {
+		if !first {
+			fmt.Fprintf(&builder, ", ")
+		}
+		first = false
+		fmt.Fprintf(&builder, "%v", *value)
+	})
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
@@ -0,0 +1,133 @@
+package gollect
+
+import (
+	"testing"
+)
+
+func TestPieceTableIsGeneralCollector(t *testing.T) {
+	p := NewPieceTable[int64]()
+	if _, isGeneralCollector := interface{}(&p).(GeneralCollector[int64]); !isGeneralCollector {
+		t.Fatalf("PieceTable[int64] should be a GeneralCollector[int64]")
+	}
+}
+
+func TestPieceTableEditing(t *testing.T) {
+	p := NewPieceTableFromData([]rune("the fox")...)
+	p.InsertRange(4, []rune("quick ")...)
+	p.InsertRange(10, []rune("brown ")...)
+	if p.PieceCount() != 3 {
+		t.Fatalf("Consecutive insertions should share a piece, got %v pieces", p.PieceCount())
+	}
+	p.EraseRange(0, 4)
+	p.PushBack('!')
+	values := p.ToVector()
+	if s := string(values.Data()); s != "quick brown fox!" {
+		t.Fatalf("PieceTable should be \"quick brown fox!\", got \"%v\"", s)
+	}
+	if p.At(6) != 'b' || p.Front() != 'q' || p.Back() != '!' {
+		t.Fatalf("At, Front or Back returned the wrong element")
+	}
+}
+
+func TestPieceTableUndo(t *testing.T) {
+	p := NewPieceTableFromData(1, 2, 3)
+	p.PushBack(4)
+	p.Erase(0)
+	p.Insert(1, 9)
+	if p.String() != "{2, 9, 3, 4}" {
+		t.Fatalf("PieceTable should be {2, 9, 3, 4}, got %v", p.String())
+	}
+	p.Undo()
+	p.Undo()
+	if p.String() != "{1, 2, 3, 4}" {
+		t.Fatalf("PieceTable should be {1, 2, 3, 4} after two Undos, got %v", p.String())
+	}
+	p.Redo()
+	if p.String() != "{2, 3, 4}" || !p.CanRedo() {
+		t.Fatalf("PieceTable should be {2, 3, 4} after Redo, got %v", p.String())
+	}
+	p.PushFront(0)
+	if p.CanRedo() || p.String() != "{0, 2, 3, 4}" {
+		t.Fatalf("An edit should discard the redo history, got %v", p.String())
+	}
+	for p.Undo() {
+	}
+	if p.String() != "{1, 2, 3}" {
+		t.Fatalf("Undoing everything should restore the original, got %v", p.String())
+	}
+}
+
+func TestPieceTableHistoryLimit(t *testing.T) {
+	p := NewPieceTableFromData(0)
+	if p.HistoryLimit() != -1 {
+		t.Fatalf("A PieceTable should keep every edit by default, got %v", p.HistoryLimit())
+	}
+	for i := 1; i <= 10; i++ {
+		p.PushBack(i)
+	}
+	p.SetHistoryLimit(3)
+	undone := 0
+	for p.Undo() {
+		undone++
+	}
+	if undone != 3 || p.String() != "{0, 1, 2, 3, 4, 5, 6, 7}" {
+		t.Fatalf("Only the last 3 edits should be undone, got %v and %v", undone, p.String())
+	}
+	for p.Redo() {
+	}
+	p.SetHistoryLimit(0)
+	p.PushBack(11)
+	if p.CanUndo() || p.Undo() || p.Size() != 12 {
+		t.Fatalf("A limit of 0 should turn Undo off")
+	}
+	p.Compact()
+	p.PushBack(12)
+	if p.CanUndo() || p.HistoryLimit() != 0 {
+		t.Fatalf("Compact should keep the history limit")
+	}
+	p.SetHistoryLimit(-1)
+	p.PushBack(13)
+	if !p.Undo() || p.Back() != 12 {
+		t.Fatalf("A negative limit should keep every edit again")
+	}
+}
+
+func TestPieceTableMatchesVector(t *testing.T) {
+	p := NewPieceTable[int]()
+	v := NewVector[int]()
+	for i := 0; i < 500; i++ {
+		index := (i * 31) % (v.Size() + 1)
+		p.Insert(index, i)
+		v.Insert(index, i)
+		if i%3 == 0 {
+			index = (i * 17) % v.Size()
+			p.Erase(index)
+			v.Erase(index)
+		}
+	}
+	if p.String() != v.String() {
+		t.Fatalf("PieceTable should match the Vector")
+	}
+	p.Compact()
+	if p.String() != v.String() || p.PieceCount() != 1 || p.CanUndo() {
+		t.Fatalf("Compact should keep the elements in a single piece, got %v pieces", p.PieceCount())
+	}
+}
+
+func TestPieceTableDestruct(t *testing.T) {
+	Msgs = []string{}
+	p := NewPieceTableFromData[DBool](true, true)
+	p.PushBack(true)
+	p.PopFront()
+	if len(Msgs) != 0 {
+		t.Fatalf("Erased elements should not be destructed before Compact, got %v", len(Msgs))
+	}
+	p.Compact()
+	if len(Msgs) != 1 {
+		t.Fatalf("Compact should destruct 1 element, got %v", len(Msgs))
+	}
+	p.Clear()
+	if len(Msgs) != 3 || !p.IsEmpty() {
+		t.Fatalf("Destruct method should have been called 3 times, got %v", len(Msgs))
+	}
+}
@@ -8,6 +8,8 @@ func NewQueue[T any]() Queue[T] {
 	return Queue[T]{data: NewVector[T]()}
 }
 
+// NewQueueFromData creates a new Queue using copies of the elements in values, by value. The
+// Queue never shares storage with values.
 func NewQueueFromData[T any](values ...T) Queue[T] {
 	return Queue[T]{data: NewVectorFromData(values...)}
 }
@@ -16,6 +18,7 @@ func NewQueueFromDataRef[T any](values ...*T) Queue[T] {
 	return Queue[T]{data: NewVectorFromDataRef(values...)}
 }
 
+// NewQueueFromQueue creates a new Queue using copies of the elements of another, by value.
 func NewQueueFromQueue[T any](other Queue[T]) Queue[T] {
 	return Queue[T]{data: NewVectorFromVector(other.data)}
 }
@@ -24,6 +27,8 @@ func MakeQueue[T any]() *Queue[T] {
 	return &Queue[T]{data: NewVector[T]()}
 }
 
+// MakeQueueFromData creates a new Queue instance using copies of the elements in values. The
+// Queue never shares storage with values.
 func MakeQueueFromData[T any](values ...T) *Queue[T] {
 	return &Queue[T]{data: NewVectorFromData(values...)}
 }
@@ -32,6 +37,7 @@ func MakeQueueFromDataRef[T any](values ...*T) *Queue[T] {
 	return &Queue[T]{data: NewVectorFromDataRef(values...)}
 }
 
+// MakeQueueFromQueue creates a new Queue instance using copies of the elements of another.
 func MakeQueueFromQueue[T any](other Queue[T]) *Queue[T] {
 	return &Queue[T]{data: NewVectorFromVector(other.data)}
 }
@@ -44,6 +50,7 @@ func (q *Queue[T]) FrontRef() *T {
 	return q.data.FrontRef()
 }
 
+// Data gets the underlying slice of the elements, which is still owned by the Queue.
 func (q *Queue[T]) Data() []T {
 	return q.data.Data()
 }
@@ -72,10 +79,50 @@ func (q *Queue[T]) PopFront() {
 	q.data.PopFront()
 }
 
+// Reserve makes sure the Queue has room for at least capacity elements without allocating.
+func (q *Queue[T]) Reserve(capacity int) {
+	if capacity < 0 {
+		panic("ERROR: Queue.Reserve - negative capacity")
+	}
+	q.data.Reserve(capacity)
+}
+
+// Capacity returns the number of elements the Queue can hold without allocating.
+func (q *Queue[T]) Capacity() int {
+	return q.data.Capacity()
+}
+
+// ShrinkToFit releases any spare capacity of the Queue.
+func (q *Queue[T]) ShrinkToFit() {
+	q.data.ShrinkToFit()
+}
+
+// SetGrowthPolicy sets how the Queue grows when it runs out of capacity.
+func (q *Queue[T]) SetGrowthPolicy(policy GrowthPolicy) {
+	policy.validate("Queue.SetGrowthPolicy")
+	q.data.SetGrowthPolicy(policy)
+}
+
+// GrowthPolicy returns how the Queue grows when it runs out of capacity.
+func (q *Queue[T]) GrowthPolicy() GrowthPolicy {
+	return q.data.GrowthPolicy()
+}
+
 func (q *Queue[T]) Swap(other *Queue[T]) {
 	q.data.Swap(&other.data)
 }
 
+// Clone returns a new Queue with copies of the elements, that does not share storage with this
+// one.
+func (q *Queue[T]) Clone() Queue[T] {
+	return Queue[T]{data: q.data.Clone()}
+}
+
+// DeepClone returns a new Queue with deep copies of the elements, see Vector.DeepClone.
+func (q *Queue[T]) DeepClone() Queue[T] {
+	return Queue[T]{data: q.data.DeepClone()}
+}
+
 func (q *Queue[T]) String() string {
 	return q.data.String()
 }
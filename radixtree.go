@@ -0,0 +1,406 @@
+package gollect
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type radixTreeNode[V any] struct {
+	prefix   string
+	hasValue bool
+	value    V
+	children []*radixTreeNode[V]
+}
+
+// childIndex returns the index of the child whose prefix starts with b, or the index it would
+// have to be inserted at.
+func (n *radixTreeNode[V]) childIndex(b byte) (index int, found bool) {
+	index = sort.Search(len(n.children), func(i int) bool { return n.children[i].prefix[0] >= b })
+	found = index < len(n.children) && n.children[index].prefix[0] == b
+	return
+}
+
+func commonPrefixLength(a string, b string) int {
+	length := 0
+	for length < len(a) && length < len(b) && a[length] == b[length] {
+		length++
+	}
+	return length
+}
+
+// RadixTree is a collection of key/value pairs with string keys, based on a radix tree.
+//
+// Keys can be given either as strings or as byte slices. Besides exact lookups, a RadixTree
+// supports finding the longest key that is a prefix of a string, and visiting all the keys that
+// start with a prefix. Keys are visited in sorted (byte-wise) order.
+//
+// The zero value is an empty RadixTree.
+//
+// If the values implement the Destructible interface, they will have the Destruct method
+// called on them when they are removed from the RadixTree.
+type RadixTree[V any] struct {
+	root *radixTreeNode[V]
+	size int
+}
+
+// NewRadixTree creates a new empty RadixTree, by value.
+func NewRadixTree[V any]() RadixTree[V] {
+	return RadixTree[V]{root: &radixTreeNode[V]{}}
+}
+
+// NewRadixTreeFromRadixTree creates a new RadixTree using the key/value pairs of another, by value.
+func NewRadixTreeFromRadixTree[V any](other RadixTree[V]) RadixTree[V] {
+	t := NewRadixTree[V]()
+	other.Visit(func(key *string, value *V, break_out *bool) {
+		t.Insert(*key, *value)
+	})
+	return t
+}
+
+// MakeRadixTree creates a new empty RadixTree instance.
+func MakeRadixTree[V any]() *RadixTree[V] {
+	return &RadixTree[V]{root: &radixTreeNode[V]{}}
+}
+
+// MakeRadixTreeFromRadixTree creates a new RadixTree instance using the key/value pairs of another.
+func MakeRadixTreeFromRadixTree[V any](other RadixTree[V]) *RadixTree[V] {
+	t := NewRadixTreeFromRadixTree(other)
+	return &t
+}
+
+// IsEmpty returns true if the RadixTree is empty.
+func (t *RadixTree[V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Size returns the number of key/value pairs in the RadixTree.
+func (t *RadixTree[V]) Size() int {
+	return t.size
+}
+
+// init sets up a zero-valued RadixTree.
+func (t *RadixTree[V]) init() {
+	if t.root == nil {
+		t.root = &radixTreeNode[V]{}
+	}
+}
+
+// Insert sets the value associated with key, and returns true if key was not already present.
+//
+// If a previous value implements the Destructible interface, it will have the Destruct method
+// called on it.
+func (t *RadixTree[V]) Insert(key string, value V) bool {
+	t.init()
+	node := t.root
+	rest := key
+	for {
+		if len(rest) == 0 {
+			if node.hasValue {
+				if e, isDestructible := interface{}(&node.value).(Destructible); isDestructible {
+					e.Destruct()
+				}
+				node.value = value
+				return false
+			}
+			node.hasValue = true
+			node.value = value
+			t.size++
+			return true
+		}
+		index, found := node.childIndex(rest[0])
+		if !found {
+			child := &radixTreeNode[V]{prefix: rest, hasValue: true, value: value}
+			node.children = append(node.children, nil)
+			copy(node.children[index+1:], node.children[index:])
+			node.children[index] = child
+			t.size++
+			return true
+		}
+		child := node.children[index]
+		common := commonPrefixLength(rest, child.prefix)
+		if common < len(child.prefix) {
+			// Split the edge, so that the common part of the prefixes gets its own node.
+			split := &radixTreeNode[V]{prefix: child.prefix[:common], children: []*radixTreeNode[V]{child}}
+			child.prefix = child.prefix[common:]
+			node.children[index] = split
+			child = split
+		}
+		node = child
+		rest = rest[common:]
+	}
+}
+
+// InsertBytes sets the value associated with key, and returns true if key was not already present.
+func (t *RadixTree[V]) InsertBytes(key []byte, value V) bool {
+	return t.Insert(string(key), value)
+}
+
+// InsertRef sets the value associated with key, and returns true if key was not already present.
+func (t *RadixTree[V]) InsertRef(key string, value *V) bool {
+	return t.Insert(key, *value)
+}
+
+func (t *RadixTree[V]) findNode(key string) *radixTreeNode[V] {
+	t.init()
+	node := t.root
+	rest := key
+	for len(rest) > 0 {
+		index, found := node.childIndex(rest[0])
+		if !found || !strings.HasPrefix(rest, node.children[index].prefix) {
+			return nil
+		}
+		node = node.children[index]
+		rest = rest[len(node.prefix):]
+	}
+	if !node.hasValue {
+		return nil
+	}
+	return node
+}
+
+// Get returns the value associated with key, and whether it was found.
+func (t *RadixTree[V]) Get(key string) (value V, found bool) {
+	if node := t.findNode(key); node != nil {
+		return node.value, true
+	}
+	return
+}
+
+// GetBytes returns the value associated with key, and whether it was found.
+func (t *RadixTree[V]) GetBytes(key []byte) (value V, found bool) {
+	return t.Get(string(key))
+}
+
+// GetRef returns a pointer to the value associated with key, or nil if it was not found.
+func (t *RadixTree[V]) GetRef(key string) *V {
+	if node := t.findNode(key); node != nil {
+		return &node.value
+	}
+	return nil
+}
+
+// ContainsKey returns true if the RadixTree contains key.
+func (t *RadixTree[V]) ContainsKey(key string) bool {
+	return t.findNode(key) != nil
+}
+
+// Delete removes key and its value from the RadixTree, and returns true if it was present.
+//
+// If the value implements the Destructible interface, it will have the Destruct method called
+// on it.
+func (t *RadixTree[V]) Delete(key string) bool {
+	var parent *radixTreeNode[V]
+	parent_index := 0
+	t.init()
+	node := t.root
+	rest := key
+	for len(rest) > 0 {
+		index, found := node.childIndex(rest[0])
+		if !found || !strings.HasPrefix(rest, node.children[index].prefix) {
+			return false
+		}
+		parent, parent_index = node, index
+		node = node.children[index]
+		rest = rest[len(node.prefix):]
+	}
+	if !node.hasValue {
+		return false
+	}
+	if e, isDestructible := interface{}(&node.value).(Destructible); isDestructible {
+		e.Destruct()
+	}
+	var zero V
+	node.hasValue = false
+	node.value = zero
+	t.size--
+
+	// Keep the tree compressed: remove nodes without a value or children, and merge nodes
+	// without a value into their only child.
+	if parent == nil {
+		return true
+	}
+	if len(node.children) == 0 {
+		copy(parent.children[parent_index:], parent.children[parent_index+1:])
+		parent.children[len(parent.children)-1] = nil
+		parent.children = parent.children[:len(parent.children)-1]
+		if parent != t.root && !parent.hasValue && len(parent.children) == 1 {
+			parent.mergeChild()
+		}
+	} else if len(node.children) == 1 {
+		node.mergeChild()
+	}
+	return true
+}
+
+// mergeChild merges the only child of n into n.
+func (n *radixTreeNode[V]) mergeChild() {
+	child := n.children[0]
+	n.prefix += child.prefix
+	n.hasValue = child.hasValue
+	n.value = child.value
+	n.children = child.children
+}
+
+// DeleteBytes removes key and its value from the RadixTree, and returns true if it was present.
+func (t *RadixTree[V]) DeleteBytes(key []byte) bool {
+	return t.Delete(string(key))
+}
+
+// LongestPrefixMatch finds the longest key in the RadixTree that is a prefix of s, and returns it
+// with its value.
+func (t *RadixTree[V]) LongestPrefixMatch(s string) (key string, value V, found bool) {
+	t.init()
+	node := t.root
+	consumed := 0
+	if node.hasValue {
+		key, value, found = "", node.value, true
+	}
+	for consumed < len(s) {
+		index, ok := node.childIndex(s[consumed])
+		if !ok || !strings.HasPrefix(s[consumed:], node.children[index].prefix) {
+			break
+		}
+		node = node.children[index]
+		consumed += len(node.prefix)
+		if node.hasValue {
+			key, value, found = s[:consumed], node.value, true
+		}
+	}
+	return
+}
+
+// LongestPrefixMatchBytes finds the longest key in the RadixTree that is a prefix of s, and
+// returns it with its value.
+func (t *RadixTree[V]) LongestPrefixMatchBytes(s []byte) (key []byte, value V, found bool) {
+	var str_key string
+	str_key, value, found = t.LongestPrefixMatch(string(s))
+	if found {
+		key = s[:len(str_key)]
+	}
+	return
+}
+
+// Clear removes all the key/value pairs from the RadixTree.
+//
+// If the values implement the Destructible interface, they will have the Destruct method
+// called on them.
+func (t *RadixTree[V]) Clear() {
+	t.Visit(func(key *string, value *V, break_out *bool) {
+		if e, isDestructible := interface{}(value).(Destructible); isDestructible {
+			e.Destruct()
+		}
+	})
+	t.root = &radixTreeNode[V]{}
+	t.size = 0
+}
+
+// Swap swaps the data of two RadixTrees.
+func (t *RadixTree[V]) Swap(other *RadixTree[V]) {
+	t.root, other.root = other.root, t.root
+	t.size, other.size = other.size, t.size
+}
+
+// Clone returns a new RadixTree with copies of the key/value pairs, that does not share any nodes
+// with this one.
+//
+// Values are copied by assignment, so pointers, slices and maps inside them are still shared.
+// Use DeepClone to copy those too.
+func (t *RadixTree[V]) Clone() RadixTree[V] {
+	return NewRadixTreeFromRadixTree(*t)
+}
+
+// DeepClone returns a new RadixTree with deep copies of the values, see Vector.DeepClone.
+func (t *RadixTree[V]) DeepClone() RadixTree[V] {
+	result := t.Clone()
+	result.Visit(func(key *string, value *V, break_out *bool) {
+		*value = DeepCopy(*value)
+	})
+	return result
+}
+
+func (t *RadixTree[V]) visitNode(node *radixTreeNode[V], key string, visitor MapVisitor[string, V], break_out *bool) {
+	if node.hasValue {
+		k := key
+		visitor(&k, &node.value, break_out)
+	}
+	for idx := 0; (!*break_out) && (idx < len(node.children)); idx++ {
+		child := node.children[idx]
+		t.visitNode(child, key+child.prefix, visitor, break_out)
+	}
+}
+
+// Visit calls a function for every key/value pair in the RadixTree, in sorted key order.
+func (t *RadixTree[V]) Visit(visitor MapVisitor[string, V]) {
+	t.init()
+	break_out := false
+	t.visitNode(t.root, "", visitor, &break_out)
+}
+
+// VisitPrefix calls a function for every key/value pair in the RadixTree whose key starts with
+// prefix, in sorted key order.
+func (t *RadixTree[V]) VisitPrefix(prefix string, visitor MapVisitor[string, V]) {
+	t.init()
+	node := t.root
+	key := ""
+	rest := prefix
+	for len(rest) > 0 {
+		index, found := node.childIndex(rest[0])
+		if !found {
+			return
+		}
+		child := node.children[index]
+		if strings.HasPrefix(rest, child.prefix) {
+			rest = rest[len(child.prefix):]
+		} else if strings.HasPrefix(child.prefix, rest) {
+			rest = ""
+		} else {
+			return
+		}
+		key += child.prefix
+		node = child
+	}
+	break_out := false
+	t.visitNode(node, key, visitor, &break_out)
+}
+
+// VisitPrefixBytes calls a function for every key/value pair in the RadixTree whose key starts
+// with prefix, in sorted key order.
+func (t *RadixTree[V]) VisitPrefixBytes(prefix []byte, visitor MapVisitor[string, V]) {
+	t.VisitPrefix(string(prefix), visitor)
+}
+
+// Keys returns a Vector of all the keys in the RadixTree, in sorted order.
+func (t *RadixTree[V]) Keys() Vector[string] {
+	keys := NewVector[string]()
+	t.Visit(func(key *string, value *V, break_out *bool) {
+		keys.PushBack(*key)
+	})
+	return keys
+}
+
+// Values returns a Vector of all the values in the RadixTree, in sorted key order.
+func (t *RadixTree[V]) Values() Vector[V] {
+	values := NewVector[V]()
+	t.Visit(func(key *string, value *V, break_out *bool) {
+		values.PushBack(*value)
+	})
+	return values
+}
+
+// String returns a string representation of the RadixTree and it's contents.
+func (t *RadixTree[V]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	count := 0
+	t.Visit(func(key *string, value *V, break_out *bool) {
+		if count == t.size-1 {
+			fmt.Fprintf(&builder, "%q: %v", *key, *value)
+		} else {
+			fmt.Fprintf(&builder, "%q: %v, ", *key, *value)
+		}
+		count++
+	})
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
@@ -0,0 +1,128 @@
+package gollect
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestRadixTreeInsertGetDelete(t *testing.T) {
+	tree := NewRadixTree[int]()
+	reference := map[string]int{}
+	rng := rand.New(rand.NewSource(12345))
+	letters := "abc"
+	for i := 0; i < 3000; i++ {
+		length := rng.Intn(6)
+		b := make([]byte, length)
+		for j := range b {
+			b[j] = letters[rng.Intn(len(letters))]
+		}
+		key := string(b)
+		if rng.Intn(3) == 0 {
+			_, present := reference[key]
+			if tree.DeleteBytes(b) != present {
+				t.Fatalf("Delete(%q) should return %v", key, present)
+			}
+			delete(reference, key)
+		} else {
+			_, present := reference[key]
+			if tree.Insert(key, i) == present {
+				t.Fatalf("Insert(%q) should return %v", key, !present)
+			}
+			reference[key] = i
+		}
+	}
+	if tree.Size() != len(reference) {
+		t.Fatalf("Size should be %v, got %v", len(reference), tree.Size())
+	}
+	keys := make([]string, 0, len(reference))
+	for key, value := range reference {
+		keys = append(keys, key)
+		if v, found := tree.Get(key); !found || v != value {
+			t.Fatalf("Get(%q) should be %v, got %v (%v)", key, value, v, found)
+		}
+	}
+	sort.Strings(keys)
+	got := tree.Keys()
+	if got.Size() != len(keys) {
+		t.Fatalf("Keys should have %v elements, got %v", len(keys), got.Size())
+	}
+	for idx, key := range keys {
+		if got.At(idx) != key {
+			t.Fatalf("Keys[%v] should be %q, got %q", idx, key, got.At(idx))
+		}
+	}
+}
+
+func TestRadixTreeLongestPrefixMatch(t *testing.T) {
+	tree := NewRadixTree[string]()
+	tree.Insert("/", "root")
+	tree.Insert("/api", "api")
+	tree.Insert("/api/v1/users", "users")
+	tree.Insert("/apix", "apix")
+
+	if key, value, found := tree.LongestPrefixMatch("/api/v1/groups"); !found || key != "/api" || value != "api" {
+		t.Fatalf("Longest match should be /api, got %q %q %v", key, value, found)
+	}
+	if key, _, _ := tree.LongestPrefixMatch("/api/v1/users/42"); key != "/api/v1/users" {
+		t.Fatalf("Longest match should be /api/v1/users, got %q", key)
+	}
+	if key, _, _ := tree.LongestPrefixMatchBytes([]byte("/other")); string(key) != "/" {
+		t.Fatalf("Longest match should be /, got %q", key)
+	}
+	if _, _, found := tree.LongestPrefixMatch("api"); found {
+		t.Fatalf("There should be no match for \"api\"")
+	}
+}
+
+func TestRadixTreeVisitPrefix(t *testing.T) {
+	tree := NewRadixTree[int]()
+	for idx, key := range []string{"team", "tea", "ten", "to", "teammate", "apple"} {
+		tree.Insert(key, idx)
+	}
+	visited := []string{}
+	tree.VisitPrefix("te", func(key *string, value *int, break_out *bool) {
+		visited = append(visited, *key)
+	})
+	if len(visited) != 4 || visited[0] != "tea" || visited[3] != "ten" {
+		t.Fatalf("VisitPrefix(te) should visit [tea team teammate ten], got %v", visited)
+	}
+	visited = visited[:0]
+	tree.VisitPrefix("", func(key *string, value *int, break_out *bool) {
+		visited = append(visited, *key)
+		*break_out = len(visited) == 2
+	})
+	if len(visited) != 2 || visited[0] != "apple" {
+		t.Fatalf("VisitPrefix should stop after 2 keys, got %v", visited)
+	}
+}
+
+func TestRadixTreeDestruct(t *testing.T) {
+	Msgs = []string{}
+	tree := NewRadixTree[DBool]()
+	tree.Insert("a", true)
+	tree.Insert("ab", true)
+	tree.Insert("a", true)
+	tree.Delete("ab")
+	tree.Clear()
+	if len(Msgs) != 3 {
+		t.Fatalf("Destruct method should have been called 3 times, got %v", len(Msgs))
+	}
+}
+
+func TestRadixTreeZeroValue(t *testing.T) {
+	var r RadixTree[int]
+	if _, found := r.Get("a"); found || r.Size() != 0 || r.Delete("a") {
+		t.Fatalf("A zero-valued RadixTree should be empty")
+	}
+	r.Insert("abc", 1)
+	r.Insert("abd", 2)
+	if key, value, found := r.LongestPrefixMatch("abcd"); !found || key != "abc" || value != 1 {
+		t.Fatalf("LongestPrefixMatch should find abc, got %v", key)
+	}
+	var empty RadixTree[int]
+	empty.Visit(func(key *string, value *int, break_out *bool) {
+		t.Fatalf("A zero-valued RadixTree should have nothing to visit")
+	})
+	empty.Clear()
+}
@@ -0,0 +1,944 @@
+package gollect
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+// roaringArrayMaxSize is the largest cardinality stored in an array container. Anything larger
+// is cheaper to store as a bitmap container.
+const roaringArrayMaxSize = 4096
+
+const roaringBitmapWords = 1024
+
+const (
+	roaringSerialCookieNoRuns = 12346
+	roaringSerialCookie       = 12347
+	roaringNoOffsetThreshold  = 4
+)
+
+// roaringContainer stores the low 16 bits of the values that share the same high 16 bits.
+type roaringContainer interface {
+	add(x uint16) (roaringContainer, bool)
+	remove(x uint16) (roaringContainer, bool)
+	contains(x uint16) bool
+	cardinality() int
+	// rank returns the number of values lesser than or equal to x.
+	rank(x uint16) int
+	selectAt(index int) uint16
+	// visit calls visitor for every value in increasing order, until it returns false. It
+	// returns false if it was stopped.
+	visit(visitor func(x uint16) bool) bool
+	toBitmap() *roaringBitmapContainer
+	clone() roaringContainer
+}
+
+type roaringArrayContainer struct {
+	values []uint16
+}
+
+func (c *roaringArrayContainer) find(x uint16) (index int, found bool) {
+	index = sort.Search(len(c.values), func(i int) bool { return c.values[i] >= x })
+	found = index < len(c.values) && c.values[index] == x
+	return
+}
+
+func (c *roaringArrayContainer) add(x uint16) (roaringContainer, bool) {
+	index, found := c.find(x)
+	if found {
+		return c, false
+	}
+	if len(c.values) >= roaringArrayMaxSize {
+		return c.toBitmap().add(x)
+	}
+	c.values = append(c.values, 0)
+	copy(c.values[index+1:], c.values[index:])
+	c.values[index] = x
+	return c, true
+}
+
+func (c *roaringArrayContainer) remove(x uint16) (roaringContainer, bool) {
+	index, found := c.find(x)
+	if !found {
+		return c, false
+	}
+	c.values = append(c.values[:index], c.values[index+1:]...)
+	return c, true
+}
+
+func (c *roaringArrayContainer) contains(x uint16) bool {
+	_, found := c.find(x)
+	return found
+}
+
+func (c *roaringArrayContainer) cardinality() int {
+	return len(c.values)
+}
+
+func (c *roaringArrayContainer) rank(x uint16) int {
+	index, found := c.find(x)
+	if found {
+		return index + 1
+	}
+	return index
+}
+
+func (c *roaringArrayContainer) selectAt(index int) uint16 {
+	return c.values[index]
+}
+
+func (c *roaringArrayContainer) visit(visitor func(x uint16) bool) bool {
+	for _, x := range c.values {
+		if !visitor(x) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *roaringArrayContainer) toBitmap() *roaringBitmapContainer {
+	b := &roaringBitmapContainer{}
+	for _, x := range c.values {
+		b.words[x/64] |= uint64(1) << (x % 64)
+	}
+	b.card = len(c.values)
+	return b
+}
+
+func (c *roaringArrayContainer) clone() roaringContainer {
+	return &roaringArrayContainer{values: append([]uint16{}, c.values...)}
+}
+
+type roaringBitmapContainer struct {
+	words [roaringBitmapWords]uint64
+	card  int
+}
+
+func (c *roaringBitmapContainer) add(x uint16) (roaringContainer, bool) {
+	mask := uint64(1) << (x % 64)
+	if c.words[x/64]&mask != 0 {
+		return c, false
+	}
+	c.words[x/64] |= mask
+	c.card++
+	return c, true
+}
+
+func (c *roaringBitmapContainer) remove(x uint16) (roaringContainer, bool) {
+	mask := uint64(1) << (x % 64)
+	if c.words[x/64]&mask == 0 {
+		return c, false
+	}
+	c.words[x/64] &^= mask
+	c.card--
+	if c.card <= roaringArrayMaxSize {
+		return c.toArray(), true
+	}
+	return c, true
+}
+
+func (c *roaringBitmapContainer) contains(x uint16) bool {
+	return c.words[x/64]&(uint64(1)<<(x%64)) != 0
+}
+
+func (c *roaringBitmapContainer) cardinality() int {
+	return c.card
+}
+
+func (c *roaringBitmapContainer) rank(x uint16) int {
+	count := 0
+	for idx := 0; idx < int(x/64); idx++ {
+		count += bits.OnesCount64(c.words[idx])
+	}
+	shift := 63 - x%64
+	return count + bits.OnesCount64(c.words[x/64]<<shift)
+}
+
+func (c *roaringBitmapContainer) selectAt(index int) uint16 {
+	for idx, word := range c.words {
+		count := bits.OnesCount64(word)
+		if index < count {
+			for ; index > 0; index-- {
+				word &= word - 1
+			}
+			return uint16(idx*64 + bits.TrailingZeros64(word))
+		}
+		index -= count
+	}
+	panic("ERROR: RoaringBitmap.Select - index out of range")
+}
+
+func (c *roaringBitmapContainer) visit(visitor func(x uint16) bool) bool {
+	for idx, word := range c.words {
+		for word != 0 {
+			if !visitor(uint16(idx*64 + bits.TrailingZeros64(word))) {
+				return false
+			}
+			word &= word - 1
+		}
+	}
+	return true
+}
+
+func (c *roaringBitmapContainer) toBitmap() *roaringBitmapContainer {
+	b := *c
+	return &b
+}
+
+func (c *roaringBitmapContainer) toArray() *roaringArrayContainer {
+	a := &roaringArrayContainer{values: make([]uint16, 0, c.card)}
+	c.visit(func(x uint16) bool {
+		a.values = append(a.values, x)
+		return true
+	})
+	return a
+}
+
+func (c *roaringBitmapContainer) clone() roaringContainer {
+	return c.toBitmap()
+}
+
+func (c *roaringBitmapContainer) recount() {
+	c.card = 0
+	for _, word := range c.words {
+		c.card += bits.OnesCount64(word)
+	}
+}
+
+// normalize returns the cheapest of an array or bitmap container holding the values of c.
+func (c *roaringBitmapContainer) normalize() roaringContainer {
+	if c.card <= roaringArrayMaxSize {
+		return c.toArray()
+	}
+	return c
+}
+
+type roaringRun struct {
+	start uint16
+	last  uint16
+}
+
+type roaringRunContainer struct {
+	runs []roaringRun
+}
+
+// find returns the index of the run containing x, or of the last run starting before x.
+func (c *roaringRunContainer) find(x uint16) (index int, found bool) {
+	index = sort.Search(len(c.runs), func(i int) bool { return c.runs[i].start > x }) - 1
+	found = index >= 0 && c.runs[index].last >= x
+	return
+}
+
+func (c *roaringRunContainer) add(x uint16) (roaringContainer, bool) {
+	prev, found := c.find(x)
+	if found {
+		return c, false
+	}
+	next := prev + 1
+	extend_prev := prev >= 0 && c.runs[prev].last+1 == x
+	extend_next := next < len(c.runs) && c.runs[next].start-1 == x
+	if extend_prev && extend_next {
+		c.runs[prev].last = c.runs[next].last
+		c.runs = append(c.runs[:next], c.runs[next+1:]...)
+	} else if extend_prev {
+		c.runs[prev].last = x
+	} else if extend_next {
+		c.runs[next].start = x
+	} else {
+		c.runs = append(c.runs, roaringRun{})
+		copy(c.runs[next+1:], c.runs[next:])
+		c.runs[next] = roaringRun{start: x, last: x}
+	}
+	return c, true
+}
+
+func (c *roaringRunContainer) remove(x uint16) (roaringContainer, bool) {
+	index, found := c.find(x)
+	if !found {
+		return c, false
+	}
+	run := c.runs[index]
+	if run.start == run.last {
+		c.runs = append(c.runs[:index], c.runs[index+1:]...)
+	} else if x == run.start {
+		c.runs[index].start++
+	} else if x == run.last {
+		c.runs[index].last--
+	} else {
+		c.runs = append(c.runs, roaringRun{})
+		copy(c.runs[index+2:], c.runs[index+1:])
+		c.runs[index] = roaringRun{start: run.start, last: x - 1}
+		c.runs[index+1] = roaringRun{start: x + 1, last: run.last}
+	}
+	return c, true
+}
+
+func (c *roaringRunContainer) contains(x uint16) bool {
+	_, found := c.find(x)
+	return found
+}
+
+func (c *roaringRunContainer) cardinality() int {
+	count := 0
+	for _, run := range c.runs {
+		count += int(run.last-run.start) + 1
+	}
+	return count
+}
+
+func (c *roaringRunContainer) rank(x uint16) int {
+	count := 0
+	for _, run := range c.runs {
+		if run.start > x {
+			break
+		}
+		if run.last >= x {
+			return count + int(x-run.start) + 1
+		}
+		count += int(run.last-run.start) + 1
+	}
+	return count
+}
+
+func (c *roaringRunContainer) selectAt(index int) uint16 {
+	for _, run := range c.runs {
+		length := int(run.last-run.start) + 1
+		if index < length {
+			return run.start + uint16(index)
+		}
+		index -= length
+	}
+	panic("ERROR: RoaringBitmap.Select - index out of range")
+}
+
+func (c *roaringRunContainer) visit(visitor func(x uint16) bool) bool {
+	for _, run := range c.runs {
+		for x := int(run.start); x <= int(run.last); x++ {
+			if !visitor(uint16(x)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (c *roaringRunContainer) toBitmap() *roaringBitmapContainer {
+	b := &roaringBitmapContainer{}
+	for _, run := range c.runs {
+		for x := int(run.start); x <= int(run.last); x++ {
+			b.words[x/64] |= uint64(1) << (x % 64)
+		}
+	}
+	b.card = c.cardinality()
+	return b
+}
+
+func (c *roaringRunContainer) clone() roaringContainer {
+	return &roaringRunContainer{runs: append([]roaringRun{}, c.runs...)}
+}
+
+// roaringToRuns returns a run container holding the values of c.
+func roaringToRuns(c roaringContainer) *roaringRunContainer {
+	r := &roaringRunContainer{}
+	c.visit(func(x uint16) bool {
+		if n := len(r.runs); n > 0 && r.runs[n-1].last+1 == x {
+			r.runs[n-1].last = x
+		} else {
+			r.runs = append(r.runs, roaringRun{start: x, last: x})
+		}
+		return true
+	})
+	return r
+}
+
+// roaringSerializedSize returns the number of bytes c takes up when serialized.
+func roaringSerializedSize(c roaringContainer) int {
+	switch c := c.(type) {
+	case *roaringRunContainer:
+		return 2 + 4*len(c.runs)
+	default:
+		if c.cardinality() <= roaringArrayMaxSize {
+			return 2 * c.cardinality()
+		}
+		return 8 * roaringBitmapWords
+	}
+}
+
+func roaringUnion(a roaringContainer, b roaringContainer) roaringContainer {
+	if aa, ok := a.(*roaringArrayContainer); ok {
+		if ba, ok := b.(*roaringArrayContainer); ok && len(aa.values)+len(ba.values) <= roaringArrayMaxSize {
+			values := make([]uint16, 0, len(aa.values)+len(ba.values))
+			i, j := 0, 0
+			for i < len(aa.values) && j < len(ba.values) {
+				if aa.values[i] < ba.values[j] {
+					values = append(values, aa.values[i])
+					i++
+				} else if aa.values[i] > ba.values[j] {
+					values = append(values, ba.values[j])
+					j++
+				} else {
+					values = append(values, aa.values[i])
+					i++
+					j++
+				}
+			}
+			values = append(values, aa.values[i:]...)
+			values = append(values, ba.values[j:]...)
+			return &roaringArrayContainer{values: values}
+		}
+	}
+	result := a.toBitmap()
+	if bb, ok := b.(*roaringBitmapContainer); ok {
+		for idx := range result.words {
+			result.words[idx] |= bb.words[idx]
+		}
+	} else {
+		b.visit(func(x uint16) bool {
+			result.words[x/64] |= uint64(1) << (x % 64)
+			return true
+		})
+	}
+	result.recount()
+	return result.normalize()
+}
+
+func roaringIntersection(a roaringContainer, b roaringContainer) roaringContainer {
+	if b.cardinality() < a.cardinality() {
+		a, b = b, a
+	}
+	if _, ok := a.(*roaringBitmapContainer); !ok {
+		result := &roaringArrayContainer{}
+		a.visit(func(x uint16) bool {
+			if b.contains(x) {
+				result.values = append(result.values, x)
+			}
+			return true
+		})
+		return result
+	}
+	// Both are bitmaps, because a bitmap has a larger cardinality than any array.
+	result := a.toBitmap()
+	bb := b.toBitmap()
+	for idx := range result.words {
+		result.words[idx] &= bb.words[idx]
+	}
+	result.recount()
+	return result.normalize()
+}
+
+func roaringDifference(a roaringContainer, b roaringContainer) roaringContainer {
+	if _, ok := a.(*roaringBitmapContainer); !ok {
+		result := &roaringArrayContainer{}
+		a.visit(func(x uint16) bool {
+			if !b.contains(x) {
+				result.values = append(result.values, x)
+			}
+			return true
+		})
+		if result.cardinality() > roaringArrayMaxSize {
+			return result.toBitmap()
+		}
+		return result
+	}
+	result := a.toBitmap()
+	if bb, ok := b.(*roaringBitmapContainer); ok {
+		for idx := range result.words {
+			result.words[idx] &^= bb.words[idx]
+		}
+	} else {
+		b.visit(func(x uint16) bool {
+			result.words[x/64] &^= uint64(1) << (x % 64)
+			return true
+		})
+	}
+	result.recount()
+	return result.normalize()
+}
+
+// RoaringBitmap is a compressed set of uint32 values.
+//
+// Values are split by their high 16 bits into containers, each of which is stored as a sorted
+// array, a bitmap or a list of runs, whichever is smallest. This gives fast membership tests and
+// set operations with much less memory than an NVector[uint32] or a Bitset for sparse sets.
+//
+// The binary serialization follows the portable Roaring format, so it can be read by the other
+// Roaring implementations.
+type RoaringBitmap struct {
+	keys       []uint16
+	containers []roaringContainer
+}
+
+// NewRoaringBitmap creates a new empty RoaringBitmap, by value.
+func NewRoaringBitmap() RoaringBitmap {
+	return RoaringBitmap{keys: []uint16{}, containers: []roaringContainer{}}
+}
+
+// NewRoaringBitmapFromData creates a new RoaringBitmap using the elements in values, by value.
+func NewRoaringBitmapFromData(values ...uint32) RoaringBitmap {
+	r := NewRoaringBitmap()
+	for _, val := range values {
+		r.Add(val)
+	}
+	return r
+}
+
+// NewRoaringBitmapFromNVector creates a new RoaringBitmap using the elements of other, by value.
+func NewRoaringBitmapFromNVector(other NVector[uint32]) RoaringBitmap {
+	return NewRoaringBitmapFromData(other.data...)
+}
+
+// NewRoaringBitmapFromRoaringBitmap creates a new RoaringBitmap using the values of another, by
+// value.
+func NewRoaringBitmapFromRoaringBitmap(other RoaringBitmap) RoaringBitmap {
+	r := RoaringBitmap{keys: append([]uint16{}, other.keys...), containers: make([]roaringContainer, len(other.containers))}
+	for idx, c := range other.containers {
+		r.containers[idx] = c.clone()
+	}
+	return r
+}
+
+// MakeRoaringBitmap creates a new empty RoaringBitmap instance.
+func MakeRoaringBitmap() *RoaringBitmap {
+	r := NewRoaringBitmap()
+	return &r
+}
+
+// MakeRoaringBitmapFromData creates a new RoaringBitmap instance using the elements in values.
+func MakeRoaringBitmapFromData(values ...uint32) *RoaringBitmap {
+	r := NewRoaringBitmapFromData(values...)
+	return &r
+}
+
+// MakeRoaringBitmapFromNVector creates a new RoaringBitmap instance using the elements of other.
+func MakeRoaringBitmapFromNVector(other NVector[uint32]) *RoaringBitmap {
+	r := NewRoaringBitmapFromNVector(other)
+	return &r
+}
+
+// MakeRoaringBitmapFromRoaringBitmap creates a new RoaringBitmap instance using the values of
+// another.
+func MakeRoaringBitmapFromRoaringBitmap(other RoaringBitmap) *RoaringBitmap {
+	r := NewRoaringBitmapFromRoaringBitmap(other)
+	return &r
+}
+
+func (r *RoaringBitmap) findKey(key uint16) (index int, found bool) {
+	index = sort.Search(len(r.keys), func(i int) bool { return r.keys[i] >= key })
+	found = index < len(r.keys) && r.keys[index] == key
+	return
+}
+
+// Add adds value to the RoaringBitmap, and returns true if it was not already present.
+func (r *RoaringBitmap) Add(value uint32) bool {
+	key := uint16(value >> 16)
+	index, found := r.findKey(key)
+	if !found {
+		r.keys = append(r.keys, 0)
+		copy(r.keys[index+1:], r.keys[index:])
+		r.keys[index] = key
+		r.containers = append(r.containers, nil)
+		copy(r.containers[index+1:], r.containers[index:])
+		r.containers[index] = &roaringArrayContainer{}
+	}
+	var added bool
+	r.containers[index], added = r.containers[index].add(uint16(value))
+	return added
+}
+
+// Remove removes value from the RoaringBitmap, and returns true if it was present.
+func (r *RoaringBitmap) Remove(value uint32) bool {
+	index, found := r.findKey(uint16(value >> 16))
+	if !found {
+		return false
+	}
+	var removed bool
+	r.containers[index], removed = r.containers[index].remove(uint16(value))
+	if r.containers[index].cardinality() == 0 {
+		r.keys = append(r.keys[:index], r.keys[index+1:]...)
+		r.containers = append(r.containers[:index], r.containers[index+1:]...)
+	}
+	return removed
+}
+
+// Contains returns true if the RoaringBitmap contains value.
+func (r *RoaringBitmap) Contains(value uint32) bool {
+	index, found := r.findKey(uint16(value >> 16))
+	return found && r.containers[index].contains(uint16(value))
+}
+
+// Cardinality returns the number of values in the RoaringBitmap.
+func (r *RoaringBitmap) Cardinality() uint64 {
+	var count uint64 = 0
+	for _, c := range r.containers {
+		count += uint64(c.cardinality())
+	}
+	return count
+}
+
+// IsEmpty returns true if the RoaringBitmap is empty.
+func (r *RoaringBitmap) IsEmpty() bool {
+	return len(r.keys) == 0
+}
+
+// Clear removes all the values from the RoaringBitmap.
+func (r *RoaringBitmap) Clear() {
+	r.keys = []uint16{}
+	r.containers = []roaringContainer{}
+}
+
+// Swap swaps the data of two RoaringBitmaps.
+func (r *RoaringBitmap) Swap(other *RoaringBitmap) {
+	*r, *other = *other, *r
+}
+
+// Clone returns a new RoaringBitmap with the same values, that does not share storage with this
+// one.
+func (r *RoaringBitmap) Clone() RoaringBitmap {
+	return NewRoaringBitmapFromRoaringBitmap(*r)
+}
+
+// Rank returns the number of values in the RoaringBitmap that are lesser than or equal to value.
+func (r *RoaringBitmap) Rank(value uint32) uint64 {
+	key := uint16(value >> 16)
+	var count uint64 = 0
+	for idx, k := range r.keys {
+		if k > key {
+			break
+		} else if k < key {
+			count += uint64(r.containers[idx].cardinality())
+		} else {
+			count += uint64(r.containers[idx].rank(uint16(value)))
+		}
+	}
+	return count
+}
+
+// Select returns the value with the given rank, where the lowest value has rank 0.
+func (r *RoaringBitmap) Select(rank uint64) (value uint32, found bool) {
+	for idx, c := range r.containers {
+		card := uint64(c.cardinality())
+		if rank < card {
+			return uint32(r.keys[idx])<<16 | uint32(c.selectAt(int(rank))), true
+		}
+		rank -= card
+	}
+	return 0, false
+}
+
+// Minimum returns the lowest value in the RoaringBitmap.
+func (r *RoaringBitmap) Minimum() uint32 {
+	if r.IsEmpty() {
+		panic("ERROR: RoaringBitmap.Minimum - empty bitmap")
+	}
+	return uint32(r.keys[0])<<16 | uint32(r.containers[0].selectAt(0))
+}
+
+// Maximum returns the highest value in the RoaringBitmap.
+func (r *RoaringBitmap) Maximum() uint32 {
+	if r.IsEmpty() {
+		panic("ERROR: RoaringBitmap.Maximum - empty bitmap")
+	}
+	last := len(r.keys) - 1
+	c := r.containers[last]
+	return uint32(r.keys[last])<<16 | uint32(c.selectAt(c.cardinality()-1))
+}
+
+// Union returns a new RoaringBitmap with the values in either RoaringBitmap.
+func (r *RoaringBitmap) Union(other RoaringBitmap) RoaringBitmap {
+	result := NewRoaringBitmap()
+	i, j := 0, 0
+	for i < len(r.keys) || j < len(other.keys) {
+		if j >= len(other.keys) || (i < len(r.keys) && r.keys[i] < other.keys[j]) {
+			result.keys = append(result.keys, r.keys[i])
+			result.containers = append(result.containers, r.containers[i].clone())
+			i++
+		} else if i >= len(r.keys) || other.keys[j] < r.keys[i] {
+			result.keys = append(result.keys, other.keys[j])
+			result.containers = append(result.containers, other.containers[j].clone())
+			j++
+		} else {
+			result.keys = append(result.keys, r.keys[i])
+			result.containers = append(result.containers, roaringUnion(r.containers[i], other.containers[j]))
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// Intersection returns a new RoaringBitmap with the values in both RoaringBitmaps.
+func (r *RoaringBitmap) Intersection(other RoaringBitmap) RoaringBitmap {
+	result := NewRoaringBitmap()
+	i, j := 0, 0
+	for i < len(r.keys) && j < len(other.keys) {
+		if r.keys[i] < other.keys[j] {
+			i++
+		} else if other.keys[j] < r.keys[i] {
+			j++
+		} else {
+			if c := roaringIntersection(r.containers[i], other.containers[j]); c.cardinality() > 0 {
+				result.keys = append(result.keys, r.keys[i])
+				result.containers = append(result.containers, c)
+			}
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// Difference returns a new RoaringBitmap with the values in this RoaringBitmap but not in other.
+func (r *RoaringBitmap) Difference(other RoaringBitmap) RoaringBitmap {
+	result := NewRoaringBitmap()
+	j := 0
+	for i := range r.keys {
+		for j < len(other.keys) && other.keys[j] < r.keys[i] {
+			j++
+		}
+		var c roaringContainer
+		if j < len(other.keys) && other.keys[j] == r.keys[i] {
+			c = roaringDifference(r.containers[i], other.containers[j])
+		} else {
+			c = r.containers[i].clone()
+		}
+		if c.cardinality() > 0 {
+			result.keys = append(result.keys, r.keys[i])
+			result.containers = append(result.containers, c)
+		}
+	}
+	return result
+}
+
+// RunOptimize converts every container that would be smaller as a list of runs into one, and
+// every list of runs that would be smaller as an array or bitmap back.
+func (r *RoaringBitmap) RunOptimize() {
+	for idx, c := range r.containers {
+		runs := roaringToRuns(c)
+		if roaringSerializedSize(runs) < roaringSerializedSize(c) {
+			r.containers[idx] = runs
+		} else if _, isRuns := c.(*roaringRunContainer); isRuns {
+			r.containers[idx] = c.toBitmap().normalize()
+		}
+	}
+}
+
+// Visit calls a function for every value in the RoaringBitmap, in increasing order.
+func (r *RoaringBitmap) Visit(visitor CollectionVisitor[uint32]) {
+	break_out := false
+	for idx, c := range r.containers {
+		high := uint32(r.keys[idx]) << 16
+		c.visit(func(x uint16) bool {
+			value := high | uint32(x)
+			visitor(&value, &break_out)
+			return !break_out
+		})
+		if break_out {
+			return
+		}
+	}
+}
+
+// ToNVector returns an NVector of all the values in the RoaringBitmap, in increasing order.
+func (r *RoaringBitmap) ToNVector() NVector[uint32] {
+	v := NVector[uint32]{data: make([]uint32, 0, r.Cardinality())}
+	r.Visit(func(value *uint32, break_out *bool) {
+		v.PushBack(*value)
+	})
+	return v
+}
+
+// String returns a string representation of the RoaringBitmap and it's contents.
+func (r *RoaringBitmap) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	first := true
+	r.Visit(func(value *uint32, break_out *bool) {
+		if first {
+			fmt.Fprintf(&builder, "%v", *value)
+			first = false
+		} else {
+			fmt.Fprintf(&builder, ", %v", *value)
+		}
+	})
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
+
+// MarshalBinary encodes the RoaringBitmap in the portable Roaring serialization format.
+func (r *RoaringBitmap) MarshalBinary() ([]byte, error) {
+	has_runs := false
+	for _, c := range r.containers {
+		if _, isRuns := c.(*roaringRunContainer); isRuns {
+			has_runs = true
+			break
+		}
+	}
+	size := len(r.keys)
+	buf := make([]byte, 0, 8+8*size)
+	if has_runs {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(roaringSerialCookie|(size-1)<<16))
+		run_flags := make([]byte, (size+7)/8)
+		for idx, c := range r.containers {
+			if _, isRuns := c.(*roaringRunContainer); isRuns {
+				run_flags[idx/8] |= 1 << (idx % 8)
+			}
+		}
+		buf = append(buf, run_flags...)
+	} else {
+		buf = binary.LittleEndian.AppendUint32(buf, roaringSerialCookieNoRuns)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(size))
+	}
+	for idx, c := range r.containers {
+		buf = binary.LittleEndian.AppendUint16(buf, r.keys[idx])
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(c.cardinality()-1))
+	}
+	if !has_runs || size >= roaringNoOffsetThreshold {
+		offset := len(buf) + 4*size
+		for _, c := range r.containers {
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(offset))
+			offset += roaringSerializedSize(c)
+		}
+	}
+	for _, c := range r.containers {
+		switch c := c.(type) {
+		case *roaringRunContainer:
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(len(c.runs)))
+			for _, run := range c.runs {
+				buf = binary.LittleEndian.AppendUint16(buf, run.start)
+				buf = binary.LittleEndian.AppendUint16(buf, run.last-run.start)
+			}
+		case *roaringBitmapContainer:
+			if c.card <= roaringArrayMaxSize {
+				for _, x := range c.toArray().values {
+					buf = binary.LittleEndian.AppendUint16(buf, x)
+				}
+			} else {
+				for _, word := range c.words {
+					buf = binary.LittleEndian.AppendUint64(buf, word)
+				}
+			}
+		case *roaringArrayContainer:
+			if len(c.values) > roaringArrayMaxSize {
+				for _, word := range c.toBitmap().words {
+					buf = binary.LittleEndian.AppendUint64(buf, word)
+				}
+			} else {
+				for _, x := range c.values {
+					buf = binary.LittleEndian.AppendUint16(buf, x)
+				}
+			}
+		}
+	}
+	return buf, nil
+}
+
+var errRoaringTruncated = errors.New("gollect: RoaringBitmap.UnmarshalBinary - truncated data")
+
+var errRoaringCardinality = errors.New("gollect: RoaringBitmap.UnmarshalBinary - cardinality does not match the container")
+
+// UnmarshalBinary decodes a RoaringBitmap from the portable Roaring serialization format.
+func (r *RoaringBitmap) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return errRoaringTruncated
+	}
+	cookie := binary.LittleEndian.Uint32(data)
+	pos := 4
+	var size int
+	var run_flags []byte
+	if cookie&0xFFFF == roaringSerialCookie {
+		size = int(cookie>>16) + 1
+		if len(data) < pos+(size+7)/8 {
+			return errRoaringTruncated
+		}
+		run_flags = data[pos : pos+(size+7)/8]
+		pos += len(run_flags)
+	} else if cookie == roaringSerialCookieNoRuns {
+		if len(data) < 8 {
+			return errRoaringTruncated
+		}
+		size = int(binary.LittleEndian.Uint32(data[4:]))
+		pos += 4
+	} else {
+		return fmt.Errorf("gollect: RoaringBitmap.UnmarshalBinary - unknown cookie %v", cookie)
+	}
+	if len(data) < pos+4*size {
+		return errRoaringTruncated
+	}
+	header := data[pos : pos+4*size]
+	pos += 4 * size
+	if run_flags == nil || size >= roaringNoOffsetThreshold {
+		pos += 4 * size
+	}
+
+	result := RoaringBitmap{keys: make([]uint16, size), containers: make([]roaringContainer, size)}
+	for idx := 0; idx < size; idx++ {
+		result.keys[idx] = binary.LittleEndian.Uint16(header[4*idx:])
+		if idx > 0 && result.keys[idx] <= result.keys[idx-1] {
+			return errors.New("gollect: RoaringBitmap.UnmarshalBinary - keys are not sorted")
+		}
+		card := int(binary.LittleEndian.Uint16(header[4*idx+2:])) + 1
+		if run_flags != nil && run_flags[idx/8]&(1<<(idx%8)) != 0 {
+			if len(data) < pos+2 {
+				return errRoaringTruncated
+			}
+			num_runs := int(binary.LittleEndian.Uint16(data[pos:]))
+			pos += 2
+			if num_runs == 0 {
+				return errors.New("gollect: RoaringBitmap.UnmarshalBinary - run container without runs")
+			}
+			if len(data) < pos+4*num_runs {
+				return errRoaringTruncated
+			}
+			c := &roaringRunContainer{runs: make([]roaringRun, num_runs)}
+			for run := 0; run < num_runs; run++ {
+				start := binary.LittleEndian.Uint16(data[pos:])
+				length := binary.LittleEndian.Uint16(data[pos+2:])
+				if int(start)+int(length) > 0xFFFF {
+					return errors.New("gollect: RoaringBitmap.UnmarshalBinary - run out of range")
+				}
+				if run > 0 && start <= c.runs[run-1].last {
+					return errors.New("gollect: RoaringBitmap.UnmarshalBinary - runs are not sorted")
+				}
+				c.runs[run] = roaringRun{start: start, last: start + length}
+				pos += 4
+			}
+			if c.cardinality() != card {
+				return errRoaringCardinality
+			}
+			result.containers[idx] = c
+		} else if card <= roaringArrayMaxSize {
+			if len(data) < pos+2*card {
+				return errRoaringTruncated
+			}
+			c := &roaringArrayContainer{values: make([]uint16, card)}
+			for i := range c.values {
+				c.values[i] = binary.LittleEndian.Uint16(data[pos:])
+				if i > 0 && c.values[i] <= c.values[i-1] {
+					return errors.New("gollect: RoaringBitmap.UnmarshalBinary - values are not sorted")
+				}
+				pos += 2
+			}
+			result.containers[idx] = c
+		} else {
+			if len(data) < pos+8*roaringBitmapWords {
+				return errRoaringTruncated
+			}
+			c := &roaringBitmapContainer{}
+			for i := range c.words {
+				c.words[i] = binary.LittleEndian.Uint64(data[pos:])
+				pos += 8
+			}
+			c.recount()
+			if c.cardinality() != card {
+				return errRoaringCardinality
+			}
+			result.containers[idx] = c
+		}
+	}
+	*r = result
+	return nil
+}
@@ -0,0 +1,235 @@
+package gollect
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestRoaringBitmapAddRemove(t *testing.T) {
+	r := NewRoaringBitmap()
+	if !r.Add(5) || r.Add(5) || !r.Add(1<<20) || !r.Add(3) {
+		t.Fatalf("Add returned the wrong result")
+	}
+	if r.String() != "{3, 5, 1048576}" || r.Cardinality() != 3 {
+		t.Fatalf("RoaringBitmap should be {3, 5, 1048576}, got %v", r.String())
+	}
+	if !r.Contains(1<<20) || r.Contains(4) {
+		t.Fatalf("Contains returned the wrong result")
+	}
+	if !r.Remove(1<<20) || r.Remove(1<<20) || len(r.keys) != 1 {
+		t.Fatalf("Remove should have dropped the empty container")
+	}
+}
+
+func TestRoaringBitmapContainers(t *testing.T) {
+	r := NewRoaringBitmap()
+	for x := uint32(0); x < 10000; x += 2 {
+		r.Add(x)
+	}
+	if _, ok := r.containers[0].(*roaringBitmapContainer); !ok {
+		t.Fatalf("Container should have become a bitmap")
+	}
+	for x := uint32(0); x < 2000; x += 2 {
+		r.Remove(x)
+	}
+	if _, ok := r.containers[0].(*roaringArrayContainer); !ok {
+		t.Fatalf("Container should have become an array")
+	}
+
+	runs := NewRoaringBitmap()
+	for x := uint32(100); x < 60000; x++ {
+		runs.Add(x)
+	}
+	runs.RunOptimize()
+	c, ok := runs.containers[0].(*roaringRunContainer)
+	if !ok || len(c.runs) != 1 {
+		t.Fatalf("Container should have become a single run")
+	}
+	runs.Remove(200)
+	runs.Add(99)
+	runs.Add(200)
+	runs.Remove(60000 - 1)
+	if len(c.runs) != 1 || runs.Cardinality() != 60000-100 {
+		t.Fatalf("Run container has the wrong contents, got %v runs", len(c.runs))
+	}
+	if runs.Minimum() != 99 || runs.Maximum() != 59998 {
+		t.Fatalf("Minimum and Maximum should be 99 and 59998, got %v and %v", runs.Minimum(), runs.Maximum())
+	}
+}
+
+func TestRoaringBitmapSetOperations(t *testing.T) {
+	a := NewRoaringBitmap()
+	b := NewRoaringBitmap()
+	for x := uint32(0); x < 200000; x += 3 {
+		a.Add(x)
+	}
+	for x := uint32(0); x < 200000; x += 5 {
+		b.Add(x)
+	}
+	b.Add(1 << 30)
+	b.RunOptimize()
+	union := a.Union(b)
+	intersection := a.Intersection(b)
+	difference := a.Difference(b)
+	for x := uint32(0); x < 200000; x++ {
+		in_a, in_b := x%3 == 0, x%5 == 0
+		if union.Contains(x) != (in_a || in_b) {
+			t.Fatalf("Union is wrong at %v", x)
+		}
+		if intersection.Contains(x) != (in_a && in_b) {
+			t.Fatalf("Intersection is wrong at %v", x)
+		}
+		if difference.Contains(x) != (in_a && !in_b) {
+			t.Fatalf("Difference is wrong at %v", x)
+		}
+	}
+	if !union.Contains(1<<30) || intersection.Contains(1<<30) || difference.Contains(1<<30) {
+		t.Fatalf("Set operations are wrong for keys only in one bitmap")
+	}
+	if union.Cardinality() != a.Cardinality()+b.Cardinality()-intersection.Cardinality() {
+		t.Fatalf("Union has the wrong cardinality, got %v", union.Cardinality())
+	}
+}
+
+func TestRoaringBitmapRankSelect(t *testing.T) {
+	r := NewRoaringBitmapFromData(1, 10, 100, 70000, 70001)
+	if r.Rank(0) != 0 || r.Rank(10) != 2 || r.Rank(69999) != 3 || r.Rank(1<<31) != 5 {
+		t.Fatalf("Rank returned the wrong result")
+	}
+	value, found := r.Select(3)
+	if !found || value != 70000 {
+		t.Fatalf("Select(3) should be 70000, got %v", value)
+	}
+	if _, found := r.Select(5); found {
+		t.Fatalf("Select(5) should be out of range")
+	}
+	for idx := uint64(0); idx < r.Cardinality(); idx++ {
+		value, _ := r.Select(idx)
+		if r.Rank(value) != idx+1 {
+			t.Fatalf("Rank and Select disagree at %v", idx)
+		}
+	}
+}
+
+func TestRoaringBitmapVisit(t *testing.T) {
+	r := NewRoaringBitmapFromNVector(NewNVectorFromData[uint32](9, 1, 1<<17, 4))
+	sum := uint32(0)
+	r.Visit(func(value *uint32, break_out *bool) {
+		sum += *value
+		*break_out = *value == 4
+	})
+	if sum != 5 {
+		t.Fatalf("Visit should have stopped after 4, got sum %v", sum)
+	}
+	v := r.ToNVector()
+	expected := NewNVectorFromData[uint32](1, 4, 9, 1<<17)
+	if v.String() != expected.String() {
+		t.Fatalf("ToNVector returned %v", v.String())
+	}
+}
+
+func TestRoaringBitmapSerialization(t *testing.T) {
+	r := NewRoaringBitmap()
+	for x := uint32(0); x < 100; x++ {
+		r.Add(x)
+	}
+	for x := uint32(1 << 16); x < 1<<16+10000; x++ {
+		r.Add(x)
+	}
+	r.Add(3 << 16)
+	r.Add(5 << 16)
+	r.Add(1 << 31)
+	for _, optimize := range []bool{false, true} {
+		if optimize {
+			r.RunOptimize()
+		}
+		data, err := r.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary failed: %v", err)
+		}
+		other := NewRoaringBitmap()
+		if err := other.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary failed: %v", err)
+		}
+		if other.String() != r.String() {
+			t.Fatalf("Round trip changed the contents")
+		}
+		if err := other.UnmarshalBinary(data[:len(data)-1]); err == nil {
+			t.Fatalf("UnmarshalBinary should have failed on truncated data")
+		}
+	}
+}
+
+func TestRoaringBitmapPortableFormat(t *testing.T) {
+	// Produced by the reference implementation for {1, 2, 3, 1000}.
+	data := []byte{
+		0x3a, 0x30, 0, 0, 1, 0, 0, 0,
+		0, 0, 3, 0,
+		16, 0, 0, 0,
+		1, 0, 2, 0, 3, 0, 0xe8, 0x03,
+	}
+	r := NewRoaringBitmap()
+	if err := r.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if r.String() != "{1, 2, 3, 1000}" {
+		t.Fatalf("RoaringBitmap should be {1, 2, 3, 1000}, got %v", r.String())
+	}
+	encoded, _ := r.MarshalBinary()
+	if string(encoded) != string(data) {
+		t.Fatalf("MarshalBinary should match the reference encoding, got %v", encoded)
+	}
+}
+
+func TestRoaringBitmapUnmarshalCorrupt(t *testing.T) {
+	r := NewRoaringBitmapFromData(3, 5)
+	data, _ := r.MarshalBinary()
+	other := NewRoaringBitmap()
+
+	// Cookie, container count, key and cardinality, offset, then the array values.
+	corrupt := append([]byte{}, data...)
+	binary.LittleEndian.PutUint16(corrupt[16:], 5)
+	binary.LittleEndian.PutUint16(corrupt[18:], 3)
+	if err := other.UnmarshalBinary(corrupt); err == nil {
+		t.Fatalf("UnmarshalBinary should have failed on unsorted array values")
+	}
+
+	r = NewRoaringBitmap()
+	for x := uint32(0); x < 10; x++ {
+		r.Add(x)
+		r.Add(x + 20)
+	}
+	r.RunOptimize()
+	data, _ = r.MarshalBinary()
+	if err := other.UnmarshalBinary(data); err != nil || other.String() != r.String() {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+
+	// Cookie, run flags, key and cardinality minus one, then the run count and the runs.
+	corrupt = append([]byte{}, data...)
+	binary.LittleEndian.PutUint16(corrupt[9:], 0)
+	if err := other.UnmarshalBinary(corrupt[:11]); err == nil {
+		t.Fatalf("UnmarshalBinary should have failed on a run container without runs")
+	}
+	corrupt = append([]byte{}, data...)
+	binary.LittleEndian.PutUint16(corrupt[15:], 5)
+	if err := other.UnmarshalBinary(corrupt); err == nil {
+		t.Fatalf("UnmarshalBinary should have failed on overlapping runs")
+	}
+	corrupt = append([]byte{}, data...)
+	binary.LittleEndian.PutUint16(corrupt[7:], 29)
+	if err := other.UnmarshalBinary(corrupt); err == nil {
+		t.Fatalf("UnmarshalBinary should have failed on a wrong run container cardinality")
+	}
+
+	r = NewRoaringBitmap()
+	for x := uint32(0); x < 5000; x++ {
+		r.Add(x)
+	}
+	data, _ = r.MarshalBinary()
+	corrupt = append([]byte{}, data...)
+	binary.LittleEndian.PutUint16(corrupt[10:], 4998)
+	if err := other.UnmarshalBinary(corrupt); err == nil {
+		t.Fatalf("UnmarshalBinary should have failed on a wrong bitmap container cardinality")
+	}
+}
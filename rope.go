@@ -0,0 +1,521 @@
+package gollect
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ropeLeafSize is the largest number of bytes stored in one leaf of a Rope.
+const ropeLeafSize = 1024
+
+// ropeNode is an immutable node of a Rope. Leaves hold a chunk of text, and branches hold the
+// totals of their subtree, so that any position can be found in O(log n) time.
+type ropeNode struct {
+	left   *ropeNode
+	right  *ropeNode
+	text   string
+	bytes  int
+	runes  int
+	lines  int
+	height int
+}
+
+func newRopeLeaf(text string) *ropeNode {
+	return &ropeNode{text: text, bytes: len(text), runes: utf8.RuneCountInString(text), lines: strings.Count(text, "\n"), height: 1}
+}
+
+func newRopeBranch(left *ropeNode, right *ropeNode) *ropeNode {
+	height := ropeHeight(left)
+	if ropeHeight(right) > height {
+		height = ropeHeight(right)
+	}
+	return &ropeNode{
+		left:   left,
+		right:  right,
+		bytes:  left.bytes + right.bytes,
+		runes:  left.runes + right.runes,
+		lines:  left.lines + right.lines,
+		height: height + 1,
+	}
+}
+
+func ropeHeight(node *ropeNode) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+func (node *ropeNode) isLeaf() bool {
+	return node.left == nil
+}
+
+// ropeBuild builds a balanced tree of leaves holding text.
+func ropeBuild(text string) *ropeNode {
+	if len(text) == 0 {
+		return nil
+	}
+	leaves := []*ropeNode{}
+	for len(text) > 0 {
+		end := len(text)
+		if end > ropeLeafSize {
+			// Never split a valid rune between two leaves. Invalid UTF-8 may have no rune start
+			// nearby, in which case it is split at the leaf size anyway.
+			end = ropeLeafSize
+			for end > ropeLeafSize-utf8.UTFMax && !utf8.RuneStart(text[end]) {
+				end--
+			}
+			if !utf8.RuneStart(text[end]) {
+				end = ropeLeafSize
+			}
+		}
+		leaves = append(leaves, newRopeLeaf(text[:end]))
+		text = text[end:]
+	}
+	var build func(leaves []*ropeNode) *ropeNode
+	build = func(leaves []*ropeNode) *ropeNode {
+		if len(leaves) == 1 {
+			return leaves[0]
+		}
+		middle := len(leaves) / 2
+		return newRopeBranch(build(leaves[:middle]), build(leaves[middle:]))
+	}
+	return build(leaves)
+}
+
+func ropeRotateLeft(node *ropeNode) *ropeNode {
+	right := node.right
+	return newRopeBranch(newRopeBranch(node.left, right.left), right.right)
+}
+
+func ropeRotateRight(node *ropeNode) *ropeNode {
+	left := node.left
+	return newRopeBranch(left.left, newRopeBranch(left.right, node.right))
+}
+
+// ropeBalance restores the AVL invariant at a branch whose children differ in height by at most
+// two.
+func ropeBalance(node *ropeNode) *ropeNode {
+	left, right := ropeHeight(node.left), ropeHeight(node.right)
+	if left > right+1 {
+		if ropeHeight(node.left.left) < ropeHeight(node.left.right) {
+			node = newRopeBranch(ropeRotateLeft(node.left), node.right)
+		}
+		return ropeRotateRight(node)
+	} else if right > left+1 {
+		if ropeHeight(node.right.right) < ropeHeight(node.right.left) {
+			node = newRopeBranch(node.left, ropeRotateRight(node.right))
+		}
+		return ropeRotateLeft(node)
+	}
+	return node
+}
+
+// ropeJoin concatenates two trees in O(|height difference|) time.
+func ropeJoin(left *ropeNode, right *ropeNode) *ropeNode {
+	if left == nil {
+		return right
+	} else if right == nil {
+		return left
+	}
+	if left.height > right.height+1 {
+		return ropeBalance(newRopeBranch(left.left, ropeJoin(left.right, right)))
+	} else if right.height > left.height+1 {
+		return ropeBalance(newRopeBranch(ropeJoin(left, right.left), right.right))
+	}
+	if left.isLeaf() && right.isLeaf() && left.bytes+right.bytes <= ropeLeafSize {
+		return newRopeLeaf(left.text + right.text)
+	}
+	return newRopeBranch(left, right)
+}
+
+// ropeSplit splits a tree at a byte offset, which must be at the start of a rune.
+func ropeSplit(node *ropeNode, offset int) (left *ropeNode, right *ropeNode) {
+	if node == nil {
+		return nil, nil
+	}
+	if offset <= 0 {
+		return nil, node
+	} else if offset >= node.bytes {
+		return node, nil
+	}
+	if node.isLeaf() {
+		return newRopeLeaf(node.text[:offset]), newRopeLeaf(node.text[offset:])
+	}
+	if offset < node.left.bytes {
+		left, right = ropeSplit(node.left, offset)
+		return left, ropeJoin(right, node.right)
+	}
+	left, right = ropeSplit(node.right, offset-node.left.bytes)
+	return ropeJoin(node.left, left), right
+}
+
+// runeToByte returns the byte offset of the rune at index.
+func (node *ropeNode) runeToByte(index int) int {
+	offset := 0
+	for node != nil && !node.isLeaf() {
+		if index < node.left.runes {
+			node = node.left
+		} else {
+			index -= node.left.runes
+			offset += node.left.bytes
+			node = node.right
+		}
+	}
+	if node != nil {
+		for idx := range node.text {
+			if index == 0 {
+				return offset + idx
+			}
+			index--
+		}
+		return offset + node.bytes
+	}
+	return offset
+}
+
+// byteToRune returns the index of the rune containing the byte at offset.
+func (node *ropeNode) byteToRune(offset int) int {
+	index := 0
+	for node != nil && !node.isLeaf() {
+		if offset < node.left.bytes {
+			node = node.left
+		} else {
+			offset -= node.left.bytes
+			index += node.left.runes
+			node = node.right
+		}
+	}
+	if node != nil {
+		for offset > 0 && offset < len(node.text) && !utf8.RuneStart(node.text[offset]) {
+			offset--
+		}
+		index += utf8.RuneCountInString(node.text[:offset])
+	}
+	return index
+}
+
+// visitLeaves calls visitor for every leaf in order, and returns true if it stopped early.
+func (node *ropeNode) visitLeaves(visitor func(text string) bool) bool {
+	if node == nil {
+		return false
+	}
+	if node.isLeaf() {
+		return !visitor(node.text)
+	}
+	return node.left.visitLeaves(visitor) || node.right.visitLeaves(visitor)
+}
+
+// Rope is a sequence of text stored as a balanced tree of chunks, for editing large documents.
+// Inserting, deleting, slicing, splitting and concatenating take O(log n) time.
+//
+// Positions are rune indices unless stated otherwise. The tree also tracks byte offsets and line
+// breaks, so it can convert between rune indices, byte offsets and line numbers in O(log n) time.
+//
+// The chunks are never modified, so Slice, Split, Concat and copies made with
+// NewRopeFromRope share them with the original, and only cost O(log n) time.
+type Rope struct {
+	root *ropeNode
+}
+
+// NewRope creates a new empty Rope, by value.
+func NewRope() Rope {
+	return Rope{}
+}
+
+// NewRopeFromString creates a new Rope holding text, by value.
+func NewRopeFromString(text string) Rope {
+	return Rope{root: ropeBuild(text)}
+}
+
+// NewRopeFromRunes creates a new Rope holding runes, by value.
+func NewRopeFromRunes(runes ...rune) Rope {
+	return NewRopeFromString(string(runes))
+}
+
+// NewRopeFromRope creates a new Rope holding the same text as another, by value, in O(1) time.
+func NewRopeFromRope(other Rope) Rope {
+	return Rope{root: other.root}
+}
+
+// MakeRope creates a new empty Rope instance.
+func MakeRope() *Rope {
+	return &Rope{}
+}
+
+// MakeRopeFromString creates a new Rope instance holding text.
+func MakeRopeFromString(text string) *Rope {
+	r := NewRopeFromString(text)
+	return &r
+}
+
+// MakeRopeFromRunes creates a new Rope instance holding runes.
+func MakeRopeFromRunes(runes ...rune) *Rope {
+	r := NewRopeFromRunes(runes...)
+	return &r
+}
+
+// MakeRopeFromRope creates a new Rope instance holding the same text as another, in O(1) time.
+func MakeRopeFromRope(other Rope) *Rope {
+	return &Rope{root: other.root}
+}
+
+// Size returns the number of runes in the Rope.
+func (r *Rope) Size() int {
+	if r.root == nil {
+		return 0
+	}
+	return r.root.runes
+}
+
+// ByteSize returns the number of bytes in the UTF-8 encoding of the Rope.
+func (r *Rope) ByteSize() int {
+	if r.root == nil {
+		return 0
+	}
+	return r.root.bytes
+}
+
+// IsEmpty returns true if the Rope is empty.
+func (r *Rope) IsEmpty() bool {
+	return r.root == nil
+}
+
+// LineCount returns the number of lines in the Rope, which is one more than the number of '\n'
+// characters.
+func (r *Rope) LineCount() int {
+	if r.root == nil {
+		return 1
+	}
+	return r.root.lines + 1
+}
+
+func (r *Rope) checkRange(from int, to int, method string) {
+	if from < 0 || to > r.Size() || from > to {
+		panic("ERROR: Rope." + method + " - range out of range")
+	}
+}
+
+// RuneAt returns the rune at index.
+func (r *Rope) RuneAt(index int) rune {
+	if index < 0 || index >= r.Size() {
+		panic("ERROR: Rope.RuneAt - index out of range")
+	}
+	offset := r.root.runeToByte(index)
+	node := r.root
+	for !node.isLeaf() {
+		if offset < node.left.bytes {
+			node = node.left
+		} else {
+			offset -= node.left.bytes
+			node = node.right
+		}
+	}
+	value, _ := utf8.DecodeRuneInString(node.text[offset:])
+	return value
+}
+
+// ByteAt returns the byte at offset in the UTF-8 encoding of the Rope.
+func (r *Rope) ByteAt(offset int) byte {
+	if offset < 0 || offset >= r.ByteSize() {
+		panic("ERROR: Rope.ByteAt - offset out of range")
+	}
+	node := r.root
+	for !node.isLeaf() {
+		if offset < node.left.bytes {
+			node = node.left
+		} else {
+			offset -= node.left.bytes
+			node = node.right
+		}
+	}
+	return node.text[offset]
+}
+
+// RuneToByte returns the byte offset of the rune at index. An index equal to Size returns
+// ByteSize.
+func (r *Rope) RuneToByte(index int) int {
+	r.checkRange(index, index, "RuneToByte")
+	return r.root.runeToByte(index)
+}
+
+// ByteToRune returns the index of the rune containing the byte at offset. An offset equal to
+// ByteSize returns Size.
+func (r *Rope) ByteToRune(offset int) int {
+	if offset < 0 || offset > r.ByteSize() {
+		panic("ERROR: Rope.ByteToRune - offset out of range")
+	}
+	return r.root.byteToRune(offset)
+}
+
+// LineOf returns the line number, starting from 0, of the rune at index.
+func (r *Rope) LineOf(index int) int {
+	r.checkRange(index, index, "LineOf")
+	line := 0
+	node := r.root
+	for node != nil && !node.isLeaf() {
+		if index < node.left.runes {
+			node = node.left
+		} else {
+			index -= node.left.runes
+			line += node.left.lines
+			node = node.right
+		}
+	}
+	if node != nil {
+		for _, c := range node.text {
+			if index == 0 {
+				break
+			}
+			if c == '\n' {
+				line++
+			}
+			index--
+		}
+	}
+	return line
+}
+
+// LineStart returns the index of the first rune of line, starting from 0.
+func (r *Rope) LineStart(line int) int {
+	if line < 0 || line >= r.LineCount() {
+		panic("ERROR: Rope.LineStart - line out of range")
+	}
+	if line == 0 {
+		return 0
+	}
+	// Find the rune after the line-th '\n'
+	index := 0
+	node := r.root
+	for !node.isLeaf() {
+		if line <= node.left.lines {
+			node = node.left
+		} else {
+			line -= node.left.lines
+			index += node.left.runes
+			node = node.right
+		}
+	}
+	for _, c := range node.text {
+		index++
+		if c == '\n' {
+			line--
+			if line == 0 {
+				break
+			}
+		}
+	}
+	return index
+}
+
+// Line returns the text of line, starting from 0, without its trailing '\n'.
+func (r *Rope) Line(line int) string {
+	start := r.LineStart(line)
+	end := r.Size()
+	if line+1 < r.LineCount() {
+		end = r.LineStart(line+1) - 1
+	}
+	slice := r.Slice(start, end)
+	return slice.String()
+}
+
+// Insert inserts text before the rune at index.
+func (r *Rope) Insert(index int, text string) {
+	r.checkRange(index, index, "Insert")
+	left, right := ropeSplit(r.root, r.root.runeToByte(index))
+	r.root = ropeJoin(ropeJoin(left, ropeBuild(text)), right)
+}
+
+// InsertRope inserts the text of other before the rune at index, sharing its chunks.
+func (r *Rope) InsertRope(index int, other Rope) {
+	r.checkRange(index, index, "InsertRope")
+	left, right := ropeSplit(r.root, r.root.runeToByte(index))
+	r.root = ropeJoin(ropeJoin(left, other.root), right)
+}
+
+// Delete removes the runes in the range [from, to).
+func (r *Rope) Delete(from int, to int) {
+	r.checkRange(from, to, "Delete")
+	from_byte, to_byte := r.root.runeToByte(from), r.root.runeToByte(to)
+	left, rest := ropeSplit(r.root, from_byte)
+	_, right := ropeSplit(rest, to_byte-from_byte)
+	r.root = ropeJoin(left, right)
+}
+
+// Slice returns a new Rope holding the runes in the range [from, to).
+func (r *Rope) Slice(from int, to int) Rope {
+	r.checkRange(from, to, "Slice")
+	from_byte, to_byte := r.root.runeToByte(from), r.root.runeToByte(to)
+	_, rest := ropeSplit(r.root, from_byte)
+	middle, _ := ropeSplit(rest, to_byte-from_byte)
+	return Rope{root: middle}
+}
+
+// Split returns two new Ropes, holding the runes before index and the rest.
+func (r *Rope) Split(index int) (left Rope, right Rope) {
+	r.checkRange(index, index, "Split")
+	left.root, right.root = ropeSplit(r.root, r.root.runeToByte(index))
+	return
+}
+
+// Concat returns a new Rope holding the text of this Rope followed by the text of other.
+func (r *Rope) Concat(other Rope) Rope {
+	return Rope{root: ropeJoin(r.root, other.root)}
+}
+
+// Append adds text to the end of the Rope.
+func (r *Rope) Append(text string) {
+	r.root = ropeJoin(r.root, ropeBuild(text))
+}
+
+// Clear removes all the text from the Rope.
+func (r *Rope) Clear() {
+	r.root = nil
+}
+
+// Swap swaps the data of two Ropes.
+func (r *Rope) Swap(other *Rope) {
+	r.root, other.root = other.root, r.root
+}
+
+// Visit calls a function for every rune in the Rope, in order.
+//
+// Note, the visitor receives a copy of each rune, so modifying it has no effect on the Rope.
+func (r *Rope) Visit(visitor CollectionVisitor[rune]) {
+	break_out := false
+	r.root.visitLeaves(func(text string) bool {
+		for _, c := range text {
+			visitor(&c, &break_out)
+			if break_out {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// VisitChunks calls a function for every chunk of text in the Rope, in order. This is much
+// faster than Visit for writing out the text.
+func (r *Rope) VisitChunks(visitor CollectionVisitor[string]) {
+	break_out := false
+	r.root.visitLeaves(func(text string) bool {
+		visitor(&text, &break_out)
+		return !break_out
+	})
+}
+
+// Runes returns an NVector of all the runes in the Rope.
+func (r *Rope) Runes() NVector[rune] {
+	return NVector[rune]{data: []rune(r.String())}
+}
+
+// String returns the text of the Rope.
+func (r *Rope) String() string {
+	var builder strings.Builder
+	builder.Grow(r.ByteSize())
+	r.root.visitLeaves(func(text string) bool {
+		builder.WriteString(text)
+		return true
+	})
+	return builder.String()
+}
@@ -0,0 +1,133 @@
+package gollect
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRopeEditing(t *testing.T) {
+	r := NewRopeFromString("Hello world")
+	r.Insert(5, ",")
+	r.Insert(r.Size(), "!")
+	r.Delete(0, 1)
+	r.Insert(0, "J")
+	if r.String() != "Jello, world!" {
+		t.Fatalf("Rope should be \"Jello, world!\", got \"%v\"", r.String())
+	}
+	left, right := r.Split(6)
+	if left.String() != "Jello," || right.String() != " world!" {
+		t.Fatalf("Split should give \"Jello,\" and \" world!\", got \"%v\" and \"%v\"", left.String(), right.String())
+	}
+	joined := right.Concat(left)
+	if joined.String() != " world!Jello," {
+		t.Fatalf("Concat should give \" world!Jello,\", got \"%v\"", joined.String())
+	}
+	slice := r.Slice(7, 12)
+	if slice.String() != "world" {
+		t.Fatalf("Slice should be \"world\", got \"%v\"", slice.String())
+	}
+	if r.String() != "Jello, world!" {
+		t.Fatalf("Split, Concat and Slice should not modify the Rope, got \"%v\"", r.String())
+	}
+}
+
+func TestRopeLarge(t *testing.T) {
+	expected := []rune{}
+	r := NewRope()
+	for i := 0; i < 2000; i++ {
+		text := "añb€\n"
+		index := (i * 7919) % (len(expected) + 1)
+		r.Insert(index, text)
+		expected = append(expected[:index], append([]rune(text), expected[index:]...)...)
+	}
+	for i := 0; i < 500; i++ {
+		from := (i * 104729) % len(expected)
+		to := from + 3
+		if to > len(expected) {
+			to = len(expected)
+		}
+		r.Delete(from, to)
+		expected = append(expected[:from], expected[to:]...)
+	}
+	if r.String() != string(expected) || r.Size() != len(expected) || r.ByteSize() != len(string(expected)) {
+		t.Fatalf("Rope does not match the expected text")
+	}
+	if r.root.height > 2*ropeHeight(ropeBuild(string(expected)))+2 {
+		t.Fatalf("Rope is unbalanced, height %v", r.root.height)
+	}
+	for _, i := range []int{0, 1, 17, 1000, len(expected) - 1} {
+		if r.RuneAt(i) != expected[i] {
+			t.Fatalf("RuneAt(%v) should be %q, got %q", i, expected[i], r.RuneAt(i))
+		}
+		offset := len(string(expected[:i]))
+		if r.RuneToByte(i) != offset || r.ByteToRune(offset) != i {
+			t.Fatalf("RuneToByte(%v) should be %v, got %v", i, offset, r.RuneToByte(i))
+		}
+		if r.ByteAt(offset) != string(expected)[offset] {
+			t.Fatalf("ByteAt(%v) is wrong", offset)
+		}
+	}
+}
+
+func TestRopeLines(t *testing.T) {
+	text := strings.Repeat("first line\nsecond\n\nläst", 100)
+	r := NewRopeFromString(text)
+	lines := strings.Split(text, "\n")
+	if r.LineCount() != len(lines) {
+		t.Fatalf("LineCount should be %v, got %v", len(lines), r.LineCount())
+	}
+	index := 0
+	for line := range lines {
+		if r.LineStart(line) != index {
+			t.Fatalf("LineStart(%v) should be %v, got %v", line, index, r.LineStart(line))
+		}
+		if r.LineOf(index) != line {
+			t.Fatalf("LineOf(%v) should be %v, got %v", index, line, r.LineOf(index))
+		}
+		if r.Line(line) != lines[line] {
+			t.Fatalf("Line(%v) should be \"%v\", got \"%v\"", line, lines[line], r.Line(line))
+		}
+		index += len([]rune(lines[line])) + 1
+	}
+}
+
+func TestRopeVisit(t *testing.T) {
+	r := NewRopeFromString("añb")
+	r.Append("€c")
+	visited := []rune{}
+	r.Visit(func(value *rune, break_out *bool) {
+		visited = append(visited, *value)
+		*break_out = *value == '€'
+	})
+	if string(visited) != "añb€" {
+		t.Fatalf("Visit should stop after €, got \"%v\"", string(visited))
+	}
+	runes := r.Runes()
+	if runes.Size() != 5 {
+		t.Fatalf("Runes should have 5 elements, got %v", runes.Size())
+	}
+}
+
+func TestRopeOutOfRange(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: Rope.Delete - range out of range" {
+			t.Fatalf("Should have panicked because the range is out of range, got \"%v\"", result)
+		}
+	}()
+	r := NewRopeFromString("abc")
+	r.Delete(2, 4)
+}
+
+func TestRopeInvalidUTF8(t *testing.T) {
+	text := strings.Repeat("\x80", 2000)
+	r := NewRopeFromString(text)
+	if r.String() != text || r.ByteSize() != 2000 || r.Size() != 2000 {
+		t.Fatalf("Rope should hold the invalid bytes unchanged, got %v bytes and %v runes", r.ByteSize(), r.Size())
+	}
+	mixed := strings.Repeat("a€", 300) + text
+	r.Insert(1000, mixed)
+	if r.String() != text[:1000]+mixed+text[1000:] {
+		t.Fatalf("Insert into invalid UTF-8 changed the text")
+	}
+}
@@ -0,0 +1,395 @@
+package gollect
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Monoid is an associative way of combining values, with an identity value that leaves any value
+// unchanged when combined with it. Combine does not need to be commutative.
+type Monoid[T any] struct {
+	Combine  func(left T, right T) T
+	Identity T
+}
+
+// SumMonoid returns a Monoid that adds numbers.
+func SumMonoid[T NativeNumeric]() Monoid[T] {
+	return Monoid[T]{Combine: func(left T, right T) T { return left + right }}
+}
+
+// MinMonoid returns a Monoid that keeps the lowest number. Its Identity is the highest value of
+// T, which is +Inf for floating-point types.
+func MinMonoid[T constraints.Integer | constraints.Float]() Monoid[T] {
+	_, highest := numericBounds[T]()
+	return Monoid[T]{
+		Combine: func(left T, right T) T {
+			if right < left {
+				return right
+			}
+			return left
+		},
+		Identity: highest,
+	}
+}
+
+// MaxMonoid returns a Monoid that keeps the highest number. Its Identity is the lowest value of
+// T, which is -Inf for floating-point types.
+func MaxMonoid[T constraints.Integer | constraints.Float]() Monoid[T] {
+	lowest, _ := numericBounds[T]()
+	return Monoid[T]{
+		Combine: func(left T, right T) T {
+			if right > left {
+				return right
+			}
+			return left
+		},
+		Identity: lowest,
+	}
+}
+
+// numericBounds returns the lowest and highest values of T, or -Inf and +Inf for floating-point
+// types.
+func numericBounds[T constraints.Integer | constraints.Float]() (lowest T, highest T) {
+	var zero T
+	typ := reflect.TypeOf(zero)
+	bits := 8 * typ.Size()
+	switch typ.Kind() {
+	case reflect.Float32, reflect.Float64:
+		low, high := math.Inf(-1), math.Inf(1)
+		return T(low), T(high)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		high := int64(1)<<(bits-1) - 1
+		low := -high - 1
+		return T(low), T(high)
+	default:
+		high := ^uint64(0) >> (64 - bits)
+		return 0, T(high)
+	}
+}
+
+// LazyUpdate describes an update that can be applied to a whole range of a SegmentTree at once,
+// such as adding to or assigning every element.
+//
+// Apply returns the aggregate of a range of length elements after update is applied to each of
+// them. Compose returns a single update that has the effect of applying older and then newer.
+type LazyUpdate[T any] struct {
+	Apply   func(update T, aggregate T, length int) T
+	Compose func(newer T, older T) T
+}
+
+// AddLazyUpdate returns a LazyUpdate that adds to every element, for a SegmentTree using
+// SumMonoid.
+func AddLazyUpdate[T constraints.Integer | constraints.Float]() LazyUpdate[T] {
+	return LazyUpdate[T]{
+		Apply:   func(update T, aggregate T, length int) T { return aggregate + update*T(length) },
+		Compose: func(newer T, older T) T { return newer + older },
+	}
+}
+
+// AddMinMaxLazyUpdate returns a LazyUpdate that adds to every element, for a SegmentTree using
+// MinMonoid or MaxMonoid.
+func AddMinMaxLazyUpdate[T constraints.Integer | constraints.Float]() LazyUpdate[T] {
+	return LazyUpdate[T]{
+		Apply:   func(update T, aggregate T, length int) T { return aggregate + update },
+		Compose: func(newer T, older T) T { return newer + older },
+	}
+}
+
+// AssignLazyUpdate returns a LazyUpdate that sets every element to the update, for a SegmentTree
+// using MinMonoid, MaxMonoid or any other Monoid where combining a value with itself gives the
+// same value.
+func AssignLazyUpdate[T any]() LazyUpdate[T] {
+	return LazyUpdate[T]{
+		Apply:   func(update T, aggregate T, length int) T { return update },
+		Compose: func(newer T, older T) T { return newer },
+	}
+}
+
+// SegmentTree holds a sequence of values, and combines any range of them with a Monoid in
+// O(log n) time, with O(log n) point updates.
+//
+// If it has a LazyUpdate, whole ranges can also be updated in O(log n) time.
+type SegmentTree[T any] struct {
+	monoid  Monoid[T]
+	update  *LazyUpdate[T]
+	size    int
+	leaves  int
+	tree    []T
+	lazy    []T
+	pending []bool
+}
+
+func newSegmentTree[T any](monoid Monoid[T], update *LazyUpdate[T], values []T) SegmentTree[T] {
+	if monoid.Combine == nil {
+		panic("ERROR: SegmentTree - nil Combine")
+	}
+	leaves := 1
+	for leaves < len(values) {
+		leaves *= 2
+	}
+	s := SegmentTree[T]{monoid: monoid, update: update, size: len(values), leaves: leaves, tree: make([]T, 2*leaves)}
+	copy(s.tree[leaves:], values)
+	for idx := leaves + len(values); idx < 2*leaves; idx++ {
+		s.tree[idx] = monoid.Identity
+	}
+	for node := leaves - 1; node >= 1; node-- {
+		s.tree[node] = monoid.Combine(s.tree[2*node], s.tree[2*node+1])
+	}
+	if update != nil {
+		s.lazy = make([]T, leaves)
+		s.pending = make([]bool, leaves)
+	}
+	return s
+}
+
+// NewSegmentTree creates a new SegmentTree holding values and combining them with monoid, by
+// value, in O(n) time.
+func NewSegmentTree[T any](monoid Monoid[T], values ...T) SegmentTree[T] {
+	return newSegmentTree(monoid, nil, values)
+}
+
+// NewSegmentTreeFromNVector creates a new SegmentTree holding the elements of other and combining
+// them with monoid, by value, in O(n) time.
+func NewSegmentTreeFromNVector[T NativeEquatable](monoid Monoid[T], other NVector[T]) SegmentTree[T] {
+	return newSegmentTree(monoid, nil, other.data)
+}
+
+// NewSegmentTreeWithLazyUpdate creates a new SegmentTree holding values, combining them with
+// monoid and supporting range updates with update, by value, in O(n) time.
+func NewSegmentTreeWithLazyUpdate[T any](monoid Monoid[T], update LazyUpdate[T], values ...T) SegmentTree[T] {
+	return newSegmentTree(monoid, &update, values)
+}
+
+// NewSegmentTreeWithLazyUpdateFromNVector creates a new SegmentTree holding the elements of
+// other, combining them with monoid and supporting range updates with update, by value, in O(n)
+// time.
+func NewSegmentTreeWithLazyUpdateFromNVector[T NativeEquatable](monoid Monoid[T], update LazyUpdate[T], other NVector[T]) SegmentTree[T] {
+	return newSegmentTree(monoid, &update, other.data)
+}
+
+// NewSegmentTreeFromSegmentTree creates a new SegmentTree with the same elements as another, by
+// value.
+func NewSegmentTreeFromSegmentTree[T any](other SegmentTree[T]) SegmentTree[T] {
+	s := other
+	s.tree = append([]T{}, other.tree...)
+	if other.update != nil {
+		s.lazy = append([]T{}, other.lazy...)
+		s.pending = append([]bool{}, other.pending...)
+	}
+	return s
+}
+
+// MakeSegmentTree creates a new SegmentTree instance holding values and combining them with
+// monoid, in O(n) time.
+func MakeSegmentTree[T any](monoid Monoid[T], values ...T) *SegmentTree[T] {
+	s := NewSegmentTree(monoid, values...)
+	return &s
+}
+
+// MakeSegmentTreeFromNVector creates a new SegmentTree instance holding the elements of other and
+// combining them with monoid, in O(n) time.
+func MakeSegmentTreeFromNVector[T NativeEquatable](monoid Monoid[T], other NVector[T]) *SegmentTree[T] {
+	s := NewSegmentTreeFromNVector(monoid, other)
+	return &s
+}
+
+// MakeSegmentTreeWithLazyUpdate creates a new SegmentTree instance holding values, combining them
+// with monoid and supporting range updates with update, in O(n) time.
+func MakeSegmentTreeWithLazyUpdate[T any](monoid Monoid[T], update LazyUpdate[T], values ...T) *SegmentTree[T] {
+	s := NewSegmentTreeWithLazyUpdate(monoid, update, values...)
+	return &s
+}
+
+// MakeSegmentTreeWithLazyUpdateFromNVector creates a new SegmentTree instance holding the
+// elements of other, combining them with monoid and supporting range updates with update, in
+// O(n) time.
+func MakeSegmentTreeWithLazyUpdateFromNVector[T NativeEquatable](monoid Monoid[T], update LazyUpdate[T], other NVector[T]) *SegmentTree[T] {
+	s := NewSegmentTreeWithLazyUpdateFromNVector(monoid, update, other)
+	return &s
+}
+
+// MakeSegmentTreeFromSegmentTree creates a new SegmentTree instance with the same elements as
+// another.
+func MakeSegmentTreeFromSegmentTree[T any](other SegmentTree[T]) *SegmentTree[T] {
+	s := NewSegmentTreeFromSegmentTree(other)
+	return &s
+}
+
+// Size returns the number of elements in the SegmentTree.
+func (s *SegmentTree[T]) Size() int {
+	return s.size
+}
+
+// IsEmpty returns true if the SegmentTree has no elements.
+func (s *SegmentTree[T]) IsEmpty() bool {
+	return s.size == 0
+}
+
+// applyNode applies update to node, which covers length elements.
+func (s *SegmentTree[T]) applyNode(node int, update T, length int) {
+	s.tree[node] = s.update.Apply(update, s.tree[node], length)
+	if node < s.leaves {
+		if s.pending[node] {
+			s.lazy[node] = s.update.Compose(update, s.lazy[node])
+		} else {
+			s.lazy[node] = update
+			s.pending[node] = true
+		}
+	}
+}
+
+// pushDown passes the pending update of node, which covers length elements, to its children.
+func (s *SegmentTree[T]) pushDown(node int, length int) {
+	if s.update != nil && node < s.leaves && s.pending[node] {
+		s.applyNode(2*node, s.lazy[node], length/2)
+		s.applyNode(2*node+1, s.lazy[node], length/2)
+		s.pending[node] = false
+	}
+}
+
+// pushPath passes every pending update on the path from the root down to the leaf at index.
+func (s *SegmentTree[T]) pushPath(index int) {
+	if s.update == nil {
+		return
+	}
+	length := s.leaves
+	for node := 1; node < s.leaves; length /= 2 {
+		s.pushDown(node, length)
+		if index&(length/2) == 0 {
+			node = 2 * node
+		} else {
+			node = 2*node + 1
+		}
+	}
+}
+
+func (s *SegmentTree[T]) check(index int, method string) {
+	if index < 0 || index >= s.size {
+		panic("ERROR: SegmentTree." + method + " - index out of range")
+	}
+}
+
+// At returns the element at index.
+func (s *SegmentTree[T]) At(index int) T {
+	s.check(index, "At")
+	s.pushPath(index)
+	return s.tree[s.leaves+index]
+}
+
+// Set sets the element at index to value.
+func (s *SegmentTree[T]) Set(index int, value T) {
+	s.check(index, "Set")
+	s.pushPath(index)
+	node := s.leaves + index
+	s.tree[node] = value
+	for node /= 2; node >= 1; node /= 2 {
+		s.tree[node] = s.monoid.Combine(s.tree[2*node], s.tree[2*node+1])
+	}
+}
+
+func (s *SegmentTree[T]) query(node int, node_from int, node_to int, from int, to int) T {
+	if to <= node_from || node_to <= from {
+		return s.monoid.Identity
+	}
+	if from <= node_from && node_to <= to {
+		return s.tree[node]
+	}
+	s.pushDown(node, node_to-node_from)
+	middle := (node_from + node_to) / 2
+	return s.monoid.Combine(s.query(2*node, node_from, middle, from, to), s.query(2*node+1, middle, node_to, from, to))
+}
+
+// Query returns the elements in the range [from, to) combined in order, or the identity if the
+// range is empty.
+func (s *SegmentTree[T]) Query(from int, to int) T {
+	if from < 0 || to > s.size || from > to {
+		panic("ERROR: SegmentTree.Query - range out of range")
+	}
+	if from == to {
+		return s.monoid.Identity
+	}
+	return s.query(1, 0, s.leaves, from, to)
+}
+
+// All returns all the elements combined in order.
+func (s *SegmentTree[T]) All() T {
+	if s.size == 0 {
+		return s.monoid.Identity
+	}
+	return s.tree[1]
+}
+
+func (s *SegmentTree[T]) updateRange(node int, node_from int, node_to int, from int, to int, update T) {
+	if to <= node_from || node_to <= from {
+		return
+	}
+	if from <= node_from && node_to <= to {
+		s.applyNode(node, update, node_to-node_from)
+		return
+	}
+	s.pushDown(node, node_to-node_from)
+	middle := (node_from + node_to) / 2
+	s.updateRange(2*node, node_from, middle, from, to, update)
+	s.updateRange(2*node+1, middle, node_to, from, to, update)
+	s.tree[node] = s.monoid.Combine(s.tree[2*node], s.tree[2*node+1])
+}
+
+// UpdateRange applies update to every element in the range [from, to).
+//
+// Panics if the SegmentTree has no LazyUpdate.
+func (s *SegmentTree[T]) UpdateRange(from int, to int, update T) {
+	if s.update == nil {
+		panic("ERROR: SegmentTree.UpdateRange - no LazyUpdate")
+	}
+	if from < 0 || to > s.size || from > to {
+		panic("ERROR: SegmentTree.UpdateRange - range out of range")
+	}
+	if from < to {
+		s.updateRange(1, 0, s.leaves, from, to, update)
+	}
+}
+
+// Swap swaps the data of two SegmentTrees.
+func (s *SegmentTree[T]) Swap(other *SegmentTree[T]) {
+	*s, *other = *other, *s
+}
+
+// Clone returns a new SegmentTree with copies of the elements, that does not share storage with
+// this one.
+//
+// Elements are copied by assignment, so pointers, slices and maps inside them are still shared.
+// Use DeepClone to copy those too.
+func (s *SegmentTree[T]) Clone() SegmentTree[T] {
+	return NewSegmentTreeFromSegmentTree(*s)
+}
+
+// DeepClone returns a new SegmentTree with deep copies of the elements and pending updates, see
+// Vector.DeepClone.
+func (s *SegmentTree[T]) DeepClone() SegmentTree[T] {
+	result := s.Clone()
+	for idx := range result.tree {
+		result.tree[idx] = DeepCopy(result.tree[idx])
+	}
+	for idx := range result.lazy {
+		result.lazy[idx] = DeepCopy(result.lazy[idx])
+	}
+	return result
+}
+
+// String returns a string representation of the SegmentTree and it's elements.
+func (s *SegmentTree[T]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	for idx := 0; idx < s.size; idx++ {
+		if idx > 0 {
+			fmt.Fprintf(&builder, ", ")
+		}
+		fmt.Fprintf(&builder, "%v", s.At(idx))
+	}
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
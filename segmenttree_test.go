@@ -0,0 +1,155 @@
+package gollect
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSegmentTreeSum(t *testing.T) {
+	s := NewSegmentTreeFromNVector(SumMonoid[int](), NewNVectorFromData(3, 1, 4, 1, 5, 9, 2))
+	if s.Query(1, 5) != 11 || s.All() != 25 || s.Query(3, 3) != 0 {
+		t.Fatalf("Query returned the wrong sums for %v", s.String())
+	}
+	s.Set(6, 10)
+	if s.All() != 33 || s.At(6) != 10 {
+		t.Fatalf("Set is wrong, got %v", s.String())
+	}
+}
+
+func TestSegmentTreeNonCommutative(t *testing.T) {
+	concat := Monoid[string]{Combine: func(left string, right string) string { return left + right }}
+	s := NewSegmentTree(concat, "a", "b", "c", "d", "e")
+	if s.Query(1, 4) != "bcd" || s.All() != "abcde" {
+		t.Fatalf("Query should keep the order of the elements, got %v", s.Query(1, 4))
+	}
+}
+
+func TestSegmentTreeLazyAssign(t *testing.T) {
+	min := Monoid[int]{
+		Combine: func(left int, right int) int {
+			if left < right {
+				return left
+			}
+			return right
+		},
+		Identity: math.MaxInt,
+	}
+	assign := LazyUpdate[int]{
+		Apply:   func(update int, aggregate int, length int) int { return update },
+		Compose: func(newer int, older int) int { return newer },
+	}
+	s := NewSegmentTreeWithLazyUpdate(min, assign, 5, 3, 8, 6, 7)
+	s.UpdateRange(1, 3, 9)
+	if s.Query(0, 5) != 5 || s.Query(1, 3) != 9 || s.String() != "{5, 9, 9, 6, 7}" {
+		t.Fatalf("UpdateRange should have assigned 9, got %v", s.String())
+	}
+}
+
+func TestSegmentTreeNoLazyUpdate(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: SegmentTree.UpdateRange - no LazyUpdate" {
+			t.Fatalf("Should have panicked because there is no LazyUpdate, got \"%v\"", result)
+		}
+	}()
+	s := NewSegmentTree(SumMonoid[int](), 1, 2, 3)
+	s.UpdateRange(0, 2, 1)
+}
+
+func TestSegmentTreeLazyRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(40))
+	values := make([]int64, 77)
+	for idx := range values {
+		values[idx] = int64(rng.Intn(100))
+	}
+	s := NewSegmentTreeWithLazyUpdateFromNVector(SumMonoid[int64](), AddLazyUpdate[int64](), NewNVectorFromData(values...))
+	for step := 0; step < 1000; step++ {
+		from := rng.Intn(len(values) + 1)
+		to := from + rng.Intn(len(values)-from+1)
+		switch rng.Intn(3) {
+		case 0:
+			delta := int64(rng.Intn(21) - 10)
+			s.UpdateRange(from, to, delta)
+			for idx := from; idx < to; idx++ {
+				values[idx] += delta
+			}
+		case 1:
+			if from < len(values) {
+				s.Set(from, int64(step))
+				values[from] = int64(step)
+			}
+		default:
+			var expected int64
+			for idx := from; idx < to; idx++ {
+				expected += values[idx]
+			}
+			if got := s.Query(from, to); got != expected {
+				t.Fatalf("Query(%v, %v) should be %v, got %v", from, to, expected, got)
+			}
+		}
+	}
+}
+
+func TestSegmentTreeMinMax(t *testing.T) {
+	lowest := NewSegmentTree(MinMonoid[int8](), 5, -3, 8)
+	highest := NewSegmentTree(MaxMonoid[uint16](), 5, 3, 8)
+	if lowest.All() != -3 || lowest.Query(2, 2) != math.MaxInt8 || highest.All() != 8 || highest.Query(0, 0) != 0 {
+		t.Fatalf("MinMonoid and MaxMonoid returned the wrong values")
+	}
+	empty := NewSegmentTree(MinMonoid[float32]())
+	if !math.IsInf(float64(empty.All()), 1) || !math.IsInf(float64(MaxMonoid[float64]().Identity), -1) {
+		t.Fatalf("The Identity of the floating-point MinMonoid and MaxMonoid should be infinite")
+	}
+	if MinMonoid[int64]().Identity != math.MaxInt64 || MaxMonoid[int64]().Identity != math.MinInt64 {
+		t.Fatalf("The Identity of MinMonoid and MaxMonoid should be the bounds of the type")
+	}
+}
+
+func TestSegmentTreeMinMaxLazyRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for _, assign := range []bool{false, true} {
+		values := make([]int, 37)
+		for idx := range values {
+			values[idx] = rng.Intn(100)
+		}
+		update := AddMinMaxLazyUpdate[int]()
+		if assign {
+			update = AssignLazyUpdate[int]()
+		}
+		lowest := NewSegmentTreeWithLazyUpdate(MinMonoid[int](), update, values...)
+		highest := NewSegmentTreeWithLazyUpdate(MaxMonoid[int](), update, values...)
+		for step := 0; step < 1000; step++ {
+			from := rng.Intn(len(values) + 1)
+			to := from + rng.Intn(len(values)-from+1)
+			if rng.Intn(2) == 0 {
+				delta := rng.Intn(21) - 10
+				lowest.UpdateRange(from, to, delta)
+				highest.UpdateRange(from, to, delta)
+				for idx := from; idx < to; idx++ {
+					if assign {
+						values[idx] = delta
+					} else {
+						values[idx] += delta
+					}
+				}
+				continue
+			}
+			expected_min, expected_max := math.MaxInt, math.MinInt
+			for idx := from; idx < to; idx++ {
+				if values[idx] < expected_min {
+					expected_min = values[idx]
+				}
+				if values[idx] > expected_max {
+					expected_max = values[idx]
+				}
+			}
+			if got := lowest.Query(from, to); got != expected_min {
+				t.Fatalf("Min Query(%v, %v) should be %v, got %v", from, to, expected_min, got)
+			}
+			if got := highest.Query(from, to); got != expected_max {
+				t.Fatalf("Max Query(%v, %v) should be %v, got %v", from, to, expected_max, got)
+			}
+		}
+	}
+}
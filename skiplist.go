@@ -0,0 +1,495 @@
+package gollect
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+const skipListMaxLevel = 32
+
+// SkipListNode is a node of a SkipList, holding one key/value pair. It is exported so that a
+// NodeAllocator for SkipList nodes can be named, as in NewFreeListAllocator[SkipListNode[K, V]]().
+type SkipListNode[K any, V any] struct {
+	key   K
+	value V
+	next  []*SkipListNode[K, V]
+	span  []int
+}
+
+func newSkipListNode[K any, V any](level int) *SkipListNode[K, V] {
+	return &SkipListNode[K, V]{next: make([]*SkipListNode[K, V], level), span: make([]int, level)}
+}
+
+// skipListRandomLevel returns a random level for a new node, where each level is a quarter as
+// likely as the one below it.
+func skipListRandomLevel(rng *rand.Rand) int {
+	level := 1
+	for level < skipListMaxLevel && rng.Int63()&3 == 0 {
+		level++
+	}
+	return level
+}
+
+// SkipList is an ordered collection of key/value pairs, based on a probabilistic skip list.
+//
+// Put, Get and Erase take O(log n) time on average. Elements can also be accessed by their rank
+// in O(log n) time.
+//
+// The zero value is an empty SkipList ordered by the Ordered implementation of the key type, or
+// by the native `<` operator for the predeclared ordered types.
+//
+// If the values implement the Destructible interface, they will have the Destruct method
+// called on them when they are removed from the SkipList.
+type SkipList[K any, V any] struct {
+	head      *SkipListNode[K, V]
+	level     int
+	size      int
+	compare   Comparator[K]
+	rng       *rand.Rand
+	allocator NodeAllocator[SkipListNode[K, V]]
+}
+
+func newSkipList[K any, V any](compare Comparator[K]) SkipList[K, V] {
+	return SkipList[K, V]{
+		head:    newSkipListNode[K, V](skipListMaxLevel),
+		level:   1,
+		compare: compare,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// NewSkipList creates a new empty SkipList ordered by the native `<` operator, by value.
+func NewSkipList[K constraints.Ordered, V any]() SkipList[K, V] {
+	return newSkipList[K, V](NativeComparator[K]())
+}
+
+// NewSkipListWithComparator creates a new empty SkipList ordered by compare, by value.
+func NewSkipListWithComparator[K any, V any](compare Comparator[K]) SkipList[K, V] {
+	return newSkipList[K, V](compare)
+}
+
+// NewSkipListWithAllocator creates a new empty SkipList ordered by the native `<` operator, that
+// gets its nodes from allocator, by value.
+//
+// A nil allocator allocates every node with new, like a SkipList created any other way. Copies of
+// the SkipList do not use the allocator, as it may not be safe to share, see FreeListAllocator.
+func NewSkipListWithAllocator[K constraints.Ordered, V any](allocator NodeAllocator[SkipListNode[K, V]]) SkipList[K, V] {
+	s := newSkipList[K, V](NativeComparator[K]())
+	s.allocator = allocator
+	return s
+}
+
+// NewSkipListWithComparatorAndAllocator creates a new empty SkipList ordered by compare, that gets
+// its nodes from allocator, by value.
+//
+// See NewSkipListWithAllocator.
+func NewSkipListWithComparatorAndAllocator[K any, V any](compare Comparator[K], allocator NodeAllocator[SkipListNode[K, V]]) SkipList[K, V] {
+	s := newSkipList[K, V](compare)
+	s.allocator = allocator
+	return s
+}
+
+// NewSkipListFromSkipList creates a new SkipList using the key/value pairs of another, by value.
+func NewSkipListFromSkipList[K any, V any](other SkipList[K, V]) SkipList[K, V] {
+	s := newSkipList[K, V](other.compare)
+	other.Visit(func(key *K, value *V, break_out *bool) {
+		s.Put(*key, *value)
+	})
+	return s
+}
+
+// MakeSkipList creates a new empty SkipList instance ordered by the native `<` operator.
+func MakeSkipList[K constraints.Ordered, V any]() *SkipList[K, V] {
+	s := NewSkipList[K, V]()
+	return &s
+}
+
+// MakeSkipListWithComparator creates a new empty SkipList instance ordered by compare.
+func MakeSkipListWithComparator[K any, V any](compare Comparator[K]) *SkipList[K, V] {
+	s := NewSkipListWithComparator[K, V](compare)
+	return &s
+}
+
+// MakeSkipListWithAllocator creates a new empty SkipList instance ordered by the native `<`
+// operator, that gets its nodes from allocator.
+func MakeSkipListWithAllocator[K constraints.Ordered, V any](allocator NodeAllocator[SkipListNode[K, V]]) *SkipList[K, V] {
+	s := NewSkipListWithAllocator[K, V](allocator)
+	return &s
+}
+
+// MakeSkipListWithComparatorAndAllocator creates a new empty SkipList instance ordered by compare,
+// that gets its nodes from allocator.
+func MakeSkipListWithComparatorAndAllocator[K any, V any](compare Comparator[K], allocator NodeAllocator[SkipListNode[K, V]]) *SkipList[K, V] {
+	s := NewSkipListWithComparatorAndAllocator[K, V](compare, allocator)
+	return &s
+}
+
+// MakeSkipListFromSkipList creates a new SkipList instance using the key/value pairs of another.
+func MakeSkipListFromSkipList[K any, V any](other SkipList[K, V]) *SkipList[K, V] {
+	s := NewSkipListFromSkipList(other)
+	return &s
+}
+
+func (s *SkipList[K, V]) newNode(level int) *SkipListNode[K, V] {
+	if s.allocator == nil {
+		return newSkipListNode[K, V](level)
+	}
+	node := s.allocator.Allocate()
+	node.next = make([]*SkipListNode[K, V], level)
+	node.span = make([]int, level)
+	return node
+}
+
+func (s *SkipList[K, V]) freeNode(node *SkipListNode[K, V]) {
+	if s.allocator != nil {
+		s.allocator.Free(node)
+	}
+}
+
+// init sets up a zero-valued SkipList, ordered by the Ordered implementation of the key type or
+// the native `<` operator.
+func (s *SkipList[K, V]) init() {
+	if s.compare == nil {
+		s.compare = resolveComparator[K](nil)
+		if s.compare == nil {
+			panic("ERROR: SkipList - no Comparator for key type")
+		}
+	}
+	if s.head == nil {
+		s.head = newSkipListNode[K, V](skipListMaxLevel)
+		s.level = 1
+	}
+	if s.rng == nil {
+		s.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+}
+
+// IsEmpty returns true if the SkipList is empty.
+func (s *SkipList[K, V]) IsEmpty() bool {
+	return s.size == 0
+}
+
+// Size returns the number of key/value pairs in the SkipList.
+func (s *SkipList[K, V]) Size() int {
+	return s.size
+}
+
+// findPredecessors fills update with the last node before key on every level, and rank with the
+// number of nodes before each of them. It returns the first node on the bottom level whose key
+// is not lesser than key.
+func (s *SkipList[K, V]) findPredecessors(key *K, update *[skipListMaxLevel]*SkipListNode[K, V], rank *[skipListMaxLevel]int) *SkipListNode[K, V] {
+	s.init()
+	node := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		if i == s.level-1 {
+			rank[i] = 0
+		} else {
+			rank[i] = rank[i+1]
+		}
+		for node.next[i] != nil && s.compare(&node.next[i].key, key) < 0 {
+			rank[i] += node.span[i]
+			node = node.next[i]
+		}
+		update[i] = node
+	}
+	return node.next[0]
+}
+
+func (s *SkipList[K, V]) findNode(key *K) *SkipListNode[K, V] {
+	s.init()
+	node := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && s.compare(&node.next[i].key, key) < 0 {
+			node = node.next[i]
+		}
+	}
+	node = node.next[0]
+	if node != nil && s.compare(&node.key, key) == 0 {
+		return node
+	}
+	return nil
+}
+
+// Put sets the value associated with key, replacing any previous value.
+//
+// If a previous value implements the Destructible interface, it will have the Destruct method
+// called on it.
+func (s *SkipList[K, V]) Put(key K, value V) {
+	var update [skipListMaxLevel]*SkipListNode[K, V]
+	var rank [skipListMaxLevel]int
+	if node := s.findPredecessors(&key, &update, &rank); node != nil && s.compare(&node.key, &key) == 0 {
+		if e, isDestructible := interface{}(&node.value).(Destructible); isDestructible {
+			e.Destruct()
+		}
+		node.value = value
+		return
+	}
+
+	level := skipListRandomLevel(s.rng)
+	if level > s.level {
+		for i := s.level; i < level; i++ {
+			rank[i] = 0
+			update[i] = s.head
+			s.head.span[i] = s.size
+		}
+		s.level = level
+	}
+
+	node := s.newNode(level)
+	node.key = key
+	node.value = value
+	for i := 0; i < level; i++ {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+		node.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+	for i := level; i < s.level; i++ {
+		update[i].span[i]++
+	}
+	s.size++
+}
+
+// PutRef sets the value associated with key, replacing any previous value.
+func (s *SkipList[K, V]) PutRef(key *K, value *V) {
+	s.Put(*key, *value)
+}
+
+// Get returns the value associated with key, and whether it was found.
+func (s *SkipList[K, V]) Get(key K) (value V, found bool) {
+	if node := s.findNode(&key); node != nil {
+		return node.value, true
+	}
+	return
+}
+
+// GetRef returns a pointer to the value associated with key, or nil if it was not found.
+func (s *SkipList[K, V]) GetRef(key K) *V {
+	if node := s.findNode(&key); node != nil {
+		return &node.value
+	}
+	return nil
+}
+
+// ContainsKey returns true if the SkipList contains key.
+func (s *SkipList[K, V]) ContainsKey(key K) bool {
+	return s.findNode(&key) != nil
+}
+
+// Erase removes key and its value from the SkipList, and returns true if it was present.
+//
+// If the value implements the Destructible interface, it will have the Destruct method called
+// on it.
+func (s *SkipList[K, V]) Erase(key K) bool {
+	var update [skipListMaxLevel]*SkipListNode[K, V]
+	var rank [skipListMaxLevel]int
+	node := s.findPredecessors(&key, &update, &rank)
+	if node == nil || s.compare(&node.key, &key) != 0 {
+		return false
+	}
+	for i := 0; i < s.level; i++ {
+		if update[i].next[i] == node {
+			update[i].span[i] += node.span[i] - 1
+			update[i].next[i] = node.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.size--
+	if e, isDestructible := interface{}(&node.value).(Destructible); isDestructible {
+		e.Destruct()
+	}
+	s.freeNode(node)
+	return true
+}
+
+// Clear removes all the key/value pairs from the SkipList.
+//
+// If the values implement the Destructible interface, they will have the Destruct method
+// called on them.
+func (s *SkipList[K, V]) Clear() {
+	s.init()
+	for node := s.head.next[0]; node != nil; {
+		next := node.next[0]
+		if e, isDestructible := interface{}(&node.value).(Destructible); isDestructible {
+			e.Destruct()
+		}
+		s.freeNode(node)
+		node = next
+	}
+	s.head = newSkipListNode[K, V](skipListMaxLevel)
+	s.level = 1
+	s.size = 0
+}
+
+// Swap swaps the data of two SkipLists.
+func (s *SkipList[K, V]) Swap(other *SkipList[K, V]) {
+	s.head, other.head = other.head, s.head
+	s.level, other.level = other.level, s.level
+	s.size, other.size = other.size, s.size
+	s.compare, other.compare = other.compare, s.compare
+	s.allocator, other.allocator = other.allocator, s.allocator
+}
+
+// Clone returns a new SkipList with copies of the key/value pairs, that does not share any nodes
+// with this one.
+//
+// Keys and values are copied by assignment, so pointers, slices and maps inside them are still
+// shared. Use DeepClone to copy the values too.
+func (s *SkipList[K, V]) Clone() SkipList[K, V] {
+	return NewSkipListFromSkipList(*s)
+}
+
+// DeepClone returns a new SkipList with deep copies of the values, see Vector.DeepClone.
+//
+// Note, the keys are still copied by assignment, so that they keep their order.
+func (s *SkipList[K, V]) DeepClone() SkipList[K, V] {
+	result := s.Clone()
+	result.Visit(func(key *K, value *V, break_out *bool) {
+		*value = DeepCopy(*value)
+	})
+	return result
+}
+
+func (s *SkipList[K, V]) nodeAt(index int) *SkipListNode[K, V] {
+	traversed := 0
+	target := index + 1
+	node := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && traversed+node.span[i] <= target {
+			traversed += node.span[i]
+			node = node.next[i]
+		}
+		if traversed == target {
+			return node
+		}
+	}
+	return nil
+}
+
+// At gets the key/value pair with the given rank, where the lowest key has rank 0.
+//
+// Note, this function does do bounds checking.
+func (s *SkipList[K, V]) At(index int) (key K, value V) {
+	if (index < 0) || (index >= s.size) {
+		panic("ERROR: SkipList.At - index out of range")
+	}
+	node := s.nodeAt(index)
+	return node.key, node.value
+}
+
+// ValueAtRef gets a pointer to the value with the given rank, where the lowest key has rank 0.
+//
+// Note, this function does do bounds checking.
+func (s *SkipList[K, V]) ValueAtRef(index int) *V {
+	if (index < 0) || (index >= s.size) {
+		panic("ERROR: SkipList.ValueAtRef - index out of range")
+	}
+	return &s.nodeAt(index).value
+}
+
+// Rank returns the rank of key, or -1 if the SkipList does not contain it.
+func (s *SkipList[K, V]) Rank(key K) int {
+	s.init()
+	rank := 0
+	node := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && s.compare(&node.next[i].key, &key) <= 0 {
+			rank += node.span[i]
+			node = node.next[i]
+		}
+		if node != s.head && s.compare(&node.key, &key) == 0 {
+			return rank - 1
+		}
+	}
+	return -1
+}
+
+// Front gets the key/value pair with the lowest key.
+func (s *SkipList[K, V]) Front() (key K, value V) {
+	if s.IsEmpty() {
+		panic("ERROR: SkipList.Front - empty skip list")
+	}
+	return s.head.next[0].key, s.head.next[0].value
+}
+
+// Back gets the key/value pair with the highest key.
+func (s *SkipList[K, V]) Back() (key K, value V) {
+	if s.IsEmpty() {
+		panic("ERROR: SkipList.Back - empty skip list")
+	}
+	node := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for node.next[i] != nil {
+			node = node.next[i]
+		}
+	}
+	return node.key, node.value
+}
+
+// Visit calls a function for every key/value pair in the SkipList, in key order.
+func (s *SkipList[K, V]) Visit(visitor MapVisitor[K, V]) {
+	s.init()
+	break_out := false
+	for node := s.head.next[0]; (!break_out) && (node != nil); node = node.next[0] {
+		visitor(&node.key, &node.value, &break_out)
+	}
+}
+
+// VisitRange calls a function for every key/value pair in the SkipList with a key in the range
+// [from, to), in key order.
+func (s *SkipList[K, V]) VisitRange(from K, to K, visitor MapVisitor[K, V]) {
+	s.init()
+	node := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && s.compare(&node.next[i].key, &from) < 0 {
+			node = node.next[i]
+		}
+	}
+	break_out := false
+	for node = node.next[0]; (!break_out) && (node != nil) && s.compare(&node.key, &to) < 0; node = node.next[0] {
+		visitor(&node.key, &node.value, &break_out)
+	}
+}
+
+// Keys returns a Vector of all the keys in the SkipList, in key order.
+func (s *SkipList[K, V]) Keys() Vector[K] {
+	keys := NewVector[K]()
+	s.Visit(func(key *K, value *V, break_out *bool) {
+		keys.PushBack(*key)
+	})
+	return keys
+}
+
+// Values returns a Vector of all the values in the SkipList, in key order.
+func (s *SkipList[K, V]) Values() Vector[V] {
+	values := NewVector[V]()
+	s.Visit(func(key *K, value *V, break_out *bool) {
+		values.PushBack(*value)
+	})
+	return values
+}
+
+// String returns a string representation of the SkipList and it's contents.
+func (s *SkipList[K, V]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	count := 0
+	s.Visit(func(key *K, value *V, break_out *bool) {
+		if count == s.size-1 {
+			fmt.Fprintf(&builder, "%v: %v", *key, *value)
+		} else {
+			fmt.Fprintf(&builder, "%v: %v, ", *key, *value)
+		}
+		count++
+	})
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
@@ -0,0 +1,187 @@
+package gollect
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestSkipListPutGetErase(t *testing.T) {
+	s := NewSkipList[int, int]()
+	reference := map[int]int{}
+	rng := rand.New(rand.NewSource(12345))
+	for i := 0; i < 2000; i++ {
+		key := rng.Intn(500)
+		if rng.Intn(3) == 0 {
+			_, present := reference[key]
+			if s.Erase(key) != present {
+				t.Fatalf("Erase(%v) should return %v", key, present)
+			}
+			delete(reference, key)
+		} else {
+			s.Put(key, i)
+			reference[key] = i
+		}
+	}
+	if s.Size() != len(reference) {
+		t.Fatalf("Size should be %v, got %v", len(reference), s.Size())
+	}
+	keys := make([]int, 0, len(reference))
+	for key, value := range reference {
+		keys = append(keys, key)
+		if v, found := s.Get(key); !found || v != value {
+			t.Fatalf("Get(%v) should be %v, got %v (%v)", key, value, v, found)
+		}
+	}
+	sort.Ints(keys)
+	for idx, key := range keys {
+		if k, _ := s.At(idx); k != key {
+			t.Fatalf("At(%v) should have key %v, got %v", idx, key, k)
+		}
+		if r := s.Rank(key); r != idx {
+			t.Fatalf("Rank(%v) should be %v, got %v", key, idx, r)
+		}
+	}
+	if r := s.Rank(-1); r != -1 {
+		t.Fatalf("Rank(-1) should be -1, got %v", r)
+	}
+	if k, _ := s.Back(); k != keys[len(keys)-1] {
+		t.Fatalf("Back should have key %v, got %v", keys[len(keys)-1], k)
+	}
+}
+
+func TestSkipListVisitRange(t *testing.T) {
+	s := NewSkipList[int, string]()
+	for i := 0; i < 10; i++ {
+		s.Put(i*10, "v")
+	}
+	got := NewNVector[int]()
+	s.VisitRange(15, 55, func(key *int, value *string, break_out *bool) {
+		got.PushBack(*key)
+	})
+	if got.String() != "{20, 30, 40, 50}" {
+		t.Fatalf("VisitRange should visit {20, 30, 40, 50}, got %v", got.String())
+	}
+	got.Clear()
+	s.VisitRange(0, 100, func(key *int, value *string, break_out *bool) {
+		got.PushBack(*key)
+		*break_out = *key == 20
+	})
+	if got.Size() != 3 {
+		t.Fatalf("VisitRange should stop after 3 keys, got %v", got.String())
+	}
+}
+
+func TestSkipListDestruct(t *testing.T) {
+	Msgs = []string{}
+	s := NewSkipList[string, DBool]()
+	s.Put("a", true)
+	s.Put("b", true)
+	s.Erase("a")
+	s.Clear()
+	if len(Msgs) != 2 {
+		t.Fatalf("Destruct method should have been called 2 times, got %v", len(Msgs))
+	}
+}
+
+func TestConcurrentSkipList(t *testing.T) {
+	s := MakeConcurrentSkipList[int, int]()
+	var waitGrp sync.WaitGroup
+	done := make(chan struct{})
+	for r := 0; r < 4; r++ {
+		waitGrp.Add(1)
+		go func() {
+			defer waitGrp.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				last := -1
+				s.Visit(func(key *int, value *int, break_out *bool) {
+					if *key <= last {
+						t.Errorf("Keys should be visited in order, got %v after %v", *key, last)
+					}
+					last = *key
+				})
+				s.Get(500)
+			}
+		}()
+	}
+	erased := map[int]bool{}
+	for i := 0; i < 1000; i++ {
+		s.Put(i, i)
+		if i%3 == 0 {
+			s.Erase(i / 2)
+			erased[i/2] = true
+		}
+	}
+	close(done)
+	waitGrp.Wait()
+
+	if s.Size() != 1000-len(erased) {
+		t.Fatalf("Size should be %v, got %v", 1000-len(erased), s.Size())
+	}
+	for i := 0; i < 1000; i++ {
+		if _, found := s.Get(i); found == erased[i] {
+			t.Fatalf("Get(%v) found should be %v", i, !erased[i])
+		}
+	}
+}
+
+func TestConcurrentSkipListNoDestruct(t *testing.T) {
+	Msgs = []string{}
+	s := MakeConcurrentSkipList[string, DBool]()
+	s.Put("a", true)
+	s.Put("a", false)
+	s.Put("b", true)
+	s.Erase("a")
+	s.Clear()
+	if len(Msgs) != 0 {
+		t.Fatalf("Destruct method should not have been called, got %v calls", len(Msgs))
+	}
+}
+
+type skipListVersion struct {
+	major int
+	minor int
+}
+
+func (v *skipListVersion) Compare(other skipListVersion) int {
+	if v.major != other.major {
+		return v.major - other.major
+	}
+	return v.minor - other.minor
+}
+
+func TestSkipListZeroValue(t *testing.T) {
+	var s SkipList[string, int]
+	if _, found := s.Get("a"); found || s.Size() != 0 || s.Rank("a") != -1 {
+		t.Fatalf("A zero-valued SkipList should be empty")
+	}
+	s.Put("b", 2)
+	s.Put("a", 1)
+	if k, v := s.Front(); k != "a" || v != 1 || s.Size() != 2 {
+		t.Fatalf("Front should be a, got %v", k)
+	}
+	var versions SkipList[skipListVersion, string]
+	versions.Clear()
+	versions.Put(skipListVersion{1, 10}, "b")
+	versions.Put(skipListVersion{1, 2}, "a")
+	if _, v := versions.Front(); v != "a" {
+		t.Fatalf("A zero-valued SkipList should use the Ordered implementation of its keys, got %v", v)
+	}
+}
+
+func TestSkipListZeroValueNoComparator(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: SkipList - no Comparator for key type" {
+			t.Fatalf("Should have panicked because there is no Comparator, got \"%v\"", result)
+		}
+	}()
+	var s SkipList[[]int, int]
+	s.Put(nil, 1)
+}
@@ -0,0 +1,77 @@
+package gollect
+
+import (
+	"reflect"
+)
+
+// slicesAlias returns true if the memory of a and b overlaps up to their capacities, as when one
+// was sliced from the other, even with a capped capacity.
+func slicesAlias[T any](a []T, b []T) bool {
+	if cap(a) == 0 || cap(b) == 0 {
+		return false
+	}
+	size := reflect.TypeOf(a).Elem().Size()
+	if size == 0 {
+		return false
+	}
+	a_start := reflect.ValueOf(&a[:1][0]).Pointer()
+	b_start := reflect.ValueOf(&b[:1][0]).Pointer()
+	return a_start < b_start+uintptr(cap(b))*size && b_start < a_start+uintptr(cap(a))*size
+}
+
+// insertSliceRange inserts values at index of data with a single move of the later elements,
+// growing data according to policy if needed. values may be a slice of data.
+func insertSliceRange[T any](data []T, index int, values []T, policy GrowthPolicy) []T {
+	if len(values) == 0 {
+		return data
+	}
+	if slicesAlias(data, values) {
+		values = append([]T{}, values...)
+	}
+	size := len(data)
+	data = reserveSlice(data, size+len(values), policy)[:size+len(values)]
+	copy(data[index+len(values):], data[index:size])
+	copy(data[index:], values)
+	return data
+}
+
+// destructSlice calls Destruct on every element of values that is Destructible.
+func destructSlice[T any](values []T) {
+	for idx := range values {
+		if e, isDestructible := interface{}(&values[idx]).(Destructible); isDestructible {
+			e.Destruct()
+		}
+	}
+}
+
+// eraseSliceRange removes the elements in the range [from, to) of data with a single move of
+// the later elements, and clears the vacated slots so they do not keep anything alive.
+func eraseSliceRange[T any](data []T, from int, to int) []T {
+	copy(data[from:], data[to:])
+	size := len(data) - (to - from)
+	var zero T
+	for idx := size; idx < len(data); idx++ {
+		data[idx] = zero
+	}
+	return data[:size]
+}
+
+// eraseSliceIf removes the elements of data that satisfy predicate in a single pass, calling
+// Destruct on them if destruct is true, and returns the number removed.
+func eraseSliceIf[T any](data []T, predicate CollectionPredicate[T], destruct bool) ([]T, int) {
+	kept := 0
+	for idx := range data {
+		if predicate(&data[idx]) {
+			if destruct {
+				destructSlice(data[idx : idx+1])
+			}
+		} else {
+			if kept != idx {
+				data[kept] = data[idx]
+			}
+			kept++
+		}
+	}
+	removed := len(data) - kept
+	return eraseSliceRange(data, kept, len(data)), removed
+}
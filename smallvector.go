@@ -0,0 +1,656 @@
+package gollect
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// SmallVectorInlineSize is the number of elements a SmallVector stores inline, before it has to
+// allocate.
+const SmallVectorInlineSize = 8
+
+// SmallVector is a general-purpose collection with the same API as Vector, that stores up to
+// SmallVectorInlineSize elements in a fixed array inside itself instead of on the heap.
+//
+// A SmallVector that never holds more than SmallVectorInlineSize elements does not allocate
+// at all, and can live entirely on the stack. Once it overflows, its elements are moved into a
+// heap-allocated slice, and it behaves like a Vector until it is cleared.
+//
+// It does have special functionality for element types that implement the Destructible
+// interface.
+//
+// Note, because the elements may live inside the SmallVector, copying a SmallVector by
+// assignment copies its inline elements, and pointers to the elements of the original do not
+// point into the copy. Use Clone rather than assignment to copy one.
+type SmallVector[T any] struct {
+	inline [SmallVectorInlineSize]T
+	size   int
+	heap   []T
+	equal  Equaler[T]
+}
+
+// NewSmallVector creates a new empty SmallVector, by value.
+func NewSmallVector[T any]() SmallVector[T] {
+	return SmallVector[T]{}
+}
+
+// NewSmallVectorFromData creates a new SmallVector using copies of the elements in values, by
+// value.
+func NewSmallVectorFromData[T any](values ...T) SmallVector[T] {
+	s := SmallVector[T]{}
+	s.AppendData(values...)
+	return s
+}
+
+// NewSmallVectorFromDataRef creates a new SmallVector using pointers to the elements in values,
+// by value.
+func NewSmallVectorFromDataRef[T any](values ...*T) SmallVector[T] {
+	s := SmallVector[T]{}
+	for _, val := range values {
+		s.PushBackRef(val)
+	}
+	return s
+}
+
+// NewSmallVectorFromSmallVector creates a new SmallVector using copies of the values of
+// another, by value.
+//
+// This is the same as other.Clone().
+func NewSmallVectorFromSmallVector[T any](other SmallVector[T]) SmallVector[T] {
+	return other.Clone()
+}
+
+// NewSmallVectorWithEqualer creates a new SmallVector using copies of the elements in values,
+// that compares its elements with equal, by value.
+func NewSmallVectorWithEqualer[T any](equal Equaler[T], values ...T) SmallVector[T] {
+	s := NewSmallVectorFromData(values...)
+	s.equal = equal
+	return s
+}
+
+// MakeSmallVector creates a new empty SmallVector instance.
+//
+// Note, a SmallVector instance is on the heap, so this only saves the allocation of its
+// elements.
+func MakeSmallVector[T any]() *SmallVector[T] {
+	return &SmallVector[T]{}
+}
+
+// MakeSmallVectorFromData creates a new SmallVector instance using copies of the elements in
+// values.
+func MakeSmallVectorFromData[T any](values ...T) *SmallVector[T] {
+	s := NewSmallVectorFromData(values...)
+	return &s
+}
+
+// MakeSmallVectorFromDataRef creates a new SmallVector instance using pointers to the elements in
+// values.
+func MakeSmallVectorFromDataRef[T any](values ...*T) *SmallVector[T] {
+	s := NewSmallVectorFromDataRef(values...)
+	return &s
+}
+
+// MakeSmallVectorFromSmallVector creates a new SmallVector instance using copies of the values
+// of another.
+func MakeSmallVectorFromSmallVector[T any](other SmallVector[T]) *SmallVector[T] {
+	s := other.Clone()
+	return &s
+}
+
+// MakeSmallVectorWithEqualer creates a new SmallVector instance using copies of the elements in
+// values, that compares its elements with equal.
+func MakeSmallVectorWithEqualer[T any](equal Equaler[T], values ...T) *SmallVector[T] {
+	s := NewSmallVectorWithEqualer(equal, values...)
+	return &s
+}
+
+// SetEqualer sets the function used to compare elements in value-based searches.
+//
+// If equal is nil, the SmallVector falls back to the EqualityComparable implementation of its
+// element type, or to `==` if the element type is comparable.
+func (s *SmallVector[T]) SetEqualer(equal Equaler[T]) {
+	s.equal = equal
+}
+
+func (s *SmallVector[T]) equaler(method string) Equaler[T] {
+	equal := resolveEqualer(s.equal)
+	if equal == nil {
+		panic("ERROR: SmallVector." + method + " - no Equaler for element type")
+	}
+	return equal
+}
+
+// IsInline returns true if the elements are stored inside the SmallVector, rather than on the
+// heap.
+func (s *SmallVector[T]) IsInline() bool {
+	return s.heap == nil
+}
+
+// Data gets the slice of the elements, which is backed by the inline array until the
+// SmallVector overflows.
+//
+// Note, the slice is still owned by the SmallVector. Changes to its elements are visible in the
+// SmallVector, and it may be moved by the next change to the SmallVector.
+func (s *SmallVector[T]) Data() []T {
+	if s.heap != nil {
+		return s.heap
+	}
+	return s.inline[:s.size:s.size]
+}
+
+// grow adds count zero-valued elements to the back, moving the elements to the heap if they no
+// longer fit inline.
+func (s *SmallVector[T]) grow(count int) {
+	if s.heap == nil {
+		if s.size+count <= SmallVectorInlineSize {
+			s.size += count
+			return
+		}
+		capacity := 2 * SmallVectorInlineSize
+		if capacity < s.size+count {
+			capacity = s.size + count
+		}
+		s.heap = make([]T, s.size, capacity)
+		copy(s.heap, s.inline[:s.size])
+		s.inline = [SmallVectorInlineSize]T{}
+		s.size = 0
+	}
+	var zero T
+	for idx := 0; idx < count; idx++ {
+		s.heap = append(s.heap, zero)
+	}
+}
+
+// truncate removes the elements from new_size onwards, without destructing them.
+func (s *SmallVector[T]) truncate(new_size int) {
+	data := s.Data()
+	var zero T
+	for idx := new_size; idx < len(data); idx++ {
+		data[idx] = zero
+	}
+	if s.heap != nil {
+		s.heap = s.heap[:new_size]
+	} else {
+		s.size = new_size
+	}
+}
+
+// At gets the element at index by value.
+//
+// Note, this function does no bounds checking besides what the Go runtime does already.
+func (s *SmallVector[T]) At(index int) T {
+	return s.Data()[index]
+}
+
+// SafeAt gets the element at index by value.
+//
+// Note, this function does do bounds checking.
+func (s *SmallVector[T]) SafeAt(index int) T {
+	return *s.SafeAtRef(index)
+}
+
+// AtRef gets a pointer to the element at index.
+//
+// Note, this function does no bounds checking besides what the Go runtime does already.
+func (s *SmallVector[T]) AtRef(index int) *T {
+	return &s.Data()[index]
+}
+
+// SafeAtRef gets a pointer to the element at index.
+//
+// Note, this function does do bounds checking.
+func (s *SmallVector[T]) SafeAtRef(index int) *T {
+	if s.IsEmpty() {
+		panic("ERROR: SmallVector.SafeAtRef - empty vector")
+	}
+	if index < 0 || index >= s.Size() {
+		panic("ERROR: SmallVector.SafeAtRef - index out of range")
+	}
+	return &s.Data()[index]
+}
+
+// Front gets the element at the front of the SmallVector by value.
+func (s *SmallVector[T]) Front() T {
+	return *s.FrontRef()
+}
+
+// FrontRef gets a pointer to the element at the front of the SmallVector.
+func (s *SmallVector[T]) FrontRef() *T {
+	if s.IsEmpty() {
+		panic("ERROR: SmallVector.FrontRef - empty vector")
+	}
+	return &s.Data()[0]
+}
+
+// Back gets the element at the back of the SmallVector by value.
+func (s *SmallVector[T]) Back() T {
+	return *s.BackRef()
+}
+
+// BackRef gets a pointer to the element at the back of the SmallVector.
+func (s *SmallVector[T]) BackRef() *T {
+	if s.IsEmpty() {
+		panic("ERROR: SmallVector.BackRef - empty vector")
+	}
+	return &s.Data()[s.Size()-1]
+}
+
+// IsEmpty returns true if the SmallVector is empty.
+func (s *SmallVector[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Size returns the number of elements in the SmallVector.
+func (s *SmallVector[T]) Size() int {
+	if s.heap != nil {
+		return len(s.heap)
+	}
+	return s.size
+}
+
+// Clear removes all the elements from the SmallVector, and returns to inline storage.
+//
+// If the elements implement the Destructible interface, then they will have the Destruct method called on them.
+func (s *SmallVector[T]) Clear() {
+	for !s.IsEmpty() {
+		s.PopBack()
+	}
+	s.heap = nil
+	s.size = 0
+}
+
+// Insert adds an element at the specified index, moving all later elements one further index back.
+func (s *SmallVector[T]) Insert(index int, value T) {
+	if index < 0 || index > s.Size() {
+		panic("ERROR: SmallVector.Insert - index out of bounds")
+	}
+	s.grow(1)
+	data := s.Data()
+	copy(data[index+1:], data[index:len(data)-1])
+	data[index] = value
+}
+
+// InsertRef adds an element at the specified index, moving all later elements one further index back.
+func (s *SmallVector[T]) InsertRef(index int, value *T) {
+	if index < 0 || index > s.Size() {
+		panic("ERROR: SmallVector.InsertRef - index out of bounds")
+	}
+	s.Insert(index, *value)
+}
+
+// Erase removes an element at the specified index, moving all later elements one index forward.
+//
+// If the element implements the Destructible interface, it will have the Destruct method called on it.
+func (s *SmallVector[T]) Erase(index int) {
+	if s.IsEmpty() {
+		panic("ERROR: SmallVector.Erase - empty vector")
+	}
+	if index < 0 || index >= s.Size() {
+		panic("ERROR: SmallVector.Erase - index out of bounds")
+	}
+	data := s.Data()
+	if e, isDestructible := interface{}(&data[index]).(Destructible); isDestructible {
+		e.Destruct()
+	}
+	copy(data[index:], data[index+1:])
+	s.truncate(len(data) - 1)
+}
+
+// AppendData adds copies of values to the back of the SmallVector.
+func (s *SmallVector[T]) AppendData(values ...T) {
+	size := s.Size()
+	s.grow(len(values))
+	copy(s.Data()[size:], values)
+}
+
+// PushBack adds an element to the back of the SmallVector.
+func (s *SmallVector[T]) PushBack(value T) {
+	if s.heap == nil && s.size < SmallVectorInlineSize {
+		s.inline[s.size] = value
+		s.size++
+		return
+	}
+	s.grow(1)
+	s.heap[len(s.heap)-1] = value
+}
+
+// PushBackRef adds an element to the back of the SmallVector.
+func (s *SmallVector[T]) PushBackRef(value *T) {
+	s.PushBack(*value)
+}
+
+// PushFront adds an element to the front of the SmallVector, moving all later elements one index backward.
+func (s *SmallVector[T]) PushFront(value T) {
+	s.Insert(0, value)
+}
+
+// PushFrontRef adds an element to the front of the SmallVector, moving all later elements one index backward.
+func (s *SmallVector[T]) PushFrontRef(value *T) {
+	s.Insert(0, *value)
+}
+
+// PopBack removes an element from the back of the SmallVector.
+//
+// If the element implements the Destructible interface, it will have the Destruct method called on it.
+func (s *SmallVector[T]) PopBack() {
+	if s.IsEmpty() {
+		panic("ERROR: SmallVector.PopBack - empty vector")
+	}
+	if e, isDestructible := interface{}(s.BackRef()).(Destructible); isDestructible {
+		e.Destruct()
+	}
+	s.truncate(s.Size() - 1)
+}
+
+// PopFront removes an element from the front of the SmallVector, moving all later elements one index forward.
+//
+// If the element implements the Destructible interface, it will have the Destruct method called on it.
+func (s *SmallVector[T]) PopFront() {
+	if s.IsEmpty() {
+		panic("ERROR: SmallVector.PopFront - empty vector")
+	}
+	s.Erase(0)
+}
+
+// Resize resizes the SmallVector.
+//
+// If the size increases, the new elements are zero-valued.
+//
+// If the size decreases and the removed elements implement the Destructible interface, they
+// will have the Destruct method called on them.
+func (s *SmallVector[T]) Resize(new_size int) {
+	if new_size < 0 {
+		panic("ERROR: SmallVector.Resize - negative new size")
+	}
+	if new_size > s.Size() {
+		s.grow(new_size - s.Size())
+	}
+	for s.Size() > new_size {
+		s.PopBack()
+	}
+}
+
+// Swap swaps the data of two SmallVectors.
+func (s *SmallVector[T]) Swap(other *SmallVector[T]) {
+	s.inline, other.inline = other.inline, s.inline
+	s.size, other.size = other.size, s.size
+	s.heap, other.heap = other.heap, s.heap
+}
+
+// Clone returns a new SmallVector with copies of the elements, that does not share storage with
+// this one.
+//
+// Elements are copied by assignment, so pointers, slices and maps inside them are still shared.
+// Use DeepClone to copy those too.
+func (s *SmallVector[T]) Clone() SmallVector[T] {
+	result := *s
+	if s.heap != nil {
+		result.heap = append(make([]T, 0, len(s.heap)), s.heap...)
+	}
+	return result
+}
+
+// DeepClone returns a new SmallVector with deep copies of the elements.
+//
+// Elements implementing DeepCloner[T] or Cloner[T] are copied with their DeepClone or Clone
+// method. Other elements are copied recursively, following pointers, slices, maps and
+// interfaces, see DeepCopy.
+func (s *SmallVector[T]) DeepClone() SmallVector[T] {
+	result := s.Clone()
+	data := result.Data()
+	for idx := range data {
+		data[idx] = DeepCopy(data[idx])
+	}
+	return result
+}
+
+// ToVector returns a Vector holding a copy of the elements of the SmallVector.
+func (s *SmallVector[T]) ToVector() Vector[T] {
+	return Vector[T]{data: append([]T{}, s.Data()...), equal: s.equal}
+}
+
+// String returns a string representation of the SmallVector and it's contents.
+func (s *SmallVector[T]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	for idx, val := range s.Data() {
+		if idx == s.Size()-1 {
+			fmt.Fprintf(&builder, "%v", val)
+		} else {
+			fmt.Fprintf(&builder, "%v, ", val)
+		}
+	}
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
+
+// Visit calls a function for every element in the SmallVector.
+func (s *SmallVector[T]) Visit(visitor CollectionVisitor[T]) {
+	data := s.Data()
+	break_out := false
+	for idx := 0; !break_out && idx < len(data); idx++ {
+		visitor(&data[idx], &break_out)
+	}
+}
+
+// VisitReverse calls a function for every element in the SmallVector in reverse order.
+func (s *SmallVector[T]) VisitReverse(visitor CollectionVisitor[T]) {
+	data := s.Data()
+	break_out := false
+	for idx := len(data) - 1; !break_out && idx >= 0; idx-- {
+		visitor(&data[idx], &break_out)
+	}
+}
+
+// ContainsValue returns true if the SmallVector contains value.
+//
+// Panics if the SmallVector has no Equaler for its element type.
+func (s *SmallVector[T]) ContainsValue(value T) bool {
+	found, _ := s.OrderedSearch(value)
+	return found
+}
+
+// ContainsRef returns true if the SmallVector contains the exact reference of value.
+func (s *SmallVector[T]) ContainsRef(value *T) bool {
+	return s.IndexIf(func(vv *T) bool { return vv == value }) >= 0
+}
+
+// OrderedSearch searches for a value, and returns the index to the first match.
+//
+// Panics if the SmallVector has no Equaler for its element type.
+func (s *SmallVector[T]) OrderedSearch(value T) (found bool, index int) {
+	equal := s.equaler("OrderedSearch")
+	index = s.IndexIf(func(vv *T) bool { return equal(vv, &value) })
+	return index >= 0, index
+}
+
+// OrderedRefSearch searches for an instance, and returns the index to the first match.
+func (s *SmallVector[T]) OrderedRefSearch(value *T) (found bool, index int) {
+	index = s.IndexIf(func(vv *T) bool { return vv == value })
+	return index >= 0, index
+}
+
+// OrderedSearchRef searches for a value, and returns a pointer to the first match.
+//
+// Panics if the SmallVector has no Equaler for its element type.
+func (s *SmallVector[T]) OrderedSearchRef(value T) *T {
+	equal := s.equaler("OrderedSearchRef")
+	return s.FindIf(func(vv *T) bool { return equal(vv, &value) })
+}
+
+// OrderedRefSearchRef searches for an instance, and returns a pointer to the first match.
+func (s *SmallVector[T]) OrderedRefSearchRef(value *T) *T {
+	return s.FindIf(func(vv *T) bool { return vv == value })
+}
+
+// Search searches for a value, and returns an index to a match.
+//
+// Note, unlike Vector.Search this never searches in parallel, as a SmallVector is meant to be
+// small.
+//
+// Panics if the SmallVector has no Equaler for its element type.
+func (s *SmallVector[T]) Search(value T) (found bool, index int) {
+	return s.OrderedSearch(value)
+}
+
+// RefSearch searches for an instance, and returns an index to a match.
+func (s *SmallVector[T]) RefSearch(value *T) (found bool, index int) {
+	return s.OrderedRefSearch(value)
+}
+
+// SearchRef searches for a value, and returns a pointer to a match.
+//
+// Panics if the SmallVector has no Equaler for its element type.
+func (s *SmallVector[T]) SearchRef(value T) *T {
+	return s.OrderedSearchRef(value)
+}
+
+// RefSearchRef searches for an instance, and returns a pointer to a match.
+func (s *SmallVector[T]) RefSearchRef(value *T) *T {
+	return s.OrderedRefSearchRef(value)
+}
+
+// FindIf returns a pointer to the first element that satisfies predicate, or nil if there is none.
+func (s *SmallVector[T]) FindIf(predicate CollectionPredicate[T]) *T {
+	if index := s.IndexIf(predicate); index >= 0 {
+		return s.AtRef(index)
+	}
+	return nil
+}
+
+// FindLastIf returns a pointer to the last element that satisfies predicate, or nil if there is none.
+func (s *SmallVector[T]) FindLastIf(predicate CollectionPredicate[T]) *T {
+	data := s.Data()
+	for idx := len(data) - 1; idx >= 0; idx-- {
+		if predicate(&data[idx]) {
+			return &data[idx]
+		}
+	}
+	return nil
+}
+
+// ContainsIf returns true if any element satisfies predicate.
+func (s *SmallVector[T]) ContainsIf(predicate CollectionPredicate[T]) bool {
+	return s.IndexIf(predicate) >= 0
+}
+
+// CountIf returns the number of elements that satisfy predicate.
+func (s *SmallVector[T]) CountIf(predicate CollectionPredicate[T]) int {
+	count := 0
+	data := s.Data()
+	for idx := range data {
+		if predicate(&data[idx]) {
+			count++
+		}
+	}
+	return count
+}
+
+// IndexIf returns the index of the first element that satisfies predicate, or -1 if there is none.
+func (s *SmallVector[T]) IndexIf(predicate CollectionPredicate[T]) int {
+	data := s.Data()
+	for idx := range data {
+		if predicate(&data[idx]) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// ParallelIndexIf returns the index of an element that satisfies predicate, or -1 if there is none.
+//
+// Note, this method may perform the search in parallel, so the match might not be the first
+// in the SmallVector, and predicate must be safe to call concurrently.
+func (s *SmallVector[T]) ParallelIndexIf(predicate CollectionPredicate[T]) int {
+	if s.IsEmpty() {
+		return -1
+	}
+	data := s.Data()
+	var found atomic.Bool
+	var retMtx sync.Mutex
+	index := -1
+	visitChunks(len(data), func(chunk_start int, chunk_end int) {
+		for idx := chunk_start; idx < chunk_end && !found.Load(); idx++ {
+			if predicate(&data[idx]) {
+				retMtx.Lock()
+				if index < 0 {
+					index = idx
+				}
+				retMtx.Unlock()
+				found.Store(true)
+				return
+			}
+		}
+	})
+	return index
+}
+
+// ParallelFindIf returns a pointer to an element that satisfies predicate, or nil if there is none.
+//
+// Note, this method may perform the search in parallel, so the match might not be the first
+// in the SmallVector, and predicate must be safe to call concurrently.
+func (s *SmallVector[T]) ParallelFindIf(predicate CollectionPredicate[T]) *T {
+	if index := s.ParallelIndexIf(predicate); index >= 0 {
+		return s.AtRef(index)
+	}
+	return nil
+}
+
+// ParallelContainsIf returns true if any element satisfies predicate.
+//
+// Note, this method may perform the search in parallel, so predicate must be safe to call
+// concurrently.
+func (s *SmallVector[T]) ParallelContainsIf(predicate CollectionPredicate[T]) bool {
+	return s.ParallelIndexIf(predicate) >= 0
+}
+
+// ParallelCountIf returns the number of elements that satisfy predicate.
+//
+// Note, this method may count in parallel, so predicate must be safe to call concurrently.
+func (s *SmallVector[T]) ParallelCountIf(predicate CollectionPredicate[T]) int {
+	data := s.Data()
+	var count atomic.Int64
+	visitChunks(len(data), func(chunk_start int, chunk_end int) {
+		var chunk_count int64 = 0
+		for idx := chunk_start; idx < chunk_end; idx++ {
+			if predicate(&data[idx]) {
+				chunk_count++
+			}
+		}
+		count.Add(chunk_count)
+	})
+	return int(count.Load())
+}
+
+// SortBy sorts the elements of the SmallVector according to compare.
+func (s *SmallVector[T]) SortBy(compare Comparator[T]) {
+	data := s.Data()
+	sort.Slice(data, func(i, j int) bool { return compare(&data[i], &data[j]) < 0 })
+}
+
+// StableSortBy sorts the elements of the SmallVector according to compare, keeping equal
+// elements in their original order.
+func (s *SmallVector[T]) StableSortBy(compare Comparator[T]) {
+	data := s.Data()
+	sort.SliceStable(data, func(i, j int) bool { return compare(&data[i], &data[j]) < 0 })
+}
+
+// IsSortedBy returns true if the elements of the SmallVector are sorted according to compare.
+func (s *SmallVector[T]) IsSortedBy(compare Comparator[T]) bool {
+	data := s.Data()
+	return sort.SliceIsSorted(data, func(i, j int) bool { return compare(&data[i], &data[j]) < 0 })
+}
+
+// BinarySearchBy searches a SmallVector sorted according to compare for value.
+//
+// If value is found, index is the index of the first match, otherwise it is the index value
+// would have to be inserted at to keep the SmallVector sorted.
+func (s *SmallVector[T]) BinarySearchBy(value T, compare Comparator[T]) (found bool, index int) {
+	data := s.Data()
+	index = sort.Search(len(data), func(i int) bool { return compare(&data[i], &value) >= 0 })
+	found = index < len(data) && compare(&data[index], &value) == 0
+	return
+}
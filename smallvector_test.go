@@ -0,0 +1,149 @@
+package gollect
+
+import (
+	"testing"
+)
+
+func TestSmallVectorIsGeneralCollector(t *testing.T) {
+	s := NewSmallVector[int64]()
+	if _, isGeneralCollector := interface{}(&s).(GeneralCollector[int64]); !isGeneralCollector {
+		t.Fatalf("SmallVector[int64] should be a GeneralCollector[int64]")
+	}
+}
+
+func TestSmallVectorSpill(t *testing.T) {
+	s := NewSmallVector[int]()
+	for i := 0; i < SmallVectorInlineSize; i++ {
+		s.PushBack(i)
+	}
+	if !s.IsInline() {
+		t.Fatalf("SmallVector should still be inline with %v elements", s.Size())
+	}
+	s.PushBack(SmallVectorInlineSize)
+	s.PushFront(-1)
+	if s.IsInline() || s.Size() != SmallVectorInlineSize+2 {
+		t.Fatalf("SmallVector should have spilled to the heap")
+	}
+	if s.String() != "{-1, 0, 1, 2, 3, 4, 5, 6, 7, 8}" {
+		t.Fatalf("SmallVector should be {-1, 0, 1, 2, 3, 4, 5, 6, 7, 8}, got %v", s.String())
+	}
+	s.Clear()
+	if !s.IsInline() || !s.IsEmpty() {
+		t.Fatalf("Clear should return the SmallVector to inline storage")
+	}
+}
+
+func TestSmallVectorMatchesVector(t *testing.T) {
+	s := NewSmallVector[int]()
+	v := NewVector[int]()
+	for i := 0; i < 200; i++ {
+		index := (i * 31) % (v.Size() + 1)
+		s.Insert(index, i)
+		v.Insert(index, i)
+		if i%3 == 0 {
+			index = (i * 17) % v.Size()
+			s.Erase(index)
+			v.Erase(index)
+		}
+	}
+	if s.String() != v.String() {
+		t.Fatalf("SmallVector should match the Vector")
+	}
+	s.Resize(3)
+	s.SortBy(func(left *int, right *int) int { return *left - *right })
+	if !s.IsSortedBy(func(left *int, right *int) int { return *left - *right }) || s.Size() != 3 {
+		t.Fatalf("SmallVector should be sorted with 3 elements, got %v", s.String())
+	}
+}
+
+func TestSmallVectorClone(t *testing.T) {
+	s := NewSmallVectorFromData(1, 2, 3)
+	c := s.Clone()
+	c.PushBack(4)
+	*c.AtRef(0) = 9
+	if s.String() != "{1, 2, 3}" || c.String() != "{9, 2, 3, 4}" {
+		t.Fatalf("Clone should not share storage, got %v and %v", s.String(), c.String())
+	}
+	for i := 0; i < 20; i++ {
+		s.PushBack(i)
+	}
+	c = s.Clone()
+	*c.AtRef(0) = 9
+	if s.At(0) != 1 {
+		t.Fatalf("Clone of a spilled SmallVector should not share storage")
+	}
+}
+
+func TestSmallVectorDestruct(t *testing.T) {
+	Msgs = []string{}
+	s := NewSmallVectorFromData[DBool](true, true, true, true)
+	s.PopFront()
+	s.PopBack()
+	s.Clear()
+	if len(Msgs) != 4 {
+		t.Fatalf("Destruct method should have been called 4 times, got %v", len(Msgs))
+	}
+}
+
+func BenchmarkSmallVectorAllocs(b *testing.B) {
+	b.Run("Vector", func(b *testing.B) {
+		b.ReportAllocs()
+		total := 0
+		for i := 0; i < b.N; i++ {
+			v := NewVector[int]()
+			for j := 0; j < SmallVectorInlineSize; j++ {
+				v.PushBack(j)
+			}
+			total += v.Back()
+		}
+	})
+	b.Run("SmallVector", func(b *testing.B) {
+		b.ReportAllocs()
+		total := 0
+		for i := 0; i < b.N; i++ {
+			s := NewSmallVector[int]()
+			for j := 0; j < SmallVectorInlineSize; j++ {
+				s.PushBack(j)
+			}
+			total += s.Back()
+		}
+	})
+	b.Run("SmallVectorSpilled", func(b *testing.B) {
+		b.ReportAllocs()
+		total := 0
+		for i := 0; i < b.N; i++ {
+			s := NewSmallVector[int]()
+			for j := 0; j < 2*SmallVectorInlineSize; j++ {
+				s.PushBack(j)
+			}
+			total += s.Back()
+		}
+	})
+}
+
+func TestSmallVectorParallelFindIf(t *testing.T) {
+	old_multiplier := ChunkMultiplier
+	ChunkMultiplier = 1
+	defer func() { ChunkMultiplier = old_multiplier }()
+
+	s := NewSmallVector[int]()
+	for i := 0; i < 1000; i++ {
+		s.PushBack(i)
+	}
+	if idx := s.ParallelIndexIf(func(v *int) bool { return *v == 777 }); idx != 777 {
+		t.Fatalf("ParallelIndexIf should return 777, got %v", idx)
+	}
+	if p := s.ParallelFindIf(func(v *int) bool { return *v == 500 }); p == nil || *p != 500 {
+		t.Fatalf("ParallelFindIf should find element 500, got %v", p)
+	}
+	if s.ParallelContainsIf(func(v *int) bool { return *v < 0 }) {
+		t.Fatalf("ParallelContainsIf should return false")
+	}
+	if c := s.ParallelCountIf(func(v *int) bool { return *v%3 == 0 }); c != 334 {
+		t.Fatalf("ParallelCountIf should return 334, got %v", c)
+	}
+	inline := NewSmallVectorFromData(1, 2, 3)
+	if c := inline.ParallelCountIf(func(v *int) bool { return *v > 1 }); c != 2 {
+		t.Fatalf("ParallelCountIf on inline elements should return 2, got %v", c)
+	}
+}
@@ -8,6 +8,8 @@ func NewStack[T any]() Stack[T] {
 	return Stack[T]{data: NewVector[T]()}
 }
 
+// NewStackFromData creates a new Stack using copies of the elements in values, by value. The
+// Stack never shares storage with values.
 func NewStackFromData[T any](values ...T) Stack[T] {
 	return Stack[T]{data: NewVectorFromData(values...)}
 }
@@ -16,6 +18,7 @@ func NewStackFromDataRef[T any](values ...*T) Stack[T] {
 	return Stack[T]{data: NewVectorFromDataRef(values...)}
 }
 
+// NewStackFromStack creates a new Stack using copies of the elements of another, by value.
 func NewStackFromStack[T any](other Stack[T]) Stack[T] {
 	return Stack[T]{data: NewVectorFromVector(other.data)}
 }
@@ -24,6 +27,8 @@ func MakeStack[T any]() *Stack[T] {
 	return &Stack[T]{data: NewVector[T]()}
 }
 
+// MakeStackFromData creates a new Stack instance using copies of the elements in values. The
+// Stack never shares storage with values.
 func MakeStackFromData[T any](values ...T) *Stack[T] {
 	return &Stack[T]{data: NewVectorFromData(values...)}
 }
@@ -32,6 +37,7 @@ func MakeStackFromDataRef[T any](values ...*T) *Stack[T] {
 	return &Stack[T]{data: NewVectorFromDataRef(values...)}
 }
 
+// MakeStackFromStack creates a new Stack instance using copies of the elements of another.
 func MakeStackFromStack[T any](other Stack[T]) *Stack[T] {
 	return &Stack[T]{data: NewVectorFromVector(other.data)}
 }
@@ -44,6 +50,7 @@ func (s *Stack[T]) TopRef() *T {
 	return s.data.BackRef()
 }
 
+// Data gets the underlying slice of the elements, which is still owned by the Stack.
 func (s *Stack[T]) Data() []T {
 	return s.data.Data()
 }
@@ -76,6 +83,17 @@ func (s *Stack[T]) Swap(other *Stack[T]) {
 	s.data.Swap(&other.data)
 }
 
+// Clone returns a new Stack with copies of the elements, that does not share storage with this
+// one.
+func (s *Stack[T]) Clone() Stack[T] {
+	return Stack[T]{data: s.data.Clone()}
+}
+
+// DeepClone returns a new Stack with deep copies of the elements, see Vector.DeepClone.
+func (s *Stack[T]) DeepClone() Stack[T] {
+	return Stack[T]{data: s.data.DeepClone()}
+}
+
 func (s *Stack[T]) String() string {
 	return s.data.String()
 }
@@ -0,0 +1,585 @@
+package gollect
+
+import (
+	"fmt"
+	"strings"
+)
+
+// unrolledListNodeSize is the number of elements each node of an UnrolledList can hold.
+const unrolledListNodeSize = 64
+
+// unrolledListNode holds the elements in data[start:end].
+type unrolledListNode[T any] struct {
+	data  [unrolledListNodeSize]T
+	start int
+	end   int
+	prev  *unrolledListNode[T]
+	next  *unrolledListNode[T]
+}
+
+func (node *unrolledListNode[T]) count() int {
+	return node.end - node.start
+}
+
+// UnrolledList is a general-purpose doubly linked list that stores up to 64 elements in each
+// node, so traversal is cache-friendly and there is one allocation per 64 elements instead of one
+// per element.
+//
+// PushFront, PushBack, PopFront and PopBack take O(1) time, and accessing, inserting or erasing
+// an element in the middle takes O(n/64) time.
+//
+// It does have special functionality for element types that implement the Destructible
+// interface.
+type UnrolledList[T any] struct {
+	front *unrolledListNode[T]
+	back  *unrolledListNode[T]
+	size  int
+	equal Equaler[T]
+}
+
+// NewUnrolledList creates a new empty UnrolledList, by value.
+func NewUnrolledList[T any]() UnrolledList[T] {
+	return UnrolledList[T]{}
+}
+
+// NewUnrolledListFromData creates a new UnrolledList holding a copy of values, by value.
+func NewUnrolledListFromData[T any](values ...T) UnrolledList[T] {
+	ret := NewUnrolledList[T]()
+	for _, v := range values {
+		ret.PushBack(v)
+	}
+	return ret
+}
+
+// NewUnrolledListFromDataRef creates a new UnrolledList holding a copy of the values pointed to,
+// by value.
+func NewUnrolledListFromDataRef[T any](values ...*T) UnrolledList[T] {
+	ret := NewUnrolledList[T]()
+	for _, v := range values {
+		ret.PushBackRef(v)
+	}
+	return ret
+}
+
+// NewUnrolledListFromUnrolledList creates a new UnrolledList holding a copy of the elements of
+// other, by value.
+func NewUnrolledListFromUnrolledList[T any](other UnrolledList[T]) UnrolledList[T] {
+	ret := NewUnrolledList[T]()
+	ret.equal = other.equal
+	other.Visit(func(item *T, break_out *bool) {
+		ret.PushBack(*item)
+	})
+	return ret
+}
+
+// NewUnrolledListWithEqualer creates a new UnrolledList holding a copy of values, by value, that
+// uses equal in value-based searches.
+func NewUnrolledListWithEqualer[T any](equal Equaler[T], values ...T) UnrolledList[T] {
+	ret := NewUnrolledListFromData(values...)
+	ret.equal = equal
+	return ret
+}
+
+// MakeUnrolledList creates a new empty UnrolledList instance.
+func MakeUnrolledList[T any]() *UnrolledList[T] {
+	return &UnrolledList[T]{}
+}
+
+// MakeUnrolledListFromData creates a new UnrolledList instance holding a copy of values.
+func MakeUnrolledListFromData[T any](values ...T) *UnrolledList[T] {
+	ret := NewUnrolledListFromData(values...)
+	return &ret
+}
+
+// MakeUnrolledListFromDataRef creates a new UnrolledList instance holding a copy of the values
+// pointed to.
+func MakeUnrolledListFromDataRef[T any](values ...*T) *UnrolledList[T] {
+	ret := NewUnrolledListFromDataRef(values...)
+	return &ret
+}
+
+// MakeUnrolledListFromUnrolledList creates a new UnrolledList instance holding a copy of the
+// elements of other.
+func MakeUnrolledListFromUnrolledList[T any](other UnrolledList[T]) *UnrolledList[T] {
+	ret := NewUnrolledListFromUnrolledList(other)
+	return &ret
+}
+
+// MakeUnrolledListWithEqualer creates a new UnrolledList instance holding a copy of values, that
+// uses equal in value-based searches.
+func MakeUnrolledListWithEqualer[T any](equal Equaler[T], values ...T) *UnrolledList[T] {
+	ret := NewUnrolledListWithEqualer(equal, values...)
+	return &ret
+}
+
+// SetEqualer sets the function used to compare elements in value-based searches.
+//
+// If equal is nil, the UnrolledList falls back to the EqualityComparable implementation of its
+// element type, or to `==` if the element type is comparable.
+func (l *UnrolledList[T]) SetEqualer(equal Equaler[T]) {
+	l.equal = equal
+}
+
+func (l *UnrolledList[T]) equaler(method string) Equaler[T] {
+	equal := resolveEqualer(l.equal)
+	if equal == nil {
+		panic("ERROR: UnrolledList." + method + " - no Equaler for element type")
+	}
+	return equal
+}
+
+// locate returns the node holding the element at index, and the position of the element in the
+// node's data, walking from whichever end is closer.
+func (l *UnrolledList[T]) locate(index int) (*unrolledListNode[T], int) {
+	if index < l.size/2 {
+		for node := l.front; node != nil; node = node.next {
+			if index < node.count() {
+				return node, node.start + index
+			}
+			index -= node.count()
+		}
+	} else {
+		index = l.size - 1 - index
+		for node := l.back; node != nil; node = node.prev {
+			if index < node.count() {
+				return node, node.end - 1 - index
+			}
+			index -= node.count()
+		}
+	}
+	return nil, -1
+}
+
+// linkAfter links node after prev, or at the front if prev is nil.
+func (l *UnrolledList[T]) linkAfter(prev *unrolledListNode[T], node *unrolledListNode[T]) {
+	node.prev = prev
+	if prev == nil {
+		node.next = l.front
+		l.front = node
+	} else {
+		node.next = prev.next
+		prev.next = node
+	}
+	if node.next == nil {
+		l.back = node
+	} else {
+		node.next.prev = node
+	}
+}
+
+func (l *UnrolledList[T]) unlink(node *unrolledListNode[T]) {
+	if node.prev == nil {
+		l.front = node.next
+	} else {
+		node.prev.next = node.next
+	}
+	if node.next == nil {
+		l.back = node.prev
+	} else {
+		node.next.prev = node.prev
+	}
+	node.prev, node.next = nil, nil
+}
+
+// destroyUnrolledListElement calls Destruct on the element at position in node if it is Destructible, and clears it.
+func destroyUnrolledListElement[T any](node *unrolledListNode[T], position int) {
+	if e, isDestructible := interface{}(&node.data[position]).(Destructible); isDestructible {
+		e.Destruct()
+	}
+	var zero T
+	node.data[position] = zero
+}
+
+// At gets the element at index by value.
+func (l *UnrolledList[T]) At(index int) T {
+	return *l.AtRef(index)
+}
+
+// AtRef gets a pointer to the element at index.
+//
+// Note, the pointer is only valid until an element is inserted or erased in the same node.
+func (l *UnrolledList[T]) AtRef(index int) *T {
+	if index < 0 || index >= l.size {
+		panic("ERROR: UnrolledList.AtRef - index out of range")
+	}
+	node, position := l.locate(index)
+	return &node.data[position]
+}
+
+// Front gets the element at the front of the UnrolledList by value.
+func (l *UnrolledList[T]) Front() T {
+	return *l.FrontRef()
+}
+
+// FrontRef gets a pointer to the element at the front of the UnrolledList.
+func (l *UnrolledList[T]) FrontRef() *T {
+	if l.IsEmpty() {
+		panic("ERROR: UnrolledList.FrontRef - empty list")
+	}
+	return &l.front.data[l.front.start]
+}
+
+// Back gets the element at the back of the UnrolledList by value.
+func (l *UnrolledList[T]) Back() T {
+	return *l.BackRef()
+}
+
+// BackRef gets a pointer to the element at the back of the UnrolledList.
+func (l *UnrolledList[T]) BackRef() *T {
+	if l.IsEmpty() {
+		panic("ERROR: UnrolledList.BackRef - empty list")
+	}
+	return &l.back.data[l.back.end-1]
+}
+
+// IsEmpty returns true if the UnrolledList is empty.
+func (l *UnrolledList[T]) IsEmpty() bool {
+	return l.size == 0
+}
+
+// Size returns the number of elements in the UnrolledList.
+func (l *UnrolledList[T]) Size() int {
+	return l.size
+}
+
+// Clear removes all the elements from the UnrolledList.
+//
+// If the elements implement the Destructible interface, then they will have the Destruct method called on them.
+func (l *UnrolledList[T]) Clear() {
+	for node := l.front; node != nil; node = node.next {
+		for idx := node.start; idx < node.end; idx++ {
+			destroyUnrolledListElement(node, idx)
+		}
+	}
+	l.front, l.back, l.size = nil, nil, 0
+}
+
+// Insert adds an element at the specified index, moving all later elements one further index back.
+func (l *UnrolledList[T]) Insert(index int, value T) {
+	if index < 0 || index > l.size {
+		panic("ERROR: UnrolledList.Insert - index out of bounds")
+	}
+	if index == l.size {
+		l.PushBack(value)
+		return
+	} else if index == 0 {
+		l.PushFront(value)
+		return
+	}
+	node, position := l.locate(index)
+	if node.start == 0 && node.end == unrolledListNodeSize {
+		// Split a full node in half, and insert into the half holding position
+		half := unrolledListNodeSize / 2
+		next := &unrolledListNode[T]{end: unrolledListNodeSize - half}
+		copy(next.data[:], node.data[half:])
+		var zero T
+		for idx := half; idx < unrolledListNodeSize; idx++ {
+			node.data[idx] = zero
+		}
+		node.end = half
+		l.linkAfter(node, next)
+		if position > half {
+			node, position = next, position-half
+		}
+	}
+	if node.end < unrolledListNodeSize {
+		copy(node.data[position+1:node.end+1], node.data[position:node.end])
+		node.end++
+	} else {
+		copy(node.data[node.start-1:position-1], node.data[node.start:position])
+		node.start--
+		position--
+	}
+	node.data[position] = value
+	l.size++
+}
+
+// InsertRef adds an element at the specified index, moving all later elements one further index back.
+func (l *UnrolledList[T]) InsertRef(index int, value *T) {
+	if index < 0 || index > l.size {
+		panic("ERROR: UnrolledList.InsertRef - index out of bounds")
+	}
+	l.Insert(index, *value)
+}
+
+// Erase removes an element at the specified index, moving all later elements one index forward.
+//
+// If the element implements the Destructible interface, it will have the Destruct method called on it.
+func (l *UnrolledList[T]) Erase(index int) {
+	if l.IsEmpty() {
+		panic("ERROR: UnrolledList.Erase - empty list")
+	}
+	if index < 0 || index >= l.size {
+		panic("ERROR: UnrolledList.Erase - index out of bounds")
+	}
+	node, position := l.locate(index)
+	destroyUnrolledListElement(node, position)
+	copy(node.data[position:node.end-1], node.data[position+1:node.end])
+	node.end--
+	var zero T
+	node.data[node.end] = zero
+	l.size--
+	if node.count() == 0 {
+		l.unlink(node)
+	} else if next := node.next; next != nil && node.count()+next.count() <= unrolledListNodeSize/2 {
+		// Merge sparse neighbours, so the list does not degrade into one element per node
+		count := copy(node.data[:], node.data[node.start:node.end])
+		for idx := count; idx < node.end; idx++ {
+			node.data[idx] = zero
+		}
+		node.start, node.end = 0, count+copy(node.data[count:], next.data[next.start:next.end])
+		l.unlink(next)
+	}
+}
+
+// PushBack adds an element to the back of the UnrolledList.
+func (l *UnrolledList[T]) PushBack(value T) {
+	if l.back == nil || l.back.end == unrolledListNodeSize {
+		l.linkAfter(l.back, &unrolledListNode[T]{})
+	}
+	l.back.data[l.back.end] = value
+	l.back.end++
+	l.size++
+}
+
+// PushBackRef adds an element to the back of the UnrolledList.
+func (l *UnrolledList[T]) PushBackRef(value *T) {
+	l.PushBack(*value)
+}
+
+// PushFront adds an element to the front of the UnrolledList.
+func (l *UnrolledList[T]) PushFront(value T) {
+	if l.front == nil || l.front.start == 0 {
+		// Fill new front nodes from the back, so later PushFronts do not shift elements
+		l.linkAfter(nil, &unrolledListNode[T]{start: unrolledListNodeSize, end: unrolledListNodeSize})
+	}
+	l.front.start--
+	l.front.data[l.front.start] = value
+	l.size++
+}
+
+// PushFrontRef adds an element to the front of the UnrolledList.
+func (l *UnrolledList[T]) PushFrontRef(value *T) {
+	l.PushFront(*value)
+}
+
+// PopBack removes an element from the back of the UnrolledList.
+//
+// If the element implements the Destructible interface, it will have the Destruct method called on it.
+func (l *UnrolledList[T]) PopBack() {
+	if l.IsEmpty() {
+		panic("ERROR: UnrolledList.PopBack - empty list")
+	}
+	l.back.end--
+	destroyUnrolledListElement(l.back, l.back.end)
+	l.size--
+	if l.back.count() == 0 {
+		l.unlink(l.back)
+	}
+}
+
+// PopFront removes an element from the front of the UnrolledList.
+//
+// If the element implements the Destructible interface, it will have the Destruct method called on it.
+func (l *UnrolledList[T]) PopFront() {
+	if l.IsEmpty() {
+		panic("ERROR: UnrolledList.PopFront - empty list")
+	}
+	destroyUnrolledListElement(l.front, l.front.start)
+	l.front.start++
+	l.size--
+	if l.front.count() == 0 {
+		l.unlink(l.front)
+	}
+}
+
+// Swap swaps the data of two UnrolledLists.
+func (l *UnrolledList[T]) Swap(other *UnrolledList[T]) {
+	*l, *other = *other, *l
+}
+
+// Clone returns a new UnrolledList with copies of the elements, that does not share any nodes
+// with this one.
+//
+// Elements are copied by assignment, so pointers, slices and maps inside them are still shared.
+// Use DeepClone to copy those too.
+func (l *UnrolledList[T]) Clone() UnrolledList[T] {
+	return NewUnrolledListFromUnrolledList(*l)
+}
+
+// DeepClone returns a new UnrolledList with deep copies of the elements, see Vector.DeepClone.
+func (l *UnrolledList[T]) DeepClone() UnrolledList[T] {
+	result := l.Clone()
+	result.Visit(func(item *T, break_out *bool) {
+		*item = DeepCopy(*item)
+	})
+	return result
+}
+
+// Visit calls a function for every element in the UnrolledList.
+func (l *UnrolledList[T]) Visit(visitor CollectionVisitor[T]) {
+	break_out := false
+	for node := l.front; !break_out && node != nil; node = node.next {
+		for idx := node.start; !break_out && idx < node.end; idx++ {
+			visitor(&node.data[idx], &break_out)
+		}
+	}
+}
+
+// VisitReverse calls a function for every element in the UnrolledList in reverse order.
+func (l *UnrolledList[T]) VisitReverse(visitor CollectionVisitor[T]) {
+	break_out := false
+	for node := l.back; !break_out && node != nil; node = node.prev {
+		for idx := node.end - 1; !break_out && idx >= node.start; idx-- {
+			visitor(&node.data[idx], &break_out)
+		}
+	}
+}
+
+func (l *UnrolledList[T]) indexWhere(predicate CollectionPredicate[T]) (found bool, index int, ref *T) {
+	index = 0
+	l.Visit(func(vv *T, break_out *bool) {
+		if predicate(vv) {
+			found = true
+			ref = vv
+			*break_out = true
+		} else {
+			index++
+		}
+	})
+	if !found {
+		index = -1
+	}
+	return
+}
+
+// ContainsValue returns true if the UnrolledList contains value.
+//
+// Panics if the UnrolledList has no Equaler for its element type.
+func (l *UnrolledList[T]) ContainsValue(value T) bool {
+	return l.OrderedSearchRef(value) != nil
+}
+
+// ContainsRef returns true if the UnrolledList contains the exact reference of value.
+func (l *UnrolledList[T]) ContainsRef(value *T) bool {
+	return l.OrderedRefSearchRef(value) != nil
+}
+
+// OrderedSearch searches for a value, and returns the index to the first match.
+//
+// Panics if the UnrolledList has no Equaler for its element type.
+func (l *UnrolledList[T]) OrderedSearch(value T) (found bool, index int) {
+	equal := l.equaler("OrderedSearch")
+	found, index, _ = l.indexWhere(func(vv *T) bool { return equal(vv, &value) })
+	return
+}
+
+// OrderedRefSearch searches for an instance, and returns the index to the first match.
+func (l *UnrolledList[T]) OrderedRefSearch(value *T) (found bool, index int) {
+	found, index, _ = l.indexWhere(func(vv *T) bool { return vv == value })
+	return
+}
+
+// OrderedSearchRef searches for a value, and returns a pointer to the first match.
+//
+// Panics if the UnrolledList has no Equaler for its element type.
+func (l *UnrolledList[T]) OrderedSearchRef(value T) *T {
+	equal := l.equaler("OrderedSearchRef")
+	_, _, ref := l.indexWhere(func(vv *T) bool { return equal(vv, &value) })
+	return ref
+}
+
+// OrderedRefSearchRef searches for an instance, and returns a pointer to the first match.
+func (l *UnrolledList[T]) OrderedRefSearchRef(value *T) *T {
+	_, _, ref := l.indexWhere(func(vv *T) bool { return vv == value })
+	return ref
+}
+
+// Search searches for a value, and returns an index to a match.
+//
+// Panics if the UnrolledList has no Equaler for its element type.
+func (l *UnrolledList[T]) Search(value T) (found bool, index int) {
+	return l.OrderedSearch(value)
+}
+
+// RefSearch searches for an instance, and returns an index to a match.
+func (l *UnrolledList[T]) RefSearch(value *T) (found bool, index int) {
+	return l.OrderedRefSearch(value)
+}
+
+// SearchRef searches for a value, and returns a pointer to a match.
+//
+// Panics if the UnrolledList has no Equaler for its element type.
+func (l *UnrolledList[T]) SearchRef(value T) *T {
+	return l.OrderedSearchRef(value)
+}
+
+// RefSearchRef searches for an instance, and returns a pointer to a match.
+func (l *UnrolledList[T]) RefSearchRef(value *T) *T {
+	return l.OrderedRefSearchRef(value)
+}
+
+// FindIf returns a pointer to the first element that satisfies predicate, or nil if there is none.
+func (l *UnrolledList[T]) FindIf(predicate CollectionPredicate[T]) *T {
+	_, _, ref := l.indexWhere(predicate)
+	return ref
+}
+
+// FindLastIf returns a pointer to the last element that satisfies predicate, or nil if there is none.
+func (l *UnrolledList[T]) FindLastIf(predicate CollectionPredicate[T]) *T {
+	var ret *T = nil
+	l.VisitReverse(func(vv *T, break_out *bool) {
+		if predicate(vv) {
+			ret = vv
+			*break_out = true
+		}
+	})
+	return ret
+}
+
+// ContainsIf returns true if any element satisfies predicate.
+func (l *UnrolledList[T]) ContainsIf(predicate CollectionPredicate[T]) bool {
+	return l.FindIf(predicate) != nil
+}
+
+// CountIf returns the number of elements that satisfy predicate.
+func (l *UnrolledList[T]) CountIf(predicate CollectionPredicate[T]) int {
+	count := 0
+	l.Visit(func(vv *T, break_out *bool) {
+		if predicate(vv) {
+			count++
+		}
+	})
+	return count
+}
+
+// IndexIf returns the index of the first element that satisfies predicate, or -1 if there is none.
+func (l *UnrolledList[T]) IndexIf(predicate CollectionPredicate[T]) int {
+	_, index, _ := l.indexWhere(predicate)
+	return index
+}
+
+// ToVector returns a Vector holding a copy of the elements of the UnrolledList.
+func (l *UnrolledList[T]) ToVector() Vector[T] {
+	data := make([]T, 0, l.size)
+	for node := l.front; node != nil; node = node.next {
+		data = append(data, node.data[node.start:node.end]...)
+	}
+	return Vector[T]{data: data, equal: l.equal}
+}
+
+// String returns a string representation of the UnrolledList and it's contents.
+func (l *UnrolledList[T]) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "{")
+	first := true
+	l.Visit(func(value *T, break_out *bool) {
+		if !first {
+			fmt.Fprintf(&builder, ", ")
+		}
+		first = false
+		fmt.Fprintf(&builder, "%v", *value)
+	})
+	fmt.Fprintf(&builder, "}")
+	return builder.String()
+}
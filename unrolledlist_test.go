@@ -0,0 +1,142 @@
+package gollect
+
+import (
+	"testing"
+)
+
+func TestUnrolledListIsGeneralCollector(t *testing.T) {
+	l := NewUnrolledList[int64]()
+	if _, isGeneralCollector := interface{}(&l).(GeneralCollector[int64]); !isGeneralCollector {
+		t.Fatalf("UnrolledList[int64] should be a GeneralCollector[int64]")
+	}
+}
+
+func TestUnrolledListMatchesVector(t *testing.T) {
+	l := NewUnrolledList[int]()
+	v := NewVector[int]()
+	for i := 0; i < 3000; i++ {
+		switch i % 5 {
+		case 0:
+			l.PushFront(i)
+			v.PushFront(i)
+		case 1:
+			l.PushBack(i)
+			v.PushBack(i)
+		default:
+			index := (i * 7919) % (v.Size() + 1)
+			l.Insert(index, i)
+			v.Insert(index, i)
+		}
+		if i%4 == 3 {
+			index := (i * 104729) % v.Size()
+			l.Erase(index)
+			v.Erase(index)
+		}
+	}
+	if l.Size() != v.Size() || l.String() != v.String() {
+		t.Fatalf("UnrolledList should match the Vector")
+	}
+	for _, i := range []int{0, 1, 63, 64, 65, v.Size() / 2, v.Size() - 1} {
+		if l.At(i) != v.At(i) {
+			t.Fatalf("At(%v) should be %v, got %v", i, v.At(i), l.At(i))
+		}
+	}
+	for !v.IsEmpty() {
+		if v.Size()%2 == 0 {
+			l.PopFront()
+			v.PopFront()
+		} else {
+			l.Erase(v.Size() / 2)
+			v.Erase(v.Size() / 2)
+		}
+		if !v.IsEmpty() && (l.Front() != v.Front() || l.Back() != v.Back()) {
+			t.Fatalf("Front and Back should match the Vector")
+		}
+	}
+	if !l.IsEmpty() || l.front != nil || l.back != nil {
+		t.Fatalf("UnrolledList should be empty")
+	}
+}
+
+func TestUnrolledListNodes(t *testing.T) {
+	l := NewUnrolledList[int]()
+	for i := 0; i < 10*unrolledListNodeSize; i++ {
+		l.PushBack(i)
+	}
+	nodes := 0
+	for node := l.front; node != nil; node = node.next {
+		nodes++
+	}
+	if nodes != 10 {
+		t.Fatalf("PushBack should fill each node, got %v nodes", nodes)
+	}
+}
+
+func TestUnrolledListSearch(t *testing.T) {
+	l := NewUnrolledListFromData(5, 3, 8, 3)
+	if found, index := l.Search(3); !found || index != 1 {
+		t.Fatalf("Search(3) should find index 1, got %v (%v)", index, found)
+	}
+	if ref := l.FindLastIf(func(v *int) bool { return *v == 3 }); ref != l.AtRef(3) {
+		t.Fatalf("FindLastIf should find the last 3")
+	}
+	reversed := NewVector[int]()
+	l.VisitReverse(func(value *int, break_out *bool) {
+		reversed.PushBack(*value)
+	})
+	if reversed.String() != "{3, 8, 3, 5}" {
+		t.Fatalf("VisitReverse should give {3, 8, 3, 5}, got %v", reversed.String())
+	}
+}
+
+func TestUnrolledListDestruct(t *testing.T) {
+	Msgs = []string{}
+	l := NewUnrolledList[DBool]()
+	for i := 0; i < 100; i++ {
+		l.PushBack(true)
+	}
+	l.PopFront()
+	l.PopBack()
+	l.Erase(50)
+	l.Clear()
+	if len(Msgs) != 100 {
+		t.Fatalf("Destruct method should have been called 100 times, got %v", len(Msgs))
+	}
+}
+
+func TestUnrolledListEmptyPop(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: UnrolledList.PopFront - empty list" {
+			t.Fatalf("Should have panicked because the list is empty, got \"%v\"", result)
+		}
+	}()
+	l := NewUnrolledList[int]()
+	l.PopFront()
+}
+
+func BenchmarkUnrolledListVisit(b *testing.B) {
+	const count = 100000
+	b.Run("List", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			l := NewList[int]()
+			for j := 0; j < count; j++ {
+				l.PushBack(j)
+			}
+			total := 0
+			l.Visit(func(value *int, break_out *bool) { total += *value })
+		}
+	})
+	b.Run("UnrolledList", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			l := NewUnrolledList[int]()
+			for j := 0; j < count; j++ {
+				l.PushBack(j)
+			}
+			total := 0
+			l.Visit(func(value *int, break_out *bool) { total += *value })
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"golang.org/x/exp/constraints"
 )
@@ -18,7 +19,12 @@ import (
 // Note, if you want a Vector of a native type, you will most likely get significantly
 // better performance out of an NVector.
 type Vector[T any] struct {
-	data []T
+	data   []T
+	equal  Equaler[T]
+	growth GrowthPolicy
+	// front is the whole array data was sliced from by PopFront, so that the room in front of
+	// data can be reused when the Vector grows.
+	front []T
 }
 
 // NewVector creates a new empty Vector, by value
@@ -26,9 +32,23 @@ func NewVector[T any]() Vector[T] {
 	return Vector[T]{data: []T{}}
 }
 
-// NewVectorFromData creates a new Vector using the elements in values, by value.
+// NewVectorFromData creates a new Vector using copies of the elements in values, by value.
+//
+// The Vector never shares storage with values, even when called as NewVectorFromData(slice...).
+// Use NewVectorAdoptingSlice to avoid the copy.
 func NewVectorFromData[T any](values ...T) Vector[T] {
-	return Vector[T]{data: values}
+	return Vector[T]{data: append([]T{}, values...)}
+}
+
+// NewVectorAdoptingSlice creates a new Vector that takes ownership of data, without copying it,
+// by value.
+//
+// The caller must not use data afterwards, as the Vector may modify it or append to it.
+func NewVectorAdoptingSlice[T any](data []T) Vector[T] {
+	if data == nil {
+		data = []T{}
+	}
+	return Vector[T]{data: data}
 }
 
 // NewVectorFromDataRef creates a new Vector using pointers to the elements in values, by value.
@@ -40,11 +60,11 @@ func NewVectorFromDataRef[T any](values ...*T) Vector[T] {
 	return v
 }
 
-// NewVectorFromVector creates a new Vector using the values of another, by value.
+// NewVectorFromVector creates a new Vector using copies of the values of another, by value.
+//
+// This is the same as other.Clone().
 func NewVectorFromVector[T any](other Vector[T]) Vector[T] {
-	v := NewVectorFromData(other.data...)
-	//copy(v.data, other.data)
-	return v
+	return other.Clone()
 }
 
 // MakeVector creates a new empty Vector instance.
@@ -52,9 +72,22 @@ func MakeVector[T any]() *Vector[T] {
 	return &Vector[T]{data: []T{}}
 }
 
-// MakeVectorFromData creates a new Vector instance using the elements in values.
+// MakeVectorFromData creates a new Vector instance using copies of the elements in values.
+//
+// The Vector never shares storage with values, even when called as MakeVectorFromData(slice...).
+// Use MakeVectorAdoptingSlice to avoid the copy.
 func MakeVectorFromData[T any](values ...T) *Vector[T] {
-	return &Vector[T]{data: values}
+	v := NewVectorFromData(values...)
+	return &v
+}
+
+// MakeVectorAdoptingSlice creates a new Vector instance that takes ownership of data, without
+// copying it.
+//
+// The caller must not use data afterwards, as the Vector may modify it or append to it.
+func MakeVectorAdoptingSlice[T any](data []T) *Vector[T] {
+	v := NewVectorAdoptingSlice(data)
+	return &v
 }
 
 // MakeVectorFromDataRef creates a new Vector instance using pointers to the elements in values.
@@ -66,10 +99,40 @@ func MakeVectorFromDataRef[T any](values ...*T) *Vector[T] {
 	return v
 }
 
-// MakeVectorFromVector creates a new Vector instance using the values of another.
+// MakeVectorFromVector creates a new Vector instance using copies of the values of another.
 func MakeVectorFromVector[T any](other Vector[T]) *Vector[T] {
-	v := MakeVectorFromData(other.data...)
-	return v
+	v := other.Clone()
+	return &v
+}
+
+// NewVectorWithEqualer creates a new Vector using copies of the elements in values, that
+// compares its elements with equal, by value.
+func NewVectorWithEqualer[T any](equal Equaler[T], values ...T) Vector[T] {
+	return Vector[T]{data: append([]T{}, values...), equal: equal}
+}
+
+// MakeVectorWithEqualer creates a new Vector instance using copies of the elements in values,
+// that compares its elements with equal.
+func MakeVectorWithEqualer[T any](equal Equaler[T], values ...T) *Vector[T] {
+	return &Vector[T]{data: append([]T{}, values...), equal: equal}
+}
+
+// SetEqualer sets the function used to compare elements in value-based searches.
+//
+// If equal is nil, the Vector falls back to the EqualityComparable implementation of its
+// element type, or to `==` if the element type is comparable.
+func (v *Vector[T]) SetEqualer(equal Equaler[T]) {
+	v.equal = equal
+}
+
+// equaler returns the Equaler used by value-based searches, panicking on behalf of method if
+// there is none.
+func (v *Vector[T]) equaler(method string) Equaler[T] {
+	equal := resolveEqualer(v.equal)
+	if equal == nil {
+		panic("ERROR: Vector." + method + " - no Equaler for element type")
+	}
+	return equal
 }
 
 // At gets the element at index by value.
@@ -145,6 +208,9 @@ func (v *Vector[T]) BackRef() *T {
 }
 
 // Data gets the underlying slice of the elements.
+//
+// Note, the slice is still owned by the Vector. Changes to its elements are visible in the
+// Vector, and it may be reallocated by the next change to the Vector.
 func (v *Vector[T]) Data() []T {
 	return v.data
 }
@@ -163,43 +229,44 @@ func (v *Vector[T]) Size() int {
 //
 // If the elements implement the Destructible interface, then they will have the Destruct method called on them.
 func (v *Vector[T]) Clear() {
-	for !v.IsEmpty() {
-		v.PopBack()
-	}
+	v.Truncate(0)
 }
 
-// Insert adds an element at the specified index, moving all later elements one further index back.
-func (v *Vector[T]) Insert(index int, value T) {
-	if (index < 0) || (index > v.Size()) {
-		panic("ERROR: Vector.Insert - index out of bounds")
+// reclaim moves the elements back to the start of their array, if PopFront left enough room in
+// front of them to hold needed elements without allocating. values may be a slice of the
+// Vector's own data, so a copy of it is returned if the elements were moved.
+func (v *Vector[T]) reclaim(needed int, values []T) []T {
+	if v.front == nil || needed <= cap(v.data) {
+		return values
 	}
-	if v.Size() != 0 {
-		if index == v.Size() {
-			v.data = append(v.data, value)
-		} else {
-			v.data = append(v.data[:index+1], v.data[index:]...)
-			v.data[index] = value
+	if cap(v.front) >= needed && slicesAlias(v.front, v.data) {
+		if len(values) > 0 {
+			values = append([]T{}, values...)
 		}
-	} else {
-		v.data = []T{value}
+		front := v.front[:cap(v.front)]
+		size := copy(front, v.data)
+		var zero T
+		for idx := size; idx < size+cap(v.front)-cap(v.data); idx++ {
+			front[idx] = zero
+		}
+		v.data = front[:size]
 	}
+	v.front = nil
+	return values
 }
 
 // Insert adds an element at the specified index, moving all later elements one further index back.
-func (v *Vector[T]) InsertRef(index int, value *T) {
+func (v *Vector[T]) Insert(index int, value T) {
 	if (index < 0) || (index > v.Size()) {
 		panic("ERROR: Vector.Insert - index out of bounds")
 	}
-	if v.Size() != 0 {
-		if index == v.Size() {
-			v.data = append(v.data, *value)
-		} else {
-			v.data = append(v.data[:index+1], v.data[index:]...)
-			v.data[index] = *value
-		}
-	} else {
-		v.data = []T{*value}
-	}
+	v.reclaim(len(v.data)+1, nil)
+	v.data = insertSlice(v.data, index, value, v.growth)
+}
+
+// InsertRef adds an element at the specified index, moving all later elements one further index back.
+func (v *Vector[T]) InsertRef(index int, value *T) {
+	v.Insert(index, *value)
 }
 
 // Erase removes an element at the specified index, moving all later elements one index forward.
@@ -218,24 +285,97 @@ func (v *Vector[T]) Erase(index int) {
 	}
 }
 
+// InsertRange adds copies of values at the specified index, moving all later elements back in a
+// single move. values may be a slice of the Vector's own Data.
+func (v *Vector[T]) InsertRange(index int, values ...T) {
+	if (index < 0) || (index > v.Size()) {
+		panic("ERROR: Vector.InsertRange - index out of bounds")
+	}
+	values = v.reclaim(len(v.data)+len(values), values)
+	v.data = insertSliceRange(v.data, index, values, v.growth)
+}
+
+// InsertVector adds copies of the elements of other at the specified index, moving all later
+// elements back in a single move.
+func (v *Vector[T]) InsertVector(index int, other Vector[T]) {
+	if (index < 0) || (index > v.Size()) {
+		panic("ERROR: Vector.InsertVector - index out of bounds")
+	}
+	values := v.reclaim(len(v.data)+len(other.data), other.data)
+	v.data = insertSliceRange(v.data, index, values, v.growth)
+}
+
+// AppendVector adds copies of the elements of other to the back of the Vector.
+func (v *Vector[T]) AppendVector(other Vector[T]) {
+	values := v.reclaim(len(v.data)+len(other.data), other.data)
+	v.data = insertSliceRange(v.data, len(v.data), values, v.growth)
+}
+
+// EraseRange removes the elements in the range [from, to), moving all later elements forward in
+// a single move.
+//
+// If the elements implement the Destructible interface, they will have the Destruct method called on them.
+func (v *Vector[T]) EraseRange(from int, to int) {
+	if (from < 0) || (to > v.Size()) || (from > to) {
+		panic("ERROR: Vector.EraseRange - range out of bounds")
+	}
+	destructSlice(v.data[from:to])
+	v.data = eraseSliceRange(v.data, from, to)
+}
+
+// EraseIf removes every element that satisfies predicate in a single pass, keeping the order of
+// the rest, and returns the number of elements removed.
+//
+// If the elements implement the Destructible interface, the removed ones will have the Destruct method called on them.
+func (v *Vector[T]) EraseIf(predicate CollectionPredicate[T]) int {
+	var removed int
+	v.data, removed = eraseSliceIf(v.data, predicate, true)
+	return removed
+}
+
+// Truncate removes all the elements from index size onwards, keeping the capacity.
+//
+// If the elements implement the Destructible interface, they will have the Destruct method called on them.
+func (v *Vector[T]) Truncate(size int) {
+	if (size < 0) || (size > v.Size()) {
+		panic("ERROR: Vector.Truncate - size out of bounds")
+	}
+	v.EraseRange(size, v.Size())
+}
+
+// Assign replaces the elements of the Vector with copies of values, reusing its storage when it
+// is large enough. values may be a slice of the Vector's own Data.
+//
+// If the old elements implement the Destructible interface, they will have the Destruct method called on them.
+func (v *Vector[T]) Assign(values ...T) {
+	if slicesAlias(v.data, values) {
+		values = append([]T{}, values...)
+	}
+	v.Truncate(0)
+	values = v.reclaim(len(values), values)
+	v.data = insertSliceRange(v.data, 0, values, v.growth)
+}
+
 // PushBack adds an element to the back of the Vector.
 func (v *Vector[T]) PushBack(value T) {
-	v.data = append(v.data, value)
+	v.reclaim(len(v.data)+1, nil)
+	v.data = append(reserveSlice(v.data, len(v.data)+1, v.growth), value)
 }
 
 // PushBackRef adds an element to the back of the Vector.
 func (v *Vector[T]) PushBackRef(value *T) {
-	v.data = append(v.data, *value)
+	v.PushBack(*value)
 }
 
 // PushFront adds an element to the front of the Vector, moving all later elements one index backward.
 func (v *Vector[T]) PushFront(value T) {
-	v.data = append([]T{value}, v.data...)
+	v.reclaim(len(v.data)+1, nil)
+	v.data = insertSlice(v.data, 0, value, v.growth)
 }
 
 // PushFrontRef adds an element to the front of the Vector, moving all later elements one index backward.
 func (v *Vector[T]) PushFrontRef(value *T) {
-	v.data = append([]T{*value}, v.data...)
+	v.PushFront(*value)
 }
 
 // PopBack removes an element from the back of the Vector.
@@ -254,13 +394,25 @@ func (v *Vector[T]) PopBack() {
 
 // PopFront removes an element from the front of the Vector, moving all later elements one index forward.
 //
+// The elements are not moved right away. The room left in front of them is reused once the
+// Vector needs it to grow, so it still counts towards Capacity.
+//
 // If the element implements the Destructible interface, it will have the Destruct method called on it.
 func (v *Vector[T]) PopFront() {
 	if !v.IsEmpty() {
 		if e, isDestructible := interface{}(v.FrontRef()).(Destructible); isDestructible {
 			e.Destruct()
 		}
+		if v.front == nil || !slicesAlias(v.front, v.data) {
+			v.front = v.data
+		}
+		var zero T
+		v.data[0] = zero
 		v.data = v.data[1:]
+		if len(v.data) == 0 {
+			v.data = v.front[:0]
+			v.front = nil
+		}
 	} else {
 		panic("ERROR: Vector.PopFront - empty vector")
 	}
@@ -279,9 +431,13 @@ func (v *Vector[T]) Resize(new_size int) {
 	if new_size == v.Size() {
 		return
 	} else if new_size > v.Size() {
-		tmp := make([]T, new_size)
-		copy(tmp, v.data)
-		v.data = tmp
+		size := v.Size()
+		v.reclaim(new_size, nil)
+		v.data = reserveSlice(v.data, new_size, v.growth)[:new_size]
+		var zero T
+		for idx := size; idx < new_size; idx++ {
+			v.data[idx] = zero
+		}
 	} else {
 		for v.Size() > new_size {
 			v.PopBack()
@@ -289,9 +445,69 @@ func (v *Vector[T]) Resize(new_size int) {
 	}
 }
 
+// Reserve makes sure the Vector has room for at least capacity elements without allocating.
+func (v *Vector[T]) Reserve(capacity int) {
+	if capacity < 0 {
+		panic("ERROR: Vector.Reserve - negative capacity")
+	}
+	v.reclaim(capacity, nil)
+	v.data = reserveSliceExact(v.data, capacity)
+}
+
+// Capacity returns the number of elements the Vector can hold without allocating.
+func (v *Vector[T]) Capacity() int {
+	if v.front != nil && slicesAlias(v.front, v.data) {
+		return cap(v.front)
+	}
+	return cap(v.data)
+}
+
+// ShrinkToFit releases any spare capacity of the Vector.
+func (v *Vector[T]) ShrinkToFit() {
+	if v.Capacity() != cap(v.data) {
+		v.data = append(make([]T, 0, len(v.data)), v.data...)
+	}
+	v.front = nil
+	v.data = shrinkSlice(v.data)
+}
+
+// SetGrowthPolicy sets how the Vector grows when it runs out of capacity.
+func (v *Vector[T]) SetGrowthPolicy(policy GrowthPolicy) {
+	policy.validate("Vector.SetGrowthPolicy")
+	v.growth = policy
+}
+
+// GrowthPolicy returns how the Vector grows when it runs out of capacity.
+func (v *Vector[T]) GrowthPolicy() GrowthPolicy {
+	return v.growth
+}
+
 // Swap swaps the data of two Vectors.
 func (v *Vector[T]) Swap(other *Vector[T]) {
 	v.data, other.data = other.data, v.data
+	v.front, other.front = other.front, v.front
+}
+
+// Clone returns a new Vector with copies of the elements, that does not share storage with this
+// one.
+//
+// Elements are copied by assignment, so pointers, slices and maps inside them are still shared.
+// Use DeepClone to copy those too.
+func (v *Vector[T]) Clone() Vector[T] {
+	return Vector[T]{data: append(make([]T, 0, len(v.data)), v.data...), equal: v.equal, growth: v.growth}
+}
+
+// DeepClone returns a new Vector with deep copies of the elements.
+//
+// Elements implementing DeepCloner[T] or Cloner[T] are copied with their DeepClone or Clone
+// method. Other elements are copied recursively, following pointers, slices, maps and
+// interfaces, see DeepCopy.
+func (v *Vector[T]) DeepClone() Vector[T] {
+	result := Vector[T]{data: make([]T, len(v.data)), equal: v.equal, growth: v.growth}
+	for idx := range v.data {
+		result.data[idx] = DeepCopy(v.data[idx])
+	}
+	return result
 }
 
 // String returns a string representation of the Vector and it's contents.
@@ -336,18 +552,17 @@ func (v *Vector[T]) VisitReverse(visitor CollectionVisitor[T]) {
 }
 
 // ContainsValue returns true if the Vector contains value.
+//
+// Panics if the Vector has no Equaler for its element type.
 func (v *Vector[T]) ContainsValue(value T) bool {
+	equal := v.equaler("ContainsValue")
 	if v.IsEmpty() {
 		return false
 	}
-	if _, isEqComparable := interface{}(v.FrontRef()).(EqualityComparable[T]); !isEqComparable {
-		return false
-	}
 	ret := false
 	idx := 0
 	v.Visit(func(vv *T, break_out *bool) {
-		vvv, _ := interface{}(vv).(EqualityComparable[T])
-		if vvv.Equal(value) {
+		if equal(vv, &value) {
 			ret = true
 			*break_out = true
 		}
@@ -374,18 +589,17 @@ func (v *Vector[T]) ContainsRef(value *T) bool {
 }
 
 // OrderedSearch searches for a value, and returns the index to the first match.
+//
+// Panics if the Vector has no Equaler for its element type.
 func (v *Vector[T]) OrderedSearch(value T) (found bool, index int) {
+	equal := v.equaler("OrderedSearch")
 	if v.IsEmpty() {
 		return false, -1
 	}
-	if _, isEqComparable := interface{}(v.FrontRef()).(EqualityComparable[T]); !isEqComparable {
-		return false, -1
-	}
 	found = false
 	index = 0
 	v.Visit(func(vv *T, break_out *bool) {
-		vvv, _ := interface{}(vv).(EqualityComparable[T])
-		if vvv.Equal(value) {
+		if equal(vv, &value) {
 			found = true
 			*break_out = true
 		} else {
@@ -414,18 +628,17 @@ func (v *Vector[T]) OrderedRefSearch(value *T) (found bool, index int) {
 }
 
 // OrderedSearchRef searches for a value, and returns a pointer to the first match.
+//
+// Panics if the Vector has no Equaler for its element type.
 func (v *Vector[T]) OrderedSearchRef(value T) *T {
+	equal := v.equaler("OrderedSearchRef")
 	if v.IsEmpty() {
 		return nil
 	}
-	if _, isEqComparable := interface{}(v.FrontRef()).(EqualityComparable[T]); !isEqComparable {
-		return nil
-	}
 	var ret *T = nil
 	index := 0
 	v.Visit(func(vv *T, break_out *bool) {
-		vvv, _ := interface{}(vv).(EqualityComparable[T])
-		if vvv.Equal(value) {
+		if equal(vv, &value) {
 			ret = vv
 			*break_out = true
 		}
@@ -458,7 +671,10 @@ var ChunkMultiplier = 4 // TODO: try to fine-tune this magic number a little bit
 //
 // Note, this method may perform a search in parallel, so the match might not be the first
 // in the Vector.
+//
+// Panics if the Vector has no Equaler for its element type.
 func (v *Vector[T]) Search(value T) (found bool, index int) {
+	equal := v.equaler("Search")
 	found = false
 	index = -1
 
@@ -466,10 +682,6 @@ func (v *Vector[T]) Search(value T) (found bool, index int) {
 		return
 	}
 
-	if _, isEqComparable := interface{}(v.FrontRef()).(EqualityComparable[T]); !isEqComparable {
-		return
-	}
-
 	chunks := runtime.GOMAXPROCS(0)
 	if v.Size() < chunks*ChunkMultiplier {
 		chunks = 1
@@ -491,8 +703,7 @@ func (v *Vector[T]) Search(value T) (found bool, index int) {
 			}
 
 			for index2 := chunk_size * ii; index2 < (chunk_size*ii)+my_chunk_size; index2++ {
-				vv, _ := interface{}(v.AtRef(index2)).(EqualityComparable[T])
-				if vv.Equal(value) {
+				if equal(v.AtRef(index2), &value) {
 					retMtx.Lock()
 					found = true
 					index = index2
@@ -573,17 +784,16 @@ func (v *Vector[T]) RefSearch(value *T) (found bool, index int) {
 //
 // Note, this method may perform a search in parallel, so the match might not be the first
 // in the Vector.
+//
+// Panics if the Vector has no Equaler for its element type.
 func (v *Vector[T]) SearchRef(value T) *T {
+	equal := v.equaler("SearchRef")
 	var ret *T = nil
 
 	if v.IsEmpty() {
 		return ret
 	}
 
-	if _, isEqComparable := interface{}(v.FrontRef()).(EqualityComparable[T]); !isEqComparable {
-		return ret
-	}
-
 	chunks := runtime.GOMAXPROCS(0)
 	if v.Size() < chunks*ChunkMultiplier {
 		chunks = 1
@@ -605,8 +815,7 @@ func (v *Vector[T]) SearchRef(value T) *T {
 			}
 
 			for index2 := chunk_size * ii; index2 < (chunk_size*ii)+my_chunk_size; index2++ {
-				vv, _ := interface{}(v.AtRef(index2)).(EqualityComparable[T])
-				if vv.Equal(value) {
+				if equal(v.AtRef(index2), &value) {
 					retMtx.Lock()
 					ret = v.AtRef(index2)
 					retMtx.Unlock()
@@ -680,6 +889,169 @@ func (v *Vector[T]) RefSearchRef(value *T) *T {
 	return ret
 }
 
+// FindIf returns a pointer to the first element that satisfies predicate, or nil if there is none.
+func (v *Vector[T]) FindIf(predicate CollectionPredicate[T]) *T {
+	for idx := range v.data {
+		if predicate(&v.data[idx]) {
+			return &v.data[idx]
+		}
+	}
+	return nil
+}
+
+// FindLastIf returns a pointer to the last element that satisfies predicate, or nil if there is none.
+func (v *Vector[T]) FindLastIf(predicate CollectionPredicate[T]) *T {
+	for idx := v.Size() - 1; idx >= 0; idx-- {
+		if predicate(&v.data[idx]) {
+			return &v.data[idx]
+		}
+	}
+	return nil
+}
+
+// ContainsIf returns true if any element satisfies predicate.
+func (v *Vector[T]) ContainsIf(predicate CollectionPredicate[T]) bool {
+	return v.IndexIf(predicate) >= 0
+}
+
+// CountIf returns the number of elements that satisfy predicate.
+func (v *Vector[T]) CountIf(predicate CollectionPredicate[T]) int {
+	count := 0
+	for idx := range v.data {
+		if predicate(&v.data[idx]) {
+			count++
+		}
+	}
+	return count
+}
+
+// IndexIf returns the index of the first element that satisfies predicate, or -1 if there is none.
+func (v *Vector[T]) IndexIf(predicate CollectionPredicate[T]) int {
+	for idx := range v.data {
+		if predicate(&v.data[idx]) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// visitChunks splits the range [0, size) into chunks and calls work on each of them in parallel.
+//
+// Like Search, the range is only split up if size is at least ChunkMultiplier times GOMAXPROCS.
+func visitChunks(size int, work func(chunk_start int, chunk_end int)) {
+	chunks := runtime.GOMAXPROCS(0)
+	if size < chunks*ChunkMultiplier {
+		chunks = 1
+	}
+	chunk_size := size / chunks
+	chunk_rem := size % chunks
+	last_chunk_num := chunks - 1
+
+	var waitGrp sync.WaitGroup
+	for i := 0; i < chunks; i++ {
+		chunk_start := chunk_size * i
+		chunk_end := chunk_start + chunk_size
+		if i == last_chunk_num {
+			chunk_end += chunk_rem
+		}
+		waitGrp.Add(1)
+		go func(start int, end int) {
+			defer waitGrp.Done()
+			work(start, end)
+		}(chunk_start, chunk_end)
+	}
+	waitGrp.Wait()
+}
+
+// ParallelIndexIf returns the index of an element that satisfies predicate, or -1 if there is none.
+//
+// Note, this method may perform the search in parallel, so the match might not be the first
+// in the Vector, and predicate must be safe to call concurrently.
+func (v *Vector[T]) ParallelIndexIf(predicate CollectionPredicate[T]) int {
+	if v.IsEmpty() {
+		return -1
+	}
+	var found atomic.Bool
+	var retMtx sync.Mutex
+	index := -1
+	visitChunks(v.Size(), func(chunk_start int, chunk_end int) {
+		for idx := chunk_start; idx < chunk_end && !found.Load(); idx++ {
+			if predicate(v.AtRef(idx)) {
+				retMtx.Lock()
+				if index < 0 {
+					index = idx
+				}
+				retMtx.Unlock()
+				found.Store(true)
+				return
+			}
+		}
+	})
+	return index
+}
+
+// ParallelFindIf returns a pointer to an element that satisfies predicate, or nil if there is none.
+//
+// Note, this method may perform the search in parallel, so the match might not be the first
+// in the Vector, and predicate must be safe to call concurrently.
+func (v *Vector[T]) ParallelFindIf(predicate CollectionPredicate[T]) *T {
+	if index := v.ParallelIndexIf(predicate); index >= 0 {
+		return v.AtRef(index)
+	}
+	return nil
+}
+
+// ParallelContainsIf returns true if any element satisfies predicate.
+//
+// Note, this method may perform the search in parallel, so predicate must be safe to call
+// concurrently.
+func (v *Vector[T]) ParallelContainsIf(predicate CollectionPredicate[T]) bool {
+	return v.ParallelIndexIf(predicate) >= 0
+}
+
+// ParallelCountIf returns the number of elements that satisfy predicate.
+//
+// Note, this method may count in parallel, so predicate must be safe to call concurrently.
+func (v *Vector[T]) ParallelCountIf(predicate CollectionPredicate[T]) int {
+	var count atomic.Int64
+	visitChunks(v.Size(), func(chunk_start int, chunk_end int) {
+		var chunk_count int64 = 0
+		for idx := chunk_start; idx < chunk_end; idx++ {
+			if predicate(v.AtRef(idx)) {
+				chunk_count++
+			}
+		}
+		count.Add(chunk_count)
+	})
+	return int(count.Load())
+}
+
+// SortBy sorts the elements of the Vector according to compare.
+func (v *Vector[T]) SortBy(compare Comparator[T]) {
+	sort.Slice(v.data, func(i, j int) bool { return compare(&v.data[i], &v.data[j]) < 0 })
+}
+
+// StableSortBy sorts the elements of the Vector according to compare, keeping equal elements in
+// their original order.
+func (v *Vector[T]) StableSortBy(compare Comparator[T]) {
+	sort.SliceStable(v.data, func(i, j int) bool { return compare(&v.data[i], &v.data[j]) < 0 })
+}
+
+// IsSortedBy returns true if the elements of the Vector are sorted according to compare.
+func (v *Vector[T]) IsSortedBy(compare Comparator[T]) bool {
+	return sort.SliceIsSorted(v.data, func(i, j int) bool { return compare(&v.data[i], &v.data[j]) < 0 })
+}
+
+// BinarySearchBy searches a Vector sorted according to compare for value.
+//
+// If value is found, index is the index of the first match, otherwise it is the index value
+// would have to be inserted at to keep the Vector sorted.
+func (v *Vector[T]) BinarySearchBy(value T, compare Comparator[T]) (found bool, index int) {
+	index = sort.Search(v.Size(), func(i int) bool { return compare(&v.data[i], &value) >= 0 })
+	found = index < v.Size() && compare(&v.data[index], &value) == 0
+	return
+}
+
 type SortableVector[T constraints.Ordered] struct {
 	Vector[T]
 }
@@ -728,12 +1100,19 @@ func MakeSortableVectorFromVector[T constraints.Ordered](other SortableVector[T]
 	return v
 }
 
+// Sort sorts the elements of the SortableVector using the native `<` operator.
+//
+// NaN values are sorted before any other value.
 func (v *SortableVector[T]) Sort() {
-	sort.Slice(v.data, func(i, j int) bool { return v.data[i] < v.data[j] })
+	sort.Slice(v.data, func(i, j int) bool { return lessNative(v.data[i], v.data[j]) })
 }
 
+// StableSort sorts the elements of the SortableVector using the native `<` operator, keeping
+// equal elements in their original order.
+//
+// NaN values are sorted before any other value.
 func (v *SortableVector[T]) StableSort() {
-	sort.SliceStable(v.data, func(i, j int) bool { return v.data[i] < v.data[j] })
+	sort.SliceStable(v.data, func(i, j int) bool { return lessNative(v.data[i], v.data[j]) })
 }
 
 func (v *SortableVector[T]) SortFunc(f func(left *T, right *T) bool) {
@@ -745,9 +1124,13 @@ func (v *SortableVector[T]) StableSortFunc(f func(left *T, right *T) bool) {
 }
 
 func (v *SortableVector[T]) IsSorted() bool {
-	return sort.SliceIsSorted(v.data, func(i, j int) bool { return v.data[i] < v.data[j] })
+	return sort.SliceIsSorted(v.data, func(i, j int) bool { return lessNative(v.data[i], v.data[j]) })
 }
 
 func (v *SortableVector[T]) IsSortedFunc(f func(left *T, right *T) bool) bool {
 	return sort.SliceIsSorted(v.data, func(i, j int) bool { return f(&v.data[i], &v.data[j]) })
 }
+
+func (v *SortableVector[T]) BinarySearch(value T) (found bool, index int) {
+	return v.BinarySearchBy(value, NativeComparator[T]())
+}
@@ -59,6 +59,27 @@ func TestNewVectorFromVector(t *testing.T) {
 	}
 }
 
+func TestVectorConstructorsCopy(t *testing.T) {
+	data := make([]int, 3, 10)
+	v1 := NewVectorFromData(data...)
+	v1.PushBack(4)
+	*v1.AtRef(0) = 1
+	if data[:4][3] != 0 || data[0] != 0 {
+		t.Fatalf("NewVectorFromData should not share the caller's slice")
+	}
+	v2 := NewVectorFromVector(v1)
+	v2.Insert(1, 5)
+	*v2.AtRef(0) = 2
+	if v1.String() != "{1, 0, 0, 4}" {
+		t.Fatalf("NewVectorFromVector should not share storage, got %v", v1.String())
+	}
+	adopted := NewVectorAdoptingSlice(data)
+	*adopted.AtRef(2) = 3
+	if data[2] != 3 {
+		t.Fatalf("NewVectorAdoptingSlice should use the caller's slice")
+	}
+}
+
 func TestAt(t *testing.T) {
 	v := NewVectorFromData(1, 2, 3)
 	if v.At(0) != 1 {
@@ -616,6 +637,134 @@ func TestIsVectorGeneralCollector(t *testing.T) {
 	}
 }
 
+type plainPoint struct {
+	X, Y int
+}
+
+func TestFindIf(t *testing.T) {
+	v := NewVectorFromData(plainPoint{1, 2}, plainPoint{3, 4}, plainPoint{1, 5})
+	first := v.FindIf(func(p *plainPoint) bool { return p.X == 1 })
+	if first != v.AtRef(0) {
+		t.Fatalf("FindIf should return a pointer to element 0, got %v", first)
+	}
+	last := v.FindLastIf(func(p *plainPoint) bool { return p.X == 1 })
+	if last != v.AtRef(2) {
+		t.Fatalf("FindLastIf should return a pointer to element 2, got %v", last)
+	}
+	if v.FindIf(func(p *plainPoint) bool { return p.X == 9 }) != nil {
+		t.Fatalf("FindIf should return nil when nothing matches")
+	}
+	if idx := v.IndexIf(func(p *plainPoint) bool { return p.Y == 4 }); idx != 1 {
+		t.Fatalf("IndexIf should return 1, got %v", idx)
+	}
+	if idx := v.IndexIf(func(p *plainPoint) bool { return p.Y == 9 }); idx != -1 {
+		t.Fatalf("IndexIf should return -1, got %v", idx)
+	}
+	if c := v.CountIf(func(p *plainPoint) bool { return p.X == 1 }); c != 2 {
+		t.Fatalf("CountIf should return 2, got %v", c)
+	}
+	if !v.ContainsIf(func(p *plainPoint) bool { return p.Y == 5 }) {
+		t.Fatalf("ContainsIf should return true")
+	}
+}
+
+func TestParallelFindIf(t *testing.T) {
+	old_multiplier := ChunkMultiplier
+	ChunkMultiplier = 1
+	defer func() { ChunkMultiplier = old_multiplier }()
+
+	v := NewVector[plainPoint]()
+	for i := 0; i < 1000; i++ {
+		v.PushBack(plainPoint{i, i % 3})
+	}
+	if idx := v.ParallelIndexIf(func(p *plainPoint) bool { return p.X == 777 }); idx != 777 {
+		t.Fatalf("ParallelIndexIf should return 777, got %v", idx)
+	}
+	if p := v.ParallelFindIf(func(p *plainPoint) bool { return p.X == 500 }); p == nil || p.X != 500 {
+		t.Fatalf("ParallelFindIf should find element 500, got %v", p)
+	}
+	if v.ParallelContainsIf(func(p *plainPoint) bool { return p.X < 0 }) {
+		t.Fatalf("ParallelContainsIf should return false")
+	}
+	if c := v.ParallelCountIf(func(p *plainPoint) bool { return p.Y == 0 }); c != 334 {
+		t.Fatalf("ParallelCountIf should return 334, got %v", c)
+	}
+}
+
+type taggedPoint struct {
+	X, Y int
+	Tags []string
+}
+
+func TestContainsValueNoEqualer(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: Vector.ContainsValue - no Equaler for element type" {
+			t.Fatalf("Should have panicked because there is no Equaler, got \"%v\"", result)
+		}
+	}()
+	v := NewVectorFromData(taggedPoint{1, 2, nil})
+	v.ContainsValue(taggedPoint{1, 2, nil})
+}
+
+func TestContainsValueInterfaceNoEqualer(t *testing.T) {
+	defer func() {
+		result, _ := recover().(string)
+		if result != "ERROR: Vector.ContainsValue - no Equaler for element type" {
+			t.Fatalf("Should have panicked because there is no Equaler, got \"%v\"", result)
+		}
+	}()
+	v := NewVectorFromData[any](1, []int{2, 3})
+	v.ContainsValue([]int{2, 3})
+}
+
+func TestContainsValueInterfaceEqualer(t *testing.T) {
+	v := NewVectorWithEqualer(DeepEqualer[any](), any(1), any([]int{2, 3}))
+	if !v.ContainsValue([]int{2, 3}) {
+		t.Fatalf("Vector should contain [2 3]")
+	}
+}
+
+func TestContainsValueNative(t *testing.T) {
+	v := NewVectorFromData(1, 2, 3)
+	if !v.ContainsValue(2) {
+		t.Fatalf("Vector should contain 2")
+	}
+	if v.ContainsValue(4) {
+		t.Fatalf("Vector should not contain 4")
+	}
+	if found, idx := v.Search(3); !found || idx != 2 {
+		t.Fatalf("Search should find 3 at 2, got %v at %v", found, idx)
+	}
+	if found, idx := v.OrderedSearch(1); !found || idx != 0 {
+		t.Fatalf("OrderedSearch should find 1 at 0, got %v at %v", found, idx)
+	}
+}
+
+func TestContainsValueEqualer(t *testing.T) {
+	v := NewVectorWithEqualer(DeepEqualer[taggedPoint](), taggedPoint{1, 2, []string{"a"}}, taggedPoint{3, 4, []string{"b"}})
+	if !v.ContainsValue(taggedPoint{3, 4, []string{"b"}}) {
+		t.Fatalf("Vector should contain {3, 4, [b]}")
+	}
+	if p := v.SearchRef(taggedPoint{1, 2, []string{"a"}}); p != v.AtRef(0) {
+		t.Fatalf("SearchRef should return a pointer to element 0, got %v", p)
+	}
+
+	byX := func(left *taggedPoint, right *taggedPoint) bool { return left.X == right.X }
+	v.SetEqualer(byX)
+	if found, idx := v.OrderedSearch(taggedPoint{3, 0, nil}); !found || idx != 1 {
+		t.Fatalf("OrderedSearch should find X == 3 at 1, got %v at %v", found, idx)
+	}
+}
+
+func TestIsVectorPredicateSearcher(t *testing.T) {
+	vec := NewVectorFromData(plainPoint{1, 2})
+
+	if _, isPredicateSearcher := interface{}(&vec).(PredicateSearcher[plainPoint]); !isPredicateSearcher {
+		t.Fatalf("Vector[plainPoint] should be a PredicateSearcher[plainPoint]")
+	}
+}
+
 const vectorItemsCount = 50000000
 
 var vectorSearchIndex int
@@ -745,3 +894,69 @@ func SubBenchmarkRefSearchRef(b *testing.B) {
 	// 	b.Logf("Did not find %v(%v)", *searched_for, searched_for)
 	// }
 }
+
+func TestVectorRanges(t *testing.T) {
+	v := NewVectorFromData(1, 2, 3)
+	v.InsertRange(1, 7, 8)
+	v.AppendVector(NewVectorFromData(4, 5))
+	v.InsertVector(0, NewVectorFromData(0))
+	if v.String() != "{0, 1, 7, 8, 2, 3, 4, 5}" {
+		t.Fatalf("Vector should be {0, 1, 7, 8, 2, 3, 4, 5}, got %v", v.String())
+	}
+	v.EraseRange(2, 4)
+	if v.String() != "{0, 1, 2, 3, 4, 5}" {
+		t.Fatalf("Vector should be {0, 1, 2, 3, 4, 5}, got %v", v.String())
+	}
+	if removed := v.EraseIf(func(value *int) bool { return *value%2 == 1 }); removed != 3 || v.String() != "{0, 2, 4}" {
+		t.Fatalf("EraseIf should remove 3 elements leaving {0, 2, 4}, got %v", v.String())
+	}
+	v.InsertRange(1, v.Data()...)
+	if v.String() != "{0, 0, 2, 4, 2, 4}" {
+		t.Fatalf("InsertRange of the Vector's own Data should work, got %v", v.String())
+	}
+	v.Assign(v.Data()[3:]...)
+	if v.String() != "{4, 2, 4}" {
+		t.Fatalf("Assign should replace the elements with {4, 2, 4}, got %v", v.String())
+	}
+	v.Truncate(1)
+	if v.String() != "{4}" {
+		t.Fatalf("Truncate should leave {4}, got %v", v.String())
+	}
+}
+
+func TestVectorRangesCappedAlias(t *testing.T) {
+	v := NewVectorFromData(1, 2, 3, 4)
+	v.Reserve(16)
+	v.InsertRange(0, v.Data()[1:3:3]...)
+	if v.String() != "{2, 3, 1, 2, 3, 4}" {
+		t.Fatalf("InsertRange of a capped slice of the Vector's own Data should work, got %v", v.String())
+	}
+	v.AppendVector(NewVectorAdoptingSlice(v.Data()[0:2:2]))
+	if v.String() != "{2, 3, 1, 2, 3, 4, 2, 3}" {
+		t.Fatalf("AppendVector of a Vector adopting the Vector's own Data should work, got %v", v.String())
+	}
+	v.InsertVector(1, NewVectorAdoptingSlice(v.Data()[4:6:6]))
+	if v.String() != "{2, 3, 4, 3, 1, 2, 3, 4, 2, 3}" {
+		t.Fatalf("InsertVector of a Vector adopting the Vector's own Data should work, got %v", v.String())
+	}
+	v.Assign(v.Data()[2:4:4]...)
+	if v.String() != "{4, 3}" {
+		t.Fatalf("Assign of a capped slice of the Vector's own Data should work, got %v", v.String())
+	}
+}
+
+func TestVectorRangesDestruct(t *testing.T) {
+	Msgs = []string{}
+	v := NewVectorFromData[DBool](true, false, true, false, true, true)
+	v.EraseRange(0, 2)
+	if len(Msgs) != 2 {
+		t.Fatalf("EraseRange should destruct 2 elements, got %v", len(Msgs))
+	}
+	v.EraseIf(func(value *DBool) bool { return !bool(*value) })
+	v.Truncate(2)
+	v.Assign(true)
+	v.Clear()
+	if len(Msgs) != 7 {
+		t.Fatalf("Destruct method should have been called 7 times, got %v", len(Msgs))
+	}
+}